@@ -0,0 +1,7010 @@
+//protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/proto/votes.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/proto/votes.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An empty category or "all" returns every category; organization_id 0
+// and an empty topic return the votes of every organization and topic.
+// category is the voting mechanism, topic the slug of a subject.
+type GetVotesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Category       string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	OrganizationId int32                  `protobuf:"varint,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Topic          string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetVotesRequest) Reset() {
+	*x = GetVotesRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVotesRequest) ProtoMessage() {}
+
+func (x *GetVotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVotesRequest.ProtoReflect.Descriptor instead.
+func (*GetVotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetVotesRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *GetVotesRequest) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+func (x *GetVotesRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+type GetVotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*Vote                `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVotesResponse) Reset() {
+	*x = GetVotesResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVotesResponse) ProtoMessage() {}
+
+func (x *GetVotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVotesResponse.ProtoReflect.Descriptor instead.
+func (*GetVotesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetVotesResponse) GetResponse() []*Vote {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type Vote struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category       string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization   string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Options        []string               `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Photo          string                 `protobuf:"bytes,8,opt,name=photo,proto3" json:"photo,omitempty"`
+	OptionDetails  []*Option              `protobuf:"bytes,9,rep,name=option_details,json=optionDetails,proto3" json:"option_details,omitempty"`
+	Status         string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	MinSelections  int32                  `protobuf:"varint,11,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	MaxSelections  int32                  `protobuf:"varint,12,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	Budget         int32                  `protobuf:"varint,13,opt,name=budget,proto3" json:"budget,omitempty"`
+	OrganizationId int32                  `protobuf:"varint,14,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Topics         []string               `protobuf:"bytes,15,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Vote) Reset() {
+	*x = Vote{}
+	mi := &file_api_proto_votes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vote) ProtoMessage() {}
+
+func (x *Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vote.ProtoReflect.Descriptor instead.
+func (*Vote) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Vote) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Vote) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Vote) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Vote) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Vote) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *Vote) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Vote) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Vote) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *Vote) GetOptionDetails() []*Option {
+	if x != nil {
+		return x.OptionDetails
+	}
+	return nil
+}
+
+func (x *Vote) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Vote) GetMinSelections() int32 {
+	if x != nil {
+		return x.MinSelections
+	}
+	return 0
+}
+
+func (x *Vote) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
+	}
+	return 0
+}
+
+func (x *Vote) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *Vote) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+func (x *Vote) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type Option struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Image         string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Position      int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Option) Reset() {
+	*x = Option{}
+	mi := &file_api_proto_votes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Option) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Option) ProtoMessage() {}
+
+func (x *Option) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Option.ProtoReflect.Descriptor instead.
+func (*Option) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Option) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Option) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Option) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Option) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Option) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type GetCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesRequest) Reset() {
+	*x = GetCategoriesRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesRequest) ProtoMessage() {}
+
+func (x *GetCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{4}
+}
+
+type GetCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []string               `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesResponse) Reset() {
+	*x = GetCategoriesResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesResponse) ProtoMessage() {}
+
+func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCategoriesResponse) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// query uses the web search syntax: words must all match, "or" separates
+// alternatives and a leading minus excludes a word. An empty category or
+// "all" searches every category; limit defaults to 20 and is capped at 100.
+type SearchVotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchVotesRequest) Reset() {
+	*x = SearchVotesRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchVotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchVotesRequest) ProtoMessage() {}
+
+func (x *SearchVotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchVotesRequest.ProtoReflect.Descriptor instead.
+func (*SearchVotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchVotesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchVotesRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchVotesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchVotesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// fuzzy is set when no vote matched the query itself and the results are
+// votes with a similarly spelled name instead.
+type SearchVotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*SearchResult        `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	Fuzzy         bool                   `protobuf:"varint,2,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchVotesResponse) Reset() {
+	*x = SearchVotesResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchVotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchVotesResponse) ProtoMessage() {}
+
+func (x *SearchVotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchVotesResponse.ProtoReflect.Descriptor instead.
+func (*SearchVotesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchVotesResponse) GetResponse() []*SearchResult {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SearchVotesResponse) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+// headline is the vote name and snippet a fragment of its description,
+// both as HTML: the text is escaped and words matching the query are
+// wrapped in <b> and </b>.
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vote          *Vote                  `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	Rank          float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Headline      string                 `protobuf:"bytes,3,opt,name=headline,proto3" json:"headline,omitempty"`
+	Snippet       string                 `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_api_proto_votes_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchResult) GetVote() *Vote {
+	if x != nil {
+		return x.Vote
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type GetVoteInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVoteInfoRequest) Reset() {
+	*x = GetVoteInfoRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVoteInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoteInfoRequest) ProtoMessage() {}
+
+func (x *GetVoteInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoteInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetVoteInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetVoteInfoRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *GetVoteInfoRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetRateInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *VoteInfo              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRateInfoResponse) Reset() {
+	*x = GetRateInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateInfoResponse) ProtoMessage() {}
+
+func (x *GetRateInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetRateInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRateInfoResponse) GetResponse() *VoteInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GetPetitionInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *PetitionInfo          `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPetitionInfoResponse) Reset() {
+	*x = GetPetitionInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPetitionInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPetitionInfoResponse) ProtoMessage() {}
+
+func (x *GetPetitionInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPetitionInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetPetitionInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPetitionInfoResponse) GetResponse() *PetitionInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GetChoiceInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *ChoiceInfo            `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChoiceInfoResponse) Reset() {
+	*x = GetChoiceInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChoiceInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChoiceInfoResponse) ProtoMessage() {}
+
+func (x *GetChoiceInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChoiceInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetChoiceInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetChoiceInfoResponse) GetResponse() *ChoiceInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type VoteInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category     string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Options      []string               `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Photo        string                 `protobuf:"bytes,8,opt,name=photo,proto3" json:"photo,omitempty"`
+	Mid          float32                `protobuf:"fixed32,9,opt,name=mid,proto3" json:"mid,omitempty"`
+	Rate         float32                `protobuf:"fixed32,10,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Follow-ups triggered by the caller's rating and not answered yet.
+	PendingFollowUps []*FollowUp `protobuf:"bytes,11,rep,name=pending_follow_ups,json=pendingFollowUps,proto3" json:"pending_follow_ups,omitempty"`
+	// The caller's own comment with its moderation status, and the newest
+	// approved comments of everyone.
+	Comment  *Comment   `protobuf:"bytes,12,opt,name=comment,proto3" json:"comment,omitempty"`
+	Comments []*Comment `protobuf:"bytes,13,rep,name=comments,proto3" json:"comments,omitempty"`
+	// The caller's latest ballot change; unset before their first ballot.
+	LastChange    *BallotChange `protobuf:"bytes,14,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteInfo) Reset() {
+	*x = VoteInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteInfo) ProtoMessage() {}
+
+func (x *VoteInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteInfo.ProtoReflect.Descriptor instead.
+func (*VoteInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VoteInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VoteInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *VoteInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VoteInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *VoteInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *VoteInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *VoteInfo) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *VoteInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *VoteInfo) GetMid() float32 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *VoteInfo) GetRate() float32 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *VoteInfo) GetPendingFollowUps() []*FollowUp {
+	if x != nil {
+		return x.PendingFollowUps
+	}
+	return nil
+}
+
+func (x *VoteInfo) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+func (x *VoteInfo) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *VoteInfo) GetLastChange() *BallotChange {
+	if x != nil {
+		return x.LastChange
+	}
+	return nil
+}
+
+type PetitionInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category     string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Options      []string               `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Photo        string                 `protobuf:"bytes,8,opt,name=photo,proto3" json:"photo,omitempty"`
+	Stats        map[string]int32       `protobuf:"bytes,9,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Support      string                 `protobuf:"bytes,10,opt,name=support,proto3" json:"support,omitempty"`
+	// open, closed or awaiting_response once the signature goal is reached.
+	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	// 0 when the petition has no goal; signatures counts "for" support.
+	SignatureGoal int32 `protobuf:"varint,12,opt,name=signature_goal,json=signatureGoal,proto3" json:"signature_goal,omitempty"`
+	Signatures    int32 `protobuf:"varint,13,opt,name=signatures,proto3" json:"signatures,omitempty"`
+	// Unset until the organization has answered.
+	OfficialResponse *PetitionResponse `protobuf:"bytes,14,opt,name=official_response,json=officialResponse,proto3" json:"official_response,omitempty"`
+	// Set once the signature goal is reached; response_overdue stays true
+	// when the response came after response_due.
+	ResponseDue     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=response_due,json=responseDue,proto3" json:"response_due,omitempty"`
+	ResponseOverdue bool                   `protobuf:"varint,16,opt,name=response_overdue,json=responseOverdue,proto3" json:"response_overdue,omitempty"`
+	LastChange      *BallotChange          `protobuf:"bytes,17,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PetitionInfo) Reset() {
+	*x = PetitionInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PetitionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PetitionInfo) ProtoMessage() {}
+
+func (x *PetitionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PetitionInfo.ProtoReflect.Descriptor instead.
+func (*PetitionInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PetitionInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PetitionInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *PetitionInfo) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PetitionInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetStats() map[string]int32 {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PetitionInfo) GetSupport() string {
+	if x != nil {
+		return x.Support
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PetitionInfo) GetSignatureGoal() int32 {
+	if x != nil {
+		return x.SignatureGoal
+	}
+	return 0
+}
+
+func (x *PetitionInfo) GetSignatures() int32 {
+	if x != nil {
+		return x.Signatures
+	}
+	return 0
+}
+
+func (x *PetitionInfo) GetOfficialResponse() *PetitionResponse {
+	if x != nil {
+		return x.OfficialResponse
+	}
+	return nil
+}
+
+func (x *PetitionInfo) GetResponseDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResponseDue
+	}
+	return nil
+}
+
+func (x *PetitionInfo) GetResponseOverdue() bool {
+	if x != nil {
+		return x.ResponseOverdue
+	}
+	return false
+}
+
+func (x *PetitionInfo) GetLastChange() *BallotChange {
+	if x != nil {
+		return x.LastChange
+	}
+	return nil
+}
+
+// PetitionResponse is the official answer to a petition. decision is
+// "accepted", "partially_accepted" or "rejected".
+type PetitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decision      string                 `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Organization  string                 `protobuf:"bytes,3,opt,name=organization,proto3" json:"organization,omitempty"`
+	Attachments   []*Attachment          `protobuf:"bytes,4,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Responded     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=responded,proto3" json:"responded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PetitionResponse) Reset() {
+	*x = PetitionResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PetitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PetitionResponse) ProtoMessage() {}
+
+func (x *PetitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PetitionResponse.ProtoReflect.Descriptor instead.
+func (*PetitionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PetitionResponse) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *PetitionResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PetitionResponse) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *PetitionResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *PetitionResponse) GetResponded() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Responded
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_api_proto_votes_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ChoiceInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category     string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Options      []string               `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Photo        string                 `protobuf:"bytes,8,opt,name=photo,proto3" json:"photo,omitempty"`
+	Stats        map[string]int32       `protobuf:"bytes,9,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// choice and option_id hold the first selected option for clients that
+	// predate approval votes; option_ids and choices hold the full selection.
+	Choice        string    `protobuf:"bytes,10,opt,name=choice,proto3" json:"choice,omitempty"`
+	OptionDetails []*Option `protobuf:"bytes,11,rep,name=option_details,json=optionDetails,proto3" json:"option_details,omitempty"`
+	OptionId      int32     `protobuf:"varint,12,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	OptionIds     []int32   `protobuf:"varint,13,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	Choices       []string  `protobuf:"bytes,14,rep,name=choices,proto3" json:"choices,omitempty"`
+	MinSelections int32     `protobuf:"varint,15,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	MaxSelections int32     `protobuf:"varint,16,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	Ballots       int32     `protobuf:"varint,17,opt,name=ballots,proto3" json:"ballots,omitempty"`
+	// Follow-ups triggered by the caller's selection and not answered yet.
+	PendingFollowUps []*FollowUp   `protobuf:"bytes,18,rep,name=pending_follow_ups,json=pendingFollowUps,proto3" json:"pending_follow_ups,omitempty"`
+	Comment          *Comment      `protobuf:"bytes,19,opt,name=comment,proto3" json:"comment,omitempty"`
+	Comments         []*Comment    `protobuf:"bytes,20,rep,name=comments,proto3" json:"comments,omitempty"`
+	LastChange       *BallotChange `protobuf:"bytes,21,opt,name=last_change,json=lastChange,proto3" json:"last_change,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChoiceInfo) Reset() {
+	*x = ChoiceInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChoiceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChoiceInfo) ProtoMessage() {}
+
+func (x *ChoiceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChoiceInfo.ProtoReflect.Descriptor instead.
+func (*ChoiceInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChoiceInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChoiceInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetStats() map[string]int32 {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetChoice() string {
+	if x != nil {
+		return x.Choice
+	}
+	return ""
+}
+
+func (x *ChoiceInfo) GetOptionDetails() []*Option {
+	if x != nil {
+		return x.OptionDetails
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *ChoiceInfo) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetChoices() []string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetMinSelections() int32 {
+	if x != nil {
+		return x.MinSelections
+	}
+	return 0
+}
+
+func (x *ChoiceInfo) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
+	}
+	return 0
+}
+
+func (x *ChoiceInfo) GetBallots() int32 {
+	if x != nil {
+		return x.Ballots
+	}
+	return 0
+}
+
+func (x *ChoiceInfo) GetPendingFollowUps() []*FollowUp {
+	if x != nil {
+		return x.PendingFollowUps
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ChoiceInfo) GetLastChange() *BallotChange {
+	if x != nil {
+		return x.LastChange
+	}
+	return nil
+}
+
+type GetRankedInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *RankedInfo            `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankedInfoResponse) Reset() {
+	*x = GetRankedInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankedInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankedInfoResponse) ProtoMessage() {}
+
+func (x *GetRankedInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankedInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetRankedInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetRankedInfoResponse) GetResponse() *RankedInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// RankedInfo carries the instant-runoff tally of a ranked vote. ranking is
+// the caller's own ballot, most preferred option first.
+type RankedInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization  string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Photo         string                 `protobuf:"bytes,7,opt,name=photo,proto3" json:"photo,omitempty"`
+	Options       []*Option              `protobuf:"bytes,8,rep,name=options,proto3" json:"options,omitempty"`
+	Ballots       int32                  `protobuf:"varint,9,opt,name=ballots,proto3" json:"ballots,omitempty"`
+	Rounds        []*RankedRound         `protobuf:"bytes,10,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	WinnerId      int32                  `protobuf:"varint,11,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	Ranking       []int32                `protobuf:"varint,12,rep,packed,name=ranking,proto3" json:"ranking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedInfo) Reset() {
+	*x = RankedInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedInfo) ProtoMessage() {}
+
+func (x *RankedInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedInfo.ProtoReflect.Descriptor instead.
+func (*RankedInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RankedInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RankedInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *RankedInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RankedInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RankedInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *RankedInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *RankedInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *RankedInfo) GetOptions() []*Option {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *RankedInfo) GetBallots() int32 {
+	if x != nil {
+		return x.Ballots
+	}
+	return 0
+}
+
+func (x *RankedInfo) GetRounds() []*RankedRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *RankedInfo) GetWinnerId() int32 {
+	if x != nil {
+		return x.WinnerId
+	}
+	return 0
+}
+
+func (x *RankedInfo) GetRanking() []int32 {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+// RankedRound lists the votes of every option still in the count, in display
+// order. winner_id is set in the final round only.
+type RankedRound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Tallies       []*OptionTally         `protobuf:"bytes,2,rep,name=tallies,proto3" json:"tallies,omitempty"`
+	Exhausted     int32                  `protobuf:"varint,3,opt,name=exhausted,proto3" json:"exhausted,omitempty"`
+	Eliminated    []int32                `protobuf:"varint,4,rep,packed,name=eliminated,proto3" json:"eliminated,omitempty"`
+	WinnerId      int32                  `protobuf:"varint,5,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedRound) Reset() {
+	*x = RankedRound{}
+	mi := &file_api_proto_votes_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedRound) ProtoMessage() {}
+
+func (x *RankedRound) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedRound.ProtoReflect.Descriptor instead.
+func (*RankedRound) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RankedRound) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *RankedRound) GetTallies() []*OptionTally {
+	if x != nil {
+		return x.Tallies
+	}
+	return nil
+}
+
+func (x *RankedRound) GetExhausted() int32 {
+	if x != nil {
+		return x.Exhausted
+	}
+	return 0
+}
+
+func (x *RankedRound) GetEliminated() []int32 {
+	if x != nil {
+		return x.Eliminated
+	}
+	return nil
+}
+
+func (x *RankedRound) GetWinnerId() int32 {
+	if x != nil {
+		return x.WinnerId
+	}
+	return 0
+}
+
+type OptionTally struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      int32                  `protobuf:"varint,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Votes         int32                  `protobuf:"varint,2,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionTally) Reset() {
+	*x = OptionTally{}
+	mi := &file_api_proto_votes_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionTally) ProtoMessage() {}
+
+func (x *OptionTally) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionTally.ProtoReflect.Descriptor instead.
+func (*OptionTally) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OptionTally) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *OptionTally) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type GetAllocationInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *AllocationInfo        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllocationInfoResponse) Reset() {
+	*x = GetAllocationInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllocationInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllocationInfoResponse) ProtoMessage() {}
+
+func (x *GetAllocationInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllocationInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetAllocationInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAllocationInfoResponse) GetResponse() *AllocationInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// AllocationInfo carries the tally of a score or quadratic vote. allocations
+// is the caller's own ballot and spent the part of the budget it uses.
+type AllocationInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization  string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Photo         string                 `protobuf:"bytes,7,opt,name=photo,proto3" json:"photo,omitempty"`
+	Options       []*Option              `protobuf:"bytes,8,rep,name=options,proto3" json:"options,omitempty"`
+	Budget        int32                  `protobuf:"varint,9,opt,name=budget,proto3" json:"budget,omitempty"`
+	Ballots       int32                  `protobuf:"varint,10,opt,name=ballots,proto3" json:"ballots,omitempty"`
+	Results       []*OptionAllocation    `protobuf:"bytes,11,rep,name=results,proto3" json:"results,omitempty"`
+	Allocations   []*Allocation          `protobuf:"bytes,12,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	Spent         int32                  `protobuf:"varint,13,opt,name=spent,proto3" json:"spent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationInfo) Reset() {
+	*x = AllocationInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationInfo) ProtoMessage() {}
+
+func (x *AllocationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationInfo.ProtoReflect.Descriptor instead.
+func (*AllocationInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AllocationInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AllocationInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AllocationInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AllocationInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AllocationInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *AllocationInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *AllocationInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *AllocationInfo) GetOptions() []*Option {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *AllocationInfo) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *AllocationInfo) GetBallots() int32 {
+	if x != nil {
+		return x.Ballots
+	}
+	return 0
+}
+
+func (x *AllocationInfo) GetResults() []*OptionAllocation {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *AllocationInfo) GetAllocations() []*Allocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *AllocationInfo) GetSpent() int32 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+// OptionAllocation is the tally of one option: total sums the points (score)
+// or votes (quadratic) it received and credits their cost. distribution
+// counts the ballots per allocated amount, ordered by amount.
+type OptionAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      int32                  `protobuf:"varint,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Credits       int32                  `protobuf:"varint,3,opt,name=credits,proto3" json:"credits,omitempty"`
+	Supporters    int32                  `protobuf:"varint,4,opt,name=supporters,proto3" json:"supporters,omitempty"`
+	Distribution  []*AllocationCount     `protobuf:"bytes,5,rep,name=distribution,proto3" json:"distribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionAllocation) Reset() {
+	*x = OptionAllocation{}
+	mi := &file_api_proto_votes_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionAllocation) ProtoMessage() {}
+
+func (x *OptionAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionAllocation.ProtoReflect.Descriptor instead.
+func (*OptionAllocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OptionAllocation) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *OptionAllocation) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *OptionAllocation) GetCredits() int32 {
+	if x != nil {
+		return x.Credits
+	}
+	return 0
+}
+
+func (x *OptionAllocation) GetSupporters() int32 {
+	if x != nil {
+		return x.Supporters
+	}
+	return 0
+}
+
+func (x *OptionAllocation) GetDistribution() []*AllocationCount {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+type AllocationCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        int32                  `protobuf:"varint,1,opt,name=points,proto3" json:"points,omitempty"`
+	Ballots       int32                  `protobuf:"varint,2,opt,name=ballots,proto3" json:"ballots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationCount) Reset() {
+	*x = AllocationCount{}
+	mi := &file_api_proto_votes_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationCount) ProtoMessage() {}
+
+func (x *AllocationCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationCount.ProtoReflect.Descriptor instead.
+func (*AllocationCount) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AllocationCount) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *AllocationCount) GetBallots() int32 {
+	if x != nil {
+		return x.Ballots
+	}
+	return 0
+}
+
+// Allocation puts points (score) or votes (quadratic) on one option. A
+// quadratic ballot costs the sum of the squared votes.
+type Allocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OptionId      int32                  `protobuf:"varint,1,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	Points        int32                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Allocation) Reset() {
+	*x = Allocation{}
+	mi := &file_api_proto_votes_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Allocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Allocation) ProtoMessage() {}
+
+func (x *Allocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allocation.ProtoReflect.Descriptor instead.
+func (*Allocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Allocation) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *Allocation) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type GetSurveyInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *SurveyInfo            `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSurveyInfoResponse) Reset() {
+	*x = GetSurveyInfoResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSurveyInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSurveyInfoResponse) ProtoMessage() {}
+
+func (x *GetSurveyInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSurveyInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSurveyInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetSurveyInfoResponse) GetResponse() *SurveyInfo {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// SurveyInfo carries the questions of a survey and the results over the
+// submitted responses. answers is the caller's own response, a draft unless
+// submitted is set.
+type SurveyInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization  string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Photo         string                 `protobuf:"bytes,7,opt,name=photo,proto3" json:"photo,omitempty"`
+	Questions     []*Question            `protobuf:"bytes,8,rep,name=questions,proto3" json:"questions,omitempty"`
+	Responses     int32                  `protobuf:"varint,9,opt,name=responses,proto3" json:"responses,omitempty"`
+	Results       []*QuestionResult      `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	Answers       []*SurveyAnswer        `protobuf:"bytes,11,rep,name=answers,proto3" json:"answers,omitempty"`
+	Submitted     bool                   `protobuf:"varint,12,opt,name=submitted,proto3" json:"submitted,omitempty"`
+	Updated       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurveyInfo) Reset() {
+	*x = SurveyInfo{}
+	mi := &file_api_proto_votes_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurveyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveyInfo) ProtoMessage() {}
+
+func (x *SurveyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveyInfo.ProtoReflect.Descriptor instead.
+func (*SurveyInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SurveyInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SurveyInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SurveyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SurveyInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SurveyInfo) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *SurveyInfo) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *SurveyInfo) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *SurveyInfo) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *SurveyInfo) GetResponses() int32 {
+	if x != nil {
+		return x.Responses
+	}
+	return 0
+}
+
+func (x *SurveyInfo) GetResults() []*QuestionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SurveyInfo) GetAnswers() []*SurveyAnswer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *SurveyInfo) GetSubmitted() bool {
+	if x != nil {
+		return x.Submitted
+	}
+	return false
+}
+
+func (x *SurveyInfo) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+// Question is one question of a survey. kind is rating, single, multi or
+// text; rating questions use rate_scale, single and multi use options.
+type Question struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	Options       []*Option              `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+	RateScale     *RateScale             `protobuf:"bytes,7,opt,name=rate_scale,json=rateScale,proto3" json:"rate_scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	mi := &file_api_proto_votes_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Question) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Question) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Question) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Question) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Question) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Question) GetOptions() []*Option {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Question) GetRateScale() *RateScale {
+	if x != nil {
+		return x.RateScale
+	}
+	return nil
+}
+
+// QuestionResult aggregates the submitted answers to one question: ratings
+// and average for rating questions, options for choice questions. Free-text
+// answers are only counted.
+type QuestionResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    int32                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Answers       int32                  `protobuf:"varint,2,opt,name=answers,proto3" json:"answers,omitempty"`
+	Average       float32                `protobuf:"fixed32,3,opt,name=average,proto3" json:"average,omitempty"`
+	Ratings       []*RatingCount         `protobuf:"bytes,4,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	Options       []*OptionTally         `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuestionResult) Reset() {
+	*x = QuestionResult{}
+	mi := &file_api_proto_votes_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionResult) ProtoMessage() {}
+
+func (x *QuestionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionResult.ProtoReflect.Descriptor instead.
+func (*QuestionResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *QuestionResult) GetQuestionId() int32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *QuestionResult) GetAnswers() int32 {
+	if x != nil {
+		return x.Answers
+	}
+	return 0
+}
+
+func (x *QuestionResult) GetAverage() float32 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *QuestionResult) GetRatings() []*RatingCount {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+func (x *QuestionResult) GetOptions() []*OptionTally {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type RatingCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        int32                  `protobuf:"varint,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	Answers       int32                  `protobuf:"varint,2,opt,name=answers,proto3" json:"answers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingCount) Reset() {
+	*x = RatingCount{}
+	mi := &file_api_proto_votes_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingCount) ProtoMessage() {}
+
+func (x *RatingCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingCount.ProtoReflect.Descriptor instead.
+func (*RatingCount) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RatingCount) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RatingCount) GetAnswers() int32 {
+	if x != nil {
+		return x.Answers
+	}
+	return 0
+}
+
+// SurveyAnswer answers one question with the field matching its kind.
+type SurveyAnswer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    int32                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Rating        *int32                 `protobuf:"varint,2,opt,name=rating,proto3,oneof" json:"rating,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SurveyAnswer) Reset() {
+	*x = SurveyAnswer{}
+	mi := &file_api_proto_votes_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SurveyAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveyAnswer) ProtoMessage() {}
+
+func (x *SurveyAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveyAnswer.ProtoReflect.Descriptor instead.
+func (*SurveyAnswer) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SurveyAnswer) GetQuestionId() int32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *SurveyAnswer) GetRating() int32 {
+	if x != nil && x.Rating != nil {
+		return *x.Rating
+	}
+	return 0
+}
+
+func (x *SurveyAnswer) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+func (x *SurveyAnswer) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// FollowUp is a question asked after a rate or choice ballot that fires its
+// trigger. Its kind, options and rate_scale work like those of a survey
+// Question.
+type FollowUp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Options       []*Option              `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	RateScale     *RateScale             `protobuf:"bytes,6,opt,name=rate_scale,json=rateScale,proto3" json:"rate_scale,omitempty"`
+	Trigger       *FollowUpTrigger       `protobuf:"bytes,7,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowUp) Reset() {
+	*x = FollowUp{}
+	mi := &file_api_proto_votes_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowUp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowUp) ProtoMessage() {}
+
+func (x *FollowUp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowUp.ProtoReflect.Descriptor instead.
+func (*FollowUp) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FollowUp) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FollowUp) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *FollowUp) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FollowUp) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FollowUp) GetOptions() []*Option {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *FollowUp) GetRateScale() *RateScale {
+	if x != nil {
+		return x.RateScale
+	}
+	return nil
+}
+
+func (x *FollowUp) GetTrigger() *FollowUpTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+// FollowUpTrigger fires on a rating from min_rating to max_rating in a rate
+// vote, or on selecting any of the options in a choice vote. When defining
+// a vote, options may be named by text in options; responses always carry
+// option_ids.
+type FollowUpTrigger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinRating     int32                  `protobuf:"varint,1,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`
+	MaxRating     int32                  `protobuf:"varint,2,opt,name=max_rating,json=maxRating,proto3" json:"max_rating,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	Options       []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowUpTrigger) Reset() {
+	*x = FollowUpTrigger{}
+	mi := &file_api_proto_votes_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowUpTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowUpTrigger) ProtoMessage() {}
+
+func (x *FollowUpTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowUpTrigger.ProtoReflect.Descriptor instead.
+func (*FollowUpTrigger) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FollowUpTrigger) GetMinRating() int32 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+func (x *FollowUpTrigger) GetMaxRating() int32 {
+	if x != nil {
+		return x.MaxRating
+	}
+	return 0
+}
+
+func (x *FollowUpTrigger) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+func (x *FollowUpTrigger) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// comment is optional free text published after moderation; leaving it
+// empty keeps the comment sent with an earlier ballot.
+type VoteRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Rating        float32                `protobuf:"fixed32,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteRateRequest) Reset() {
+	*x = VoteRateRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteRateRequest) ProtoMessage() {}
+
+func (x *VoteRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRateRequest.ProtoReflect.Descriptor instead.
+func (*VoteRateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VoteRateRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VoteRateRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *VoteRateRequest) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *VoteRateRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type VotePetitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Support       string                 `protobuf:"bytes,3,opt,name=support,proto3" json:"support,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePetitionRequest) Reset() {
+	*x = VotePetitionRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePetitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePetitionRequest) ProtoMessage() {}
+
+func (x *VotePetitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePetitionRequest.ProtoReflect.Descriptor instead.
+func (*VotePetitionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VotePetitionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VotePetitionRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *VotePetitionRequest) GetSupport() string {
+	if x != nil {
+		return x.Support
+	}
+	return ""
+}
+
+// option_ids takes precedence over option_id, which takes precedence over
+// choice; choice is matched against the option text for clients that
+// predate option IDs. Approval votes need option_ids to select more than
+// one option. comment works as in VoteRateRequest.
+type VoteChoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Choice        string                 `protobuf:"bytes,3,opt,name=choice,proto3" json:"choice,omitempty"`
+	OptionId      int32                  `protobuf:"varint,4,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,5,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	Comment       string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteChoiceRequest) Reset() {
+	*x = VoteChoiceRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteChoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteChoiceRequest) ProtoMessage() {}
+
+func (x *VoteChoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteChoiceRequest.ProtoReflect.Descriptor instead.
+func (*VoteChoiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VoteChoiceRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VoteChoiceRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *VoteChoiceRequest) GetChoice() string {
+	if x != nil {
+		return x.Choice
+	}
+	return ""
+}
+
+func (x *VoteChoiceRequest) GetOptionId() int32 {
+	if x != nil {
+		return x.OptionId
+	}
+	return 0
+}
+
+func (x *VoteChoiceRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+func (x *VoteChoiceRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// option_ids ranks options from most to least preferred; options may be
+// left out.
+type VoteRankedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteRankedRequest) Reset() {
+	*x = VoteRankedRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteRankedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteRankedRequest) ProtoMessage() {}
+
+func (x *VoteRankedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteRankedRequest.ProtoReflect.Descriptor instead.
+func (*VoteRankedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VoteRankedRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VoteRankedRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *VoteRankedRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type VoteAllocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Allocations   []*Allocation          `protobuf:"bytes,3,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteAllocationRequest) Reset() {
+	*x = VoteAllocationRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteAllocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteAllocationRequest) ProtoMessage() {}
+
+func (x *VoteAllocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteAllocationRequest.ProtoReflect.Descriptor instead.
+func (*VoteAllocationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VoteAllocationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VoteAllocationRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *VoteAllocationRequest) GetAllocations() []*Allocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+// SubmitSurveyRequest replaces the caller's whole response. With draft set
+// required questions may be left out and the response is not counted until
+// it is submitted; a submitted response cannot go back to being a draft.
+type SubmitSurveyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Answers       []*SurveyAnswer        `protobuf:"bytes,3,rep,name=answers,proto3" json:"answers,omitempty"`
+	Draft         bool                   `protobuf:"varint,4,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitSurveyRequest) Reset() {
+	*x = SubmitSurveyRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitSurveyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitSurveyRequest) ProtoMessage() {}
+
+func (x *SubmitSurveyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitSurveyRequest.ProtoReflect.Descriptor instead.
+func (*SubmitSurveyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SubmitSurveyRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SubmitSurveyRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *SubmitSurveyRequest) GetAnswers() []*SurveyAnswer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *SubmitSurveyRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+// AnswerFollowUpsRequest answers follow-ups triggered by the caller's
+// ballot; question_id of each answer is the follow-up ID. Answers to other
+// follow-ups are kept.
+type AnswerFollowUpsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Answers       []*SurveyAnswer        `protobuf:"bytes,3,rep,name=answers,proto3" json:"answers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerFollowUpsRequest) Reset() {
+	*x = AnswerFollowUpsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerFollowUpsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerFollowUpsRequest) ProtoMessage() {}
+
+func (x *AnswerFollowUpsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerFollowUpsRequest.ProtoReflect.Descriptor instead.
+func (*AnswerFollowUpsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AnswerFollowUpsRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AnswerFollowUpsRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *AnswerFollowUpsRequest) GetAnswers() []*SurveyAnswer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+// WithdrawVoteRequest takes back the caller's ballot in a vote of any type
+// while the vote still accepts ballots. Follow-up answers and the comment of
+// the ballot are removed with it.
+type WithdrawVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawVoteRequest) Reset() {
+	*x = WithdrawVoteRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawVoteRequest) ProtoMessage() {}
+
+func (x *WithdrawVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawVoteRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WithdrawVoteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *WithdrawVoteRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+// BallotChange records when the caller cast, changed or withdrew their
+// ballot; action is "cast", "change" or "withdraw".
+type BallotChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Changed       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BallotChange) Reset() {
+	*x = BallotChange{}
+	mi := &file_api_proto_votes_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BallotChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallotChange) ProtoMessage() {}
+
+func (x *BallotChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallotChange.ProtoReflect.Descriptor instead.
+func (*BallotChange) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BallotChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BallotChange) GetChanged() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+type VoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      string                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteResponse) Reset() {
+	*x = VoteResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteResponse) ProtoMessage() {}
+
+func (x *VoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteResponse.ProtoReflect.Descriptor instead.
+func (*VoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VoteResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+type HealthCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckRequest) Reset() {
+	*x = HealthCheckRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckRequest) ProtoMessage() {}
+
+func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
+func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{45}
+}
+
+type HealthCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsHealthy     bool                   `protobuf:"varint,1,opt,name=is_healthy,json=isHealthy,proto3" json:"is_healthy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheckResponse) Reset() {
+	*x = HealthCheckResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheckResponse) ProtoMessage() {}
+
+func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
+func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *HealthCheckResponse) GetIsHealthy() bool {
+	if x != nil {
+		return x.IsHealthy
+	}
+	return false
+}
+
+type VoteDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Organization  string                 `protobuf:"bytes,5,opt,name=organization,proto3" json:"organization,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	Options       []string               `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Photo         string                 `protobuf:"bytes,8,opt,name=photo,proto3" json:"photo,omitempty"`
+	ExternalKey   string                 `protobuf:"bytes,9,opt,name=external_key,json=externalKey,proto3" json:"external_key,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=start,proto3" json:"start,omitempty"`
+	RateScale     *RateScale             `protobuf:"bytes,11,opt,name=rate_scale,json=rateScale,proto3" json:"rate_scale,omitempty"`
+	OptionDetails []*Option              `protobuf:"bytes,12,rep,name=option_details,json=optionDetails,proto3" json:"option_details,omitempty"`
+	// draft, scheduled or open on creation; ignored by UpdateVote, use
+	// SetVoteStatus to move a vote between states.
+	Status string `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	// Choice votes only; both default to 1, a single choice.
+	MinSelections int32 `protobuf:"varint,14,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	MaxSelections int32 `protobuf:"varint,15,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	// Score and quadratic votes only: points or credits per ballot.
+	Budget int32 `protobuf:"varint,16,opt,name=budget,proto3" json:"budget,omitempty"`
+	// Survey votes only, in display order.
+	Questions []*Question `protobuf:"bytes,17,rep,name=questions,proto3" json:"questions,omitempty"`
+	// Rate and choice votes only, in display order.
+	FollowUps []*FollowUp `protobuf:"bytes,18,rep,name=follow_ups,json=followUps,proto3" json:"follow_ups,omitempty"`
+	// Petitions only: signatures after which the petition awaits an official
+	// response; 0 means no goal.
+	SignatureGoal int32 `protobuf:"varint,19,opt,name=signature_goal,json=signatureGoal,proto3" json:"signature_goal,omitempty"`
+	// Why a moderator rejected a submitted petition; ignored on input.
+	ReviewReason string `protobuf:"bytes,20,opt,name=review_reason,json=reviewReason,proto3" json:"review_reason,omitempty"`
+	// Petitions that reached their goal only; ignored on input.
+	ResponseDue     *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=response_due,json=responseDue,proto3" json:"response_due,omitempty"`
+	ResponseOverdue bool                   `protobuf:"varint,22,opt,name=response_overdue,json=responseOverdue,proto3" json:"response_overdue,omitempty"`
+	// Takes precedence over organization when set. Otherwise organization is
+	// matched by name ignoring case and created if it does not exist yet.
+	OrganizationId int32 `protobuf:"varint,23,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	// Topic slugs, at most 5; every topic must exist.
+	Topics        []string `protobuf:"bytes,24,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteDefinition) Reset() {
+	*x = VoteDefinition{}
+	mi := &file_api_proto_votes_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteDefinition) ProtoMessage() {}
+
+func (x *VoteDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteDefinition.ProtoReflect.Descriptor instead.
+func (*VoteDefinition) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VoteDefinition) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetExternalKey() string {
+	if x != nil {
+		return x.ExternalKey
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetRateScale() *RateScale {
+	if x != nil {
+		return x.RateScale
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetOptionDetails() []*Option {
+	if x != nil {
+		return x.OptionDetails
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetMinSelections() int32 {
+	if x != nil {
+		return x.MinSelections
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetFollowUps() []*FollowUp {
+	if x != nil {
+		return x.FollowUps
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetSignatureGoal() int32 {
+	if x != nil {
+		return x.SignatureGoal
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetReviewReason() string {
+	if x != nil {
+		return x.ReviewReason
+	}
+	return ""
+}
+
+func (x *VoteDefinition) GetResponseDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResponseDue
+	}
+	return nil
+}
+
+func (x *VoteDefinition) GetResponseOverdue() bool {
+	if x != nil {
+		return x.ResponseOverdue
+	}
+	return false
+}
+
+func (x *VoteDefinition) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+func (x *VoteDefinition) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+type RateScale struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           int32                  `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           int32                  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	Step          int32                  `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateScale) Reset() {
+	*x = RateScale{}
+	mi := &file_api_proto_votes_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateScale) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateScale) ProtoMessage() {}
+
+func (x *RateScale) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateScale.ProtoReflect.Descriptor instead.
+func (*RateScale) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RateScale) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *RateScale) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *RateScale) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type CreateVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vote          *VoteDefinition        `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVoteRequest) Reset() {
+	*x = CreateVoteRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVoteRequest) ProtoMessage() {}
+
+func (x *CreateVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateVoteRequest) GetVote() *VoteDefinition {
+	if x != nil {
+		return x.Vote
+	}
+	return nil
+}
+
+type CreateVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *VoteDefinition        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVoteResponse) Reset() {
+	*x = CreateVoteResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVoteResponse) ProtoMessage() {}
+
+func (x *CreateVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVoteResponse.ProtoReflect.Descriptor instead.
+func (*CreateVoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateVoteResponse) GetResponse() *VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// The category, rate scale, selection range and budget of a vote are
+// fixed once it has opened or received ballots, and so are its options,
+// survey questions and follow-ups except for additions and their order;
+// changing them then fails with FAILED_PRECONDITION and the reason
+// RULES_LOCKED.
+type UpdateVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vote          *VoteDefinition        `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVoteRequest) Reset() {
+	*x = UpdateVoteRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVoteRequest) ProtoMessage() {}
+
+func (x *UpdateVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVoteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UpdateVoteRequest) GetVote() *VoteDefinition {
+	if x != nil {
+		return x.Vote
+	}
+	return nil
+}
+
+type UpdateVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *VoteDefinition        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVoteResponse) Reset() {
+	*x = UpdateVoteResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVoteResponse) ProtoMessage() {}
+
+func (x *UpdateVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVoteResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *UpdateVoteResponse) GetResponse() *VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// A vote with ballots cannot be deleted, as the audit log would lose them;
+// the request fails with FAILED_PRECONDITION and the reason
+// VOTE_HAS_BALLOTS. Archive the vote instead.
+type DeleteVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVoteRequest) Reset() {
+	*x = DeleteVoteRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVoteRequest) ProtoMessage() {}
+
+func (x *DeleteVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteVoteRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+type DeleteVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      string                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVoteResponse) Reset() {
+	*x = DeleteVoteResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVoteResponse) ProtoMessage() {}
+
+func (x *DeleteVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVoteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteVoteResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+// An empty status lists votes in every state.
+type ListAllVotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAllVotesRequest) Reset() {
+	*x = ListAllVotesRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllVotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllVotesRequest) ProtoMessage() {}
+
+func (x *ListAllVotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllVotesRequest.ProtoReflect.Descriptor instead.
+func (*ListAllVotesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListAllVotesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListAllVotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*VoteDefinition      `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAllVotesResponse) Reset() {
+	*x = ListAllVotesResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAllVotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAllVotesResponse) ProtoMessage() {}
+
+func (x *ListAllVotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAllVotesResponse.ProtoReflect.Descriptor instead.
+func (*ListAllVotesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListAllVotesResponse) GetResponse() []*VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type SetVoteStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVoteStatusRequest) Reset() {
+	*x = SetVoteStatusRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVoteStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVoteStatusRequest) ProtoMessage() {}
+
+func (x *SetVoteStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVoteStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetVoteStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetVoteStatusRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *SetVoteStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SetVoteStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *VoteDefinition        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVoteStatusResponse) Reset() {
+	*x = SetVoteStatusResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVoteStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVoteStatusResponse) ProtoMessage() {}
+
+func (x *SetVoteStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVoteStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetVoteStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SetVoteStatusResponse) GetResponse() *VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Comment is a free-text answer sent with a rate or choice ballot. status is
+// "pending", "approved" or "rejected"; reason explains a rejection.
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Reviewed      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=reviewed,proto3" json:"reviewed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_api_proto_votes_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Comment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Comment) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Comment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Comment) GetReviewed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Reviewed
+	}
+	return nil
+}
+
+// An empty status lists pending comments; vote_id 0 lists every vote.
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	VoteId        int32                  `protobuf:"varint,2,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListCommentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*Comment             `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListCommentsResponse) GetResponse() []*Comment {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// status must be "approved" or "rejected".
+type ReviewCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     int32                  `protobuf:"varint,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewCommentRequest) Reset() {
+	*x = ReviewCommentRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewCommentRequest) ProtoMessage() {}
+
+func (x *ReviewCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewCommentRequest.ProtoReflect.Descriptor instead.
+func (*ReviewCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ReviewCommentRequest) GetCommentId() int32 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+func (x *ReviewCommentRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReviewCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReviewCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Comment               `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewCommentResponse) Reset() {
+	*x = ReviewCommentResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewCommentResponse) ProtoMessage() {}
+
+func (x *ReviewCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewCommentResponse.ProtoReflect.Descriptor instead.
+func (*ReviewCommentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ReviewCommentResponse) GetResponse() *Comment {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// A petition submitted by a citizen waits in moderation and starts
+// collecting signatures once a moderator opens it.
+type CreatePetitionRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Token        string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Organization string                 `protobuf:"bytes,4,opt,name=organization,proto3" json:"organization,omitempty"`
+	Photo        string                 `protobuf:"bytes,5,opt,name=photo,proto3" json:"photo,omitempty"`
+	End          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`
+	// Takes precedence over organization. Petitions can only be addressed to
+	// existing organizations.
+	OrganizationId int32    `protobuf:"varint,7,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Topics         []string `protobuf:"bytes,8,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePetitionRequest) Reset() {
+	*x = CreatePetitionRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePetitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePetitionRequest) ProtoMessage() {}
+
+func (x *CreatePetitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePetitionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePetitionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CreatePetitionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreatePetitionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePetitionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePetitionRequest) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *CreatePetitionRequest) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *CreatePetitionRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *CreatePetitionRequest) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+func (x *CreatePetitionRequest) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// status is "moderation", or "rejected" with a reason when the text was
+// refused by the profanity filter.
+type CreatePetitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	SignatureGoal int32                  `protobuf:"varint,4,opt,name=signature_goal,json=signatureGoal,proto3" json:"signature_goal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePetitionResponse) Reset() {
+	*x = CreatePetitionResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePetitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePetitionResponse) ProtoMessage() {}
+
+func (x *CreatePetitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePetitionResponse.ProtoReflect.Descriptor instead.
+func (*CreatePetitionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CreatePetitionResponse) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *CreatePetitionResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreatePetitionResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreatePetitionResponse) GetSignatureGoal() int32 {
+	if x != nil {
+		return x.SignatureGoal
+	}
+	return 0
+}
+
+// status must be "open" or "rejected"; a rejection requires a reason. A
+// positive signature_goal replaces the goal the petition was submitted with.
+type ReviewPetitionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	SignatureGoal int32                  `protobuf:"varint,4,opt,name=signature_goal,json=signatureGoal,proto3" json:"signature_goal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPetitionRequest) Reset() {
+	*x = ReviewPetitionRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPetitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPetitionRequest) ProtoMessage() {}
+
+func (x *ReviewPetitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPetitionRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPetitionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReviewPetitionRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *ReviewPetitionRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReviewPetitionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReviewPetitionRequest) GetSignatureGoal() int32 {
+	if x != nil {
+		return x.SignatureGoal
+	}
+	return 0
+}
+
+type ReviewPetitionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *VoteDefinition        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPetitionResponse) Reset() {
+	*x = ReviewPetitionResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPetitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPetitionResponse) ProtoMessage() {}
+
+func (x *ReviewPetitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPetitionResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPetitionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ReviewPetitionResponse) GetResponse() *VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Publishing again replaces the earlier response. An empty organization
+// defaults to the one the petition is addressed to.
+type PublishPetitionResponseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VoteId        int32                  `protobuf:"varint,1,opt,name=vote_id,json=voteId,proto3" json:"vote_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Organization  string                 `protobuf:"bytes,4,opt,name=organization,proto3" json:"organization,omitempty"`
+	Attachments   []*Attachment          `protobuf:"bytes,5,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPetitionResponseRequest) Reset() {
+	*x = PublishPetitionResponseRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPetitionResponseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPetitionResponseRequest) ProtoMessage() {}
+
+func (x *PublishPetitionResponseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishPetitionResponseRequest.ProtoReflect.Descriptor instead.
+func (*PublishPetitionResponseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PublishPetitionResponseRequest) GetVoteId() int32 {
+	if x != nil {
+		return x.VoteId
+	}
+	return 0
+}
+
+func (x *PublishPetitionResponseRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *PublishPetitionResponseRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PublishPetitionResponseRequest) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *PublishPetitionResponseRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type PublishPetitionResponseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *PetitionResponse      `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishPetitionResponseResponse) Reset() {
+	*x = PublishPetitionResponseResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishPetitionResponseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishPetitionResponseResponse) ProtoMessage() {}
+
+func (x *PublishPetitionResponseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishPetitionResponseResponse.ProtoReflect.Descriptor instead.
+func (*PublishPetitionResponseResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *PublishPetitionResponseResponse) GetResponse() *PetitionResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type ListOverduePetitionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverduePetitionsRequest) Reset() {
+	*x = ListOverduePetitionsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverduePetitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverduePetitionsRequest) ProtoMessage() {}
+
+func (x *ListOverduePetitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverduePetitionsRequest.ProtoReflect.Descriptor instead.
+func (*ListOverduePetitionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{70}
+}
+
+type ListOverduePetitionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*VoteDefinition      `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverduePetitionsResponse) Reset() {
+	*x = ListOverduePetitionsResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverduePetitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverduePetitionsResponse) ProtoMessage() {}
+
+func (x *ListOverduePetitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverduePetitionsResponse.ProtoReflect.Descriptor instead.
+func (*ListOverduePetitionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListOverduePetitionsResponse) GetResponse() []*VoteDefinition {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// votes counts the public votes of the organization.
+type Organization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Logo          string                 `protobuf:"bytes,3,opt,name=logo,proto3" json:"logo,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	Verified      bool                   `protobuf:"varint,6,opt,name=verified,proto3" json:"verified,omitempty"`
+	Votes         int32                  `protobuf:"varint,7,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_api_proto_votes_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Organization) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetLogo() string {
+	if x != nil {
+		return x.Logo
+	}
+	return ""
+}
+
+func (x *Organization) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Organization) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *Organization) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Organization) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{73}
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*Organization        `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListOrganizationsResponse) GetResponse() []*Organization {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GetOrganizationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId int32                  `protobuf:"varint,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganizationRequest) Reset() {
+	*x = GetOrganizationRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationRequest) ProtoMessage() {}
+
+func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetOrganizationRequest) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+type GetOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Organization          `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationResponse) Reset() {
+	*x = GetOrganizationResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationResponse) ProtoMessage() {}
+
+func (x *GetOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetOrganizationResponse) GetResponse() *Organization {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Names are unique ignoring case; votes is ignored.
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateOrganizationRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type CreateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Organization          `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CreateOrganizationResponse) GetResponse() *Organization {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// A new name is copied to the votes of the organization.
+type UpdateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrganizationRequest) Reset() {
+	*x = UpdateOrganizationRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganizationRequest) ProtoMessage() {}
+
+func (x *UpdateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *UpdateOrganizationRequest) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type UpdateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Organization          `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrganizationResponse) Reset() {
+	*x = UpdateOrganizationResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganizationResponse) ProtoMessage() {}
+
+func (x *UpdateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *UpdateOrganizationResponse) GetResponse() *Organization {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// votes counts the public votes of the topic.
+type Topic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Votes         int32                  `protobuf:"varint,3,opt,name=votes,proto3" json:"votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Topic) Reset() {
+	*x = Topic{}
+	mi := &file_api_proto_votes_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Topic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topic) ProtoMessage() {}
+
+func (x *Topic) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topic.ProtoReflect.Descriptor instead.
+func (*Topic) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *Topic) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Topic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Topic) GetVotes() int32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+type ListTopicsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsRequest) Reset() {
+	*x = ListTopicsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsRequest) ProtoMessage() {}
+
+func (x *ListTopicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopicsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{82}
+}
+
+type ListTopicsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*Topic               `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicsResponse) Reset() {
+	*x = ListTopicsResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicsResponse) ProtoMessage() {}
+
+func (x *ListTopicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ListTopicsResponse) GetResponse() []*Topic {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// slug is a lowercase identifier such as "public-transport"; votes is
+// ignored.
+type CreateTopicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         *Topic                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopicRequest) Reset() {
+	*x = CreateTopicRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopicRequest) ProtoMessage() {}
+
+func (x *CreateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopicRequest.ProtoReflect.Descriptor instead.
+func (*CreateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CreateTopicRequest) GetTopic() *Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+type CreateTopicResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Topic                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTopicResponse) Reset() {
+	*x = CreateTopicResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTopicResponse) ProtoMessage() {}
+
+func (x *CreateTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTopicResponse.ProtoReflect.Descriptor instead.
+func (*CreateTopicResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *CreateTopicResponse) GetResponse() *Topic {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Renames the topic with the given slug; slugs never change.
+type UpdateTopicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         *Topic                 `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTopicRequest) Reset() {
+	*x = UpdateTopicRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTopicRequest) ProtoMessage() {}
+
+func (x *UpdateTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTopicRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTopicRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *UpdateTopicRequest) GetTopic() *Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+type UpdateTopicResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Topic                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTopicResponse) Reset() {
+	*x = UpdateTopicResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTopicResponse) ProtoMessage() {}
+
+func (x *UpdateTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTopicResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTopicResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *UpdateTopicResponse) GetResponse() *Topic {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// role is admin, editor, moderator or analyst. Editors are assigned to an
+// organization; the other roles have organization_id 0.
+type RoleAssignment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	OrganizationId int32                  `protobuf:"varint,3,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Granted        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=granted,proto3" json:"granted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RoleAssignment) Reset() {
+	*x = RoleAssignment{}
+	mi := &file_api_proto_votes_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleAssignment) ProtoMessage() {}
+
+func (x *RoleAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleAssignment.ProtoReflect.Descriptor instead.
+func (*RoleAssignment) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *RoleAssignment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RoleAssignment) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RoleAssignment) GetOrganizationId() int32 {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return 0
+}
+
+func (x *RoleAssignment) GetGranted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Granted
+	}
+	return nil
+}
+
+// An empty user_id lists the roles of every user.
+type ListRoleAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleAssignmentsRequest) Reset() {
+	*x = ListRoleAssignmentsRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListRoleAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListRoleAssignmentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListRoleAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      []*RoleAssignment      `protobuf:"bytes,1,rep,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleAssignmentsResponse) Reset() {
+	*x = ListRoleAssignmentsResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListRoleAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListRoleAssignmentsResponse) GetResponse() []*RoleAssignment {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GrantRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *RoleAssignment        `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleRequest) Reset() {
+	*x = GrantRoleRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleRequest) ProtoMessage() {}
+
+func (x *GrantRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleRequest.ProtoReflect.Descriptor instead.
+func (*GrantRoleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GrantRoleRequest) GetAssignment() *RoleAssignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+type GrantRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *RoleAssignment        `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleResponse) Reset() {
+	*x = GrantRoleResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleResponse) ProtoMessage() {}
+
+func (x *GrantRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleResponse.ProtoReflect.Descriptor instead.
+func (*GrantRoleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GrantRoleResponse) GetResponse() *RoleAssignment {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type RevokeRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *RoleAssignment        `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleRequest) Reset() {
+	*x = RevokeRoleRequest{}
+	mi := &file_api_proto_votes_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleRequest) ProtoMessage() {}
+
+func (x *RevokeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *RevokeRoleRequest) GetAssignment() *RoleAssignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+type RevokeRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      string                 `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleResponse) Reset() {
+	*x = RevokeRoleResponse{}
+	mi := &file_api_proto_votes_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleResponse) ProtoMessage() {}
+
+func (x *RevokeRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_votes_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleResponse.ProtoReflect.Descriptor instead.
+func (*RevokeRoleResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_votes_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RevokeRoleResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+var File_api_proto_votes_proto protoreflect.FileDescriptor
+
+const file_api_proto_votes_proto_rawDesc = "" +
+	"\n" +
+	"\x15api/proto/votes.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"l\n" +
+	"\x0fGetVotesRequest\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\x05R\x0eorganizationId\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\"9\n" +
+	"\x10GetVotesResponse\x12%\n" +
+	"\bresponse\x18\x01 \x03(\v2\t.api.VoteR\bresponse\"\xdd\x03\n" +
+	"\x04Vote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\x12\x14\n" +
+	"\x05photo\x18\b \x01(\tR\x05photo\x122\n" +
+	"\x0eoption_details\x18\t \x03(\v2\v.api.OptionR\roptionDetails\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12%\n" +
+	"\x0emin_selections\x18\v \x01(\x05R\rminSelections\x12%\n" +
+	"\x0emax_selections\x18\f \x01(\x05R\rmaxSelections\x12\x16\n" +
+	"\x06budget\x18\r \x01(\x05R\x06budget\x12'\n" +
+	"\x0forganization_id\x18\x0e \x01(\x05R\x0eorganizationId\x12\x16\n" +
+	"\x06topics\x18\x0f \x03(\tR\x06topics\"\x80\x01\n" +
+	"\x06Option\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\"\x16\n" +
+	"\x14GetCategoriesRequest\"7\n" +
+	"\x15GetCategoriesResponse\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\tR\n" +
+	"categories\"t\n" +
+	"\x12SearchVotesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"Z\n" +
+	"\x13SearchVotesResponse\x12-\n" +
+	"\bresponse\x18\x01 \x03(\v2\x11.api.SearchResultR\bresponse\x12\x14\n" +
+	"\x05fuzzy\x18\x02 \x01(\bR\x05fuzzy\"w\n" +
+	"\fSearchResult\x12\x1d\n" +
+	"\x04vote\x18\x01 \x01(\v2\t.api.VoteR\x04vote\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12\x1a\n" +
+	"\bheadline\x18\x03 \x01(\tR\bheadline\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\"C\n" +
+	"\x12GetVoteInfoRequest\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"@\n" +
+	"\x13GetRateInfoResponse\x12)\n" +
+	"\bresponse\x18\x01 \x01(\v2\r.api.VoteInfoR\bresponse\"H\n" +
+	"\x17GetPetitionInfoResponse\x12-\n" +
+	"\bresponse\x18\x01 \x01(\v2\x11.api.PetitionInfoR\bresponse\"D\n" +
+	"\x15GetChoiceInfoResponse\x12+\n" +
+	"\bresponse\x18\x01 \x01(\v2\x0f.api.ChoiceInfoR\bresponse\"\xd7\x03\n" +
+	"\bVoteInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\x12\x14\n" +
+	"\x05photo\x18\b \x01(\tR\x05photo\x12\x10\n" +
+	"\x03mid\x18\t \x01(\x02R\x03mid\x12\x12\n" +
+	"\x04rate\x18\n" +
+	" \x01(\x02R\x04rate\x12;\n" +
+	"\x12pending_follow_ups\x18\v \x03(\v2\r.api.FollowUpR\x10pendingFollowUps\x12&\n" +
+	"\acomment\x18\f \x01(\v2\f.api.CommentR\acomment\x12(\n" +
+	"\bcomments\x18\r \x03(\v2\f.api.CommentR\bcomments\x122\n" +
+	"\vlast_change\x18\x0e \x01(\v2\x11.api.BallotChangeR\n" +
+	"lastChange\"\xbb\x05\n" +
+	"\fPetitionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\x12\x14\n" +
+	"\x05photo\x18\b \x01(\tR\x05photo\x122\n" +
+	"\x05stats\x18\t \x03(\v2\x1c.api.PetitionInfo.StatsEntryR\x05stats\x12\x18\n" +
+	"\asupport\x18\n" +
+	" \x01(\tR\asupport\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12%\n" +
+	"\x0esignature_goal\x18\f \x01(\x05R\rsignatureGoal\x12\x1e\n" +
+	"\n" +
+	"signatures\x18\r \x01(\x05R\n" +
+	"signatures\x12B\n" +
+	"\x11official_response\x18\x0e \x01(\v2\x15.api.PetitionResponseR\x10officialResponse\x12=\n" +
+	"\fresponse_due\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vresponseDue\x12)\n" +
+	"\x10response_overdue\x18\x10 \x01(\bR\x0fresponseOverdue\x122\n" +
+	"\vlast_change\x18\x11 \x01(\v2\x11.api.BallotChangeR\n" +
+	"lastChange\x1a8\n" +
+	"\n" +
+	"StatsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xd3\x01\n" +
+	"\x10PetitionResponse\x12\x1a\n" +
+	"\bdecision\x18\x01 \x01(\tR\bdecision\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\"\n" +
+	"\forganization\x18\x03 \x01(\tR\forganization\x121\n" +
+	"\vattachments\x18\x04 \x03(\v2\x0f.api.AttachmentR\vattachments\x128\n" +
+	"\tresponded\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tresponded\"2\n" +
+	"\n" +
+	"Attachment\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xa9\x06\n" +
+	"\n" +
+	"ChoiceInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\x12\x14\n" +
+	"\x05photo\x18\b \x01(\tR\x05photo\x120\n" +
+	"\x05stats\x18\t \x03(\v2\x1a.api.ChoiceInfo.StatsEntryR\x05stats\x12\x16\n" +
+	"\x06choice\x18\n" +
+	" \x01(\tR\x06choice\x122\n" +
+	"\x0eoption_details\x18\v \x03(\v2\v.api.OptionR\roptionDetails\x12\x1b\n" +
+	"\toption_id\x18\f \x01(\x05R\boptionId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\r \x03(\x05R\toptionIds\x12\x18\n" +
+	"\achoices\x18\x0e \x03(\tR\achoices\x12%\n" +
+	"\x0emin_selections\x18\x0f \x01(\x05R\rminSelections\x12%\n" +
+	"\x0emax_selections\x18\x10 \x01(\x05R\rmaxSelections\x12\x18\n" +
+	"\aballots\x18\x11 \x01(\x05R\aballots\x12;\n" +
+	"\x12pending_follow_ups\x18\x12 \x03(\v2\r.api.FollowUpR\x10pendingFollowUps\x12&\n" +
+	"\acomment\x18\x13 \x01(\v2\f.api.CommentR\acomment\x12(\n" +
+	"\bcomments\x18\x14 \x03(\v2\f.api.CommentR\bcomments\x122\n" +
+	"\vlast_change\x18\x15 \x01(\v2\x11.api.BallotChangeR\n" +
+	"lastChange\x1a8\n" +
+	"\n" +
+	"StatsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"D\n" +
+	"\x15GetRankedInfoResponse\x12+\n" +
+	"\bresponse\x18\x01 \x01(\v2\x0f.api.RankedInfoR\bresponse\"\xf8\x02\n" +
+	"\n" +
+	"RankedInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05photo\x18\a \x01(\tR\x05photo\x12%\n" +
+	"\aoptions\x18\b \x03(\v2\v.api.OptionR\aoptions\x12\x18\n" +
+	"\aballots\x18\t \x01(\x05R\aballots\x12(\n" +
+	"\x06rounds\x18\n" +
+	" \x03(\v2\x10.api.RankedRoundR\x06rounds\x12\x1b\n" +
+	"\twinner_id\x18\v \x01(\x05R\bwinnerId\x12\x18\n" +
+	"\aranking\x18\f \x03(\x05R\aranking\"\xac\x01\n" +
+	"\vRankedRound\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12*\n" +
+	"\atallies\x18\x02 \x03(\v2\x10.api.OptionTallyR\atallies\x12\x1c\n" +
+	"\texhausted\x18\x03 \x01(\x05R\texhausted\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x04 \x03(\x05R\n" +
+	"eliminated\x12\x1b\n" +
+	"\twinner_id\x18\x05 \x01(\x05R\bwinnerId\"@\n" +
+	"\vOptionTally\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\x05R\boptionId\x12\x14\n" +
+	"\x05votes\x18\x02 \x01(\x05R\x05votes\"L\n" +
+	"\x19GetAllocationInfoResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.AllocationInfoR\bresponse\"\xad\x03\n" +
+	"\x0eAllocationInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05photo\x18\a \x01(\tR\x05photo\x12%\n" +
+	"\aoptions\x18\b \x03(\v2\v.api.OptionR\aoptions\x12\x16\n" +
+	"\x06budget\x18\t \x01(\x05R\x06budget\x12\x18\n" +
+	"\aballots\x18\n" +
+	" \x01(\x05R\aballots\x12/\n" +
+	"\aresults\x18\v \x03(\v2\x15.api.OptionAllocationR\aresults\x121\n" +
+	"\vallocations\x18\f \x03(\v2\x0f.api.AllocationR\vallocations\x12\x14\n" +
+	"\x05spent\x18\r \x01(\x05R\x05spent\"\xb9\x01\n" +
+	"\x10OptionAllocation\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\x05R\boptionId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\acredits\x18\x03 \x01(\x05R\acredits\x12\x1e\n" +
+	"\n" +
+	"supporters\x18\x04 \x01(\x05R\n" +
+	"supporters\x128\n" +
+	"\fdistribution\x18\x05 \x03(\v2\x14.api.AllocationCountR\fdistribution\"C\n" +
+	"\x0fAllocationCount\x12\x16\n" +
+	"\x06points\x18\x01 \x01(\x05R\x06points\x12\x18\n" +
+	"\aballots\x18\x02 \x01(\x05R\aballots\"A\n" +
+	"\n" +
+	"Allocation\x12\x1b\n" +
+	"\toption_id\x18\x01 \x01(\x05R\boptionId\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x05R\x06points\"D\n" +
+	"\x15GetSurveyInfoResponse\x12+\n" +
+	"\bresponse\x18\x01 \x01(\v2\x0f.api.SurveyInfoR\bresponse\"\xd1\x03\n" +
+	"\n" +
+	"SurveyInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05photo\x18\a \x01(\tR\x05photo\x12+\n" +
+	"\tquestions\x18\b \x03(\v2\r.api.QuestionR\tquestions\x12\x1c\n" +
+	"\tresponses\x18\t \x01(\x05R\tresponses\x12-\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2\x13.api.QuestionResultR\aresults\x12+\n" +
+	"\aanswers\x18\v \x03(\v2\x11.api.SurveyAnswerR\aanswers\x12\x1c\n" +
+	"\tsubmitted\x18\f \x01(\bR\tsubmitted\x124\n" +
+	"\aupdated\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\"\xd0\x01\n" +
+	"\bQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\x12%\n" +
+	"\aoptions\x18\x06 \x03(\v2\v.api.OptionR\aoptions\x12-\n" +
+	"\n" +
+	"rate_scale\x18\a \x01(\v2\x0e.api.RateScaleR\trateScale\"\xbd\x01\n" +
+	"\x0eQuestionResult\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x05R\n" +
+	"questionId\x12\x18\n" +
+	"\aanswers\x18\x02 \x01(\x05R\aanswers\x12\x18\n" +
+	"\aaverage\x18\x03 \x01(\x02R\aaverage\x12*\n" +
+	"\aratings\x18\x04 \x03(\v2\x10.api.RatingCountR\aratings\x12*\n" +
+	"\aoptions\x18\x05 \x03(\v2\x10.api.OptionTallyR\aoptions\"?\n" +
+	"\vRatingCount\x12\x16\n" +
+	"\x06rating\x18\x01 \x01(\x05R\x06rating\x12\x18\n" +
+	"\aanswers\x18\x02 \x01(\x05R\aanswers\"\x8a\x01\n" +
+	"\fSurveyAnswer\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x05R\n" +
+	"questionId\x12\x1b\n" +
+	"\x06rating\x18\x02 \x01(\x05H\x00R\x06rating\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x05R\toptionIds\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04textB\t\n" +
+	"\a_rating\"\xe4\x01\n" +
+	"\bFollowUp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12%\n" +
+	"\aoptions\x18\x05 \x03(\v2\v.api.OptionR\aoptions\x12-\n" +
+	"\n" +
+	"rate_scale\x18\x06 \x01(\v2\x0e.api.RateScaleR\trateScale\x12.\n" +
+	"\atrigger\x18\a \x01(\v2\x14.api.FollowUpTriggerR\atrigger\"\x88\x01\n" +
+	"\x0fFollowUpTrigger\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\x01 \x01(\x05R\tminRating\x12\x1d\n" +
+	"\n" +
+	"max_rating\x18\x02 \x01(\x05R\tmaxRating\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x05R\toptionIds\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\"r\n" +
+	"\x0fVoteRateRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x02R\x06rating\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"^\n" +
+	"\x13VotePetitionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12\x18\n" +
+	"\asupport\x18\x03 \x01(\tR\asupport\"\xb0\x01\n" +
+	"\x11VoteChoiceRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12\x16\n" +
+	"\x06choice\x18\x03 \x01(\tR\x06choice\x12\x1b\n" +
+	"\toption_id\x18\x04 \x01(\x05R\boptionId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x05 \x03(\x05R\toptionIds\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\"a\n" +
+	"\x11VoteRankedRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x05R\toptionIds\"y\n" +
+	"\x15VoteAllocationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x121\n" +
+	"\vallocations\x18\x03 \x03(\v2\x0f.api.AllocationR\vallocations\"\x87\x01\n" +
+	"\x13SubmitSurveyRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12+\n" +
+	"\aanswers\x18\x03 \x03(\v2\x11.api.SurveyAnswerR\aanswers\x12\x14\n" +
+	"\x05draft\x18\x04 \x01(\bR\x05draft\"t\n" +
+	"\x16AnswerFollowUpsRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12+\n" +
+	"\aanswers\x18\x03 \x03(\v2\x11.api.SurveyAnswerR\aanswers\"D\n" +
+	"\x13WithdrawVoteRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\"\\\n" +
+	"\fBallotChange\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x124\n" +
+	"\achanged\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\achanged\"*\n" +
+	"\fVoteResponse\x12\x1a\n" +
+	"\bresponse\x18\x01 \x01(\tR\bresponse\"\x14\n" +
+	"\x12HealthCheckRequest\"4\n" +
+	"\x13HealthCheckResponse\x12\x1d\n" +
+	"\n" +
+	"is_healthy\x18\x01 \x01(\bR\tisHealthy\"\xfc\x06\n" +
+	"\x0eVoteDefinition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x05 \x01(\tR\forganization\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\aoptions\x18\a \x03(\tR\aoptions\x12\x14\n" +
+	"\x05photo\x18\b \x01(\tR\x05photo\x12!\n" +
+	"\fexternal_key\x18\t \x01(\tR\vexternalKey\x120\n" +
+	"\x05start\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12-\n" +
+	"\n" +
+	"rate_scale\x18\v \x01(\v2\x0e.api.RateScaleR\trateScale\x122\n" +
+	"\x0eoption_details\x18\f \x03(\v2\v.api.OptionR\roptionDetails\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12%\n" +
+	"\x0emin_selections\x18\x0e \x01(\x05R\rminSelections\x12%\n" +
+	"\x0emax_selections\x18\x0f \x01(\x05R\rmaxSelections\x12\x16\n" +
+	"\x06budget\x18\x10 \x01(\x05R\x06budget\x12+\n" +
+	"\tquestions\x18\x11 \x03(\v2\r.api.QuestionR\tquestions\x12,\n" +
+	"\n" +
+	"follow_ups\x18\x12 \x03(\v2\r.api.FollowUpR\tfollowUps\x12%\n" +
+	"\x0esignature_goal\x18\x13 \x01(\x05R\rsignatureGoal\x12#\n" +
+	"\rreview_reason\x18\x14 \x01(\tR\freviewReason\x12=\n" +
+	"\fresponse_due\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\vresponseDue\x12)\n" +
+	"\x10response_overdue\x18\x16 \x01(\bR\x0fresponseOverdue\x12'\n" +
+	"\x0forganization_id\x18\x17 \x01(\x05R\x0eorganizationId\x12\x16\n" +
+	"\x06topics\x18\x18 \x03(\tR\x06topics\"C\n" +
+	"\tRateScale\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x05R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x05R\x03max\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\x05R\x04step\"<\n" +
+	"\x11CreateVoteRequest\x12'\n" +
+	"\x04vote\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\x04vote\"E\n" +
+	"\x12CreateVoteResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\bresponse\"<\n" +
+	"\x11UpdateVoteRequest\x12'\n" +
+	"\x04vote\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\x04vote\"E\n" +
+	"\x12UpdateVoteResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\bresponse\",\n" +
+	"\x11DeleteVoteRequest\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\"0\n" +
+	"\x12DeleteVoteResponse\x12\x1a\n" +
+	"\bresponse\x18\x01 \x01(\tR\bresponse\"-\n" +
+	"\x13ListAllVotesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"G\n" +
+	"\x14ListAllVotesResponse\x12/\n" +
+	"\bresponse\x18\x01 \x03(\v2\x13.api.VoteDefinitionR\bresponse\"G\n" +
+	"\x14SetVoteStatusRequest\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"H\n" +
+	"\x15SetVoteStatusResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\bresponse\"\xe4\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x124\n" +
+	"\acreated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\breviewed\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\breviewed\"F\n" +
+	"\x13ListCommentsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x17\n" +
+	"\avote_id\x18\x02 \x01(\x05R\x06voteId\"@\n" +
+	"\x14ListCommentsResponse\x12(\n" +
+	"\bresponse\x18\x01 \x03(\v2\f.api.CommentR\bresponse\"e\n" +
+	"\x14ReviewCommentRequest\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\x05R\tcommentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"A\n" +
+	"\x15ReviewCommentResponse\x12(\n" +
+	"\bresponse\x18\x01 \x01(\v2\f.api.CommentR\bresponse\"\x8c\x02\n" +
+	"\x15CreatePetitionRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\forganization\x18\x04 \x01(\tR\forganization\x12\x14\n" +
+	"\x05photo\x18\x05 \x01(\tR\x05photo\x12,\n" +
+	"\x03end\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12'\n" +
+	"\x0forganization_id\x18\a \x01(\x05R\x0eorganizationId\x12\x16\n" +
+	"\x06topics\x18\b \x03(\tR\x06topics\"\x88\x01\n" +
+	"\x16CreatePetitionResponse\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12%\n" +
+	"\x0esignature_goal\x18\x04 \x01(\x05R\rsignatureGoal\"\x87\x01\n" +
+	"\x15ReviewPetitionRequest\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12%\n" +
+	"\x0esignature_goal\x18\x04 \x01(\x05R\rsignatureGoal\"I\n" +
+	"\x16ReviewPetitionResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.VoteDefinitionR\bresponse\"\xc0\x01\n" +
+	"\x1ePublishPetitionResponseRequest\x12\x17\n" +
+	"\avote_id\x18\x01 \x01(\x05R\x06voteId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\"\n" +
+	"\forganization\x18\x04 \x01(\tR\forganization\x121\n" +
+	"\vattachments\x18\x05 \x03(\v2\x0f.api.AttachmentR\vattachments\"T\n" +
+	"\x1fPublishPetitionResponseResponse\x121\n" +
+	"\bresponse\x18\x01 \x01(\v2\x15.api.PetitionResponseR\bresponse\"\x1d\n" +
+	"\x1bListOverduePetitionsRequest\"O\n" +
+	"\x1cListOverduePetitionsResponse\x12/\n" +
+	"\bresponse\x18\x01 \x03(\v2\x13.api.VoteDefinitionR\bresponse\"\xb4\x01\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04logo\x18\x03 \x01(\tR\x04logo\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\x12\x1a\n" +
+	"\bverified\x18\x06 \x01(\bR\bverified\x12\x14\n" +
+	"\x05votes\x18\a \x01(\x05R\x05votes\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"J\n" +
+	"\x19ListOrganizationsResponse\x12-\n" +
+	"\bresponse\x18\x01 \x03(\v2\x11.api.OrganizationR\bresponse\"A\n" +
+	"\x16GetOrganizationRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\x05R\x0eorganizationId\"H\n" +
+	"\x17GetOrganizationResponse\x12-\n" +
+	"\bresponse\x18\x01 \x01(\v2\x11.api.OrganizationR\bresponse\"R\n" +
+	"\x19CreateOrganizationRequest\x125\n" +
+	"\forganization\x18\x01 \x01(\v2\x11.api.OrganizationR\forganization\"K\n" +
+	"\x1aCreateOrganizationResponse\x12-\n" +
+	"\bresponse\x18\x01 \x01(\v2\x11.api.OrganizationR\bresponse\"R\n" +
+	"\x19UpdateOrganizationRequest\x125\n" +
+	"\forganization\x18\x01 \x01(\v2\x11.api.OrganizationR\forganization\"K\n" +
+	"\x1aUpdateOrganizationResponse\x12-\n" +
+	"\bresponse\x18\x01 \x01(\v2\x11.api.OrganizationR\bresponse\"E\n" +
+	"\x05Topic\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05votes\x18\x03 \x01(\x05R\x05votes\"\x13\n" +
+	"\x11ListTopicsRequest\"<\n" +
+	"\x12ListTopicsResponse\x12&\n" +
+	"\bresponse\x18\x01 \x03(\v2\n" +
+	".api.TopicR\bresponse\"6\n" +
+	"\x12CreateTopicRequest\x12 \n" +
+	"\x05topic\x18\x01 \x01(\v2\n" +
+	".api.TopicR\x05topic\"=\n" +
+	"\x13CreateTopicResponse\x12&\n" +
+	"\bresponse\x18\x01 \x01(\v2\n" +
+	".api.TopicR\bresponse\"6\n" +
+	"\x12UpdateTopicRequest\x12 \n" +
+	"\x05topic\x18\x01 \x01(\v2\n" +
+	".api.TopicR\x05topic\"=\n" +
+	"\x13UpdateTopicResponse\x12&\n" +
+	"\bresponse\x18\x01 \x01(\v2\n" +
+	".api.TopicR\bresponse\"\x9c\x01\n" +
+	"\x0eRoleAssignment\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12'\n" +
+	"\x0forganization_id\x18\x03 \x01(\x05R\x0eorganizationId\x124\n" +
+	"\agranted\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\agranted\"5\n" +
+	"\x1aListRoleAssignmentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"N\n" +
+	"\x1bListRoleAssignmentsResponse\x12/\n" +
+	"\bresponse\x18\x01 \x03(\v2\x13.api.RoleAssignmentR\bresponse\"G\n" +
+	"\x10GrantRoleRequest\x123\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x13.api.RoleAssignmentR\n" +
+	"assignment\"D\n" +
+	"\x11GrantRoleResponse\x12/\n" +
+	"\bresponse\x18\x01 \x01(\v2\x13.api.RoleAssignmentR\bresponse\"H\n" +
+	"\x11RevokeRoleRequest\x123\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x13.api.RoleAssignmentR\n" +
+	"assignment\"0\n" +
+	"\x12RevokeRoleResponse\x12\x1a\n" +
+	"\bresponse\x18\x01 \x01(\tR\bresponse2\xcd\v\n" +
+	"\fVotesService\x127\n" +
+	"\bGetVotes\x12\x14.api.GetVotesRequest\x1a\x15.api.GetVotesResponse\x12F\n" +
+	"\rGetCategories\x12\x19.api.GetCategoriesRequest\x1a\x1a.api.GetCategoriesResponse\x12@\n" +
+	"\vSearchVotes\x12\x17.api.SearchVotesRequest\x1a\x18.api.SearchVotesResponse\x12@\n" +
+	"\vGetRateInfo\x12\x17.api.GetVoteInfoRequest\x1a\x18.api.GetRateInfoResponse\x12H\n" +
+	"\x0fGetPetitionInfo\x12\x17.api.GetVoteInfoRequest\x1a\x1c.api.GetPetitionInfoResponse\x12D\n" +
+	"\rGetChoiceInfo\x12\x17.api.GetVoteInfoRequest\x1a\x1a.api.GetChoiceInfoResponse\x12D\n" +
+	"\rGetRankedInfo\x12\x17.api.GetVoteInfoRequest\x1a\x1a.api.GetRankedInfoResponse\x12L\n" +
+	"\x11GetAllocationInfo\x12\x17.api.GetVoteInfoRequest\x1a\x1e.api.GetAllocationInfoResponse\x12D\n" +
+	"\rGetSurveyInfo\x12\x17.api.GetVoteInfoRequest\x1a\x1a.api.GetSurveyInfoResponse\x123\n" +
+	"\bVoteRate\x12\x14.api.VoteRateRequest\x1a\x11.api.VoteResponse\x12;\n" +
+	"\fVotePetition\x12\x18.api.VotePetitionRequest\x1a\x11.api.VoteResponse\x127\n" +
+	"\n" +
+	"VoteChoice\x12\x16.api.VoteChoiceRequest\x1a\x11.api.VoteResponse\x127\n" +
+	"\n" +
+	"VoteRanked\x12\x16.api.VoteRankedRequest\x1a\x11.api.VoteResponse\x12?\n" +
+	"\x0eVoteAllocation\x12\x1a.api.VoteAllocationRequest\x1a\x11.api.VoteResponse\x12;\n" +
+	"\fSubmitSurvey\x12\x18.api.SubmitSurveyRequest\x1a\x11.api.VoteResponse\x12A\n" +
+	"\x0fAnswerFollowUps\x12\x1b.api.AnswerFollowUpsRequest\x1a\x11.api.VoteResponse\x12;\n" +
+	"\fWithdrawVote\x12\x18.api.WithdrawVoteRequest\x1a\x11.api.VoteResponse\x12I\n" +
+	"\x0eCreatePetition\x12\x1a.api.CreatePetitionRequest\x1a\x1b.api.CreatePetitionResponse\x12R\n" +
+	"\x11ListOrganizations\x12\x1d.api.ListOrganizationsRequest\x1a\x1e.api.ListOrganizationsResponse\x12L\n" +
+	"\x0fGetOrganization\x12\x1b.api.GetOrganizationRequest\x1a\x1c.api.GetOrganizationResponse\x12=\n" +
+	"\n" +
+	"ListTopics\x12\x16.api.ListTopicsRequest\x1a\x17.api.ListTopicsResponse\x12@\n" +
+	"\vHealthCheck\x12\x17.api.HealthCheckRequest\x1a\x18.api.HealthCheckResponse2\xff\t\n" +
+	"\x11VotesAdminService\x12=\n" +
+	"\n" +
+	"CreateVote\x12\x16.api.CreateVoteRequest\x1a\x17.api.CreateVoteResponse\x12=\n" +
+	"\n" +
+	"UpdateVote\x12\x16.api.UpdateVoteRequest\x1a\x17.api.UpdateVoteResponse\x12=\n" +
+	"\n" +
+	"DeleteVote\x12\x16.api.DeleteVoteRequest\x1a\x17.api.DeleteVoteResponse\x12C\n" +
+	"\fListAllVotes\x12\x18.api.ListAllVotesRequest\x1a\x19.api.ListAllVotesResponse\x12F\n" +
+	"\rSetVoteStatus\x12\x19.api.SetVoteStatusRequest\x1a\x1a.api.SetVoteStatusResponse\x12C\n" +
+	"\fListComments\x12\x18.api.ListCommentsRequest\x1a\x19.api.ListCommentsResponse\x12F\n" +
+	"\rReviewComment\x12\x19.api.ReviewCommentRequest\x1a\x1a.api.ReviewCommentResponse\x12I\n" +
+	"\x0eReviewPetition\x12\x1a.api.ReviewPetitionRequest\x1a\x1b.api.ReviewPetitionResponse\x12d\n" +
+	"\x17PublishPetitionResponse\x12#.api.PublishPetitionResponseRequest\x1a$.api.PublishPetitionResponseResponse\x12[\n" +
+	"\x14ListOverduePetitions\x12 .api.ListOverduePetitionsRequest\x1a!.api.ListOverduePetitionsResponse\x12U\n" +
+	"\x12CreateOrganization\x12\x1e.api.CreateOrganizationRequest\x1a\x1f.api.CreateOrganizationResponse\x12U\n" +
+	"\x12UpdateOrganization\x12\x1e.api.UpdateOrganizationRequest\x1a\x1f.api.UpdateOrganizationResponse\x12@\n" +
+	"\vCreateTopic\x12\x17.api.CreateTopicRequest\x1a\x18.api.CreateTopicResponse\x12@\n" +
+	"\vUpdateTopic\x12\x17.api.UpdateTopicRequest\x1a\x18.api.UpdateTopicResponse\x12X\n" +
+	"\x13ListRoleAssignments\x12\x1f.api.ListRoleAssignmentsRequest\x1a .api.ListRoleAssignmentsResponse\x12:\n" +
+	"\tGrantRole\x12\x15.api.GrantRoleRequest\x1a\x16.api.GrantRoleResponse\x12=\n" +
+	"\n" +
+	"RevokeRole\x12\x16.api.RevokeRoleRequest\x1a\x17.api.RevokeRoleResponseB-Z+github.com/GP-Hacks/kdt2024-votes/api/protob\x06proto3"
+
+var (
+	file_api_proto_votes_proto_rawDescOnce sync.Once
+	file_api_proto_votes_proto_rawDescData []byte
+)
+
+func file_api_proto_votes_proto_rawDescGZIP() []byte {
+	file_api_proto_votes_proto_rawDescOnce.Do(func() {
+		file_api_proto_votes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_votes_proto_rawDesc), len(file_api_proto_votes_proto_rawDesc)))
+	})
+	return file_api_proto_votes_proto_rawDescData
+}
+
+var file_api_proto_votes_proto_msgTypes = make([]protoimpl.MessageInfo, 97)
+var file_api_proto_votes_proto_goTypes = []any{
+	(*GetVotesRequest)(nil),                 // 0: api.GetVotesRequest
+	(*GetVotesResponse)(nil),                // 1: api.GetVotesResponse
+	(*Vote)(nil),                            // 2: api.Vote
+	(*Option)(nil),                          // 3: api.Option
+	(*GetCategoriesRequest)(nil),            // 4: api.GetCategoriesRequest
+	(*GetCategoriesResponse)(nil),           // 5: api.GetCategoriesResponse
+	(*SearchVotesRequest)(nil),              // 6: api.SearchVotesRequest
+	(*SearchVotesResponse)(nil),             // 7: api.SearchVotesResponse
+	(*SearchResult)(nil),                    // 8: api.SearchResult
+	(*GetVoteInfoRequest)(nil),              // 9: api.GetVoteInfoRequest
+	(*GetRateInfoResponse)(nil),             // 10: api.GetRateInfoResponse
+	(*GetPetitionInfoResponse)(nil),         // 11: api.GetPetitionInfoResponse
+	(*GetChoiceInfoResponse)(nil),           // 12: api.GetChoiceInfoResponse
+	(*VoteInfo)(nil),                        // 13: api.VoteInfo
+	(*PetitionInfo)(nil),                    // 14: api.PetitionInfo
+	(*PetitionResponse)(nil),                // 15: api.PetitionResponse
+	(*Attachment)(nil),                      // 16: api.Attachment
+	(*ChoiceInfo)(nil),                      // 17: api.ChoiceInfo
+	(*GetRankedInfoResponse)(nil),           // 18: api.GetRankedInfoResponse
+	(*RankedInfo)(nil),                      // 19: api.RankedInfo
+	(*RankedRound)(nil),                     // 20: api.RankedRound
+	(*OptionTally)(nil),                     // 21: api.OptionTally
+	(*GetAllocationInfoResponse)(nil),       // 22: api.GetAllocationInfoResponse
+	(*AllocationInfo)(nil),                  // 23: api.AllocationInfo
+	(*OptionAllocation)(nil),                // 24: api.OptionAllocation
+	(*AllocationCount)(nil),                 // 25: api.AllocationCount
+	(*Allocation)(nil),                      // 26: api.Allocation
+	(*GetSurveyInfoResponse)(nil),           // 27: api.GetSurveyInfoResponse
+	(*SurveyInfo)(nil),                      // 28: api.SurveyInfo
+	(*Question)(nil),                        // 29: api.Question
+	(*QuestionResult)(nil),                  // 30: api.QuestionResult
+	(*RatingCount)(nil),                     // 31: api.RatingCount
+	(*SurveyAnswer)(nil),                    // 32: api.SurveyAnswer
+	(*FollowUp)(nil),                        // 33: api.FollowUp
+	(*FollowUpTrigger)(nil),                 // 34: api.FollowUpTrigger
+	(*VoteRateRequest)(nil),                 // 35: api.VoteRateRequest
+	(*VotePetitionRequest)(nil),             // 36: api.VotePetitionRequest
+	(*VoteChoiceRequest)(nil),               // 37: api.VoteChoiceRequest
+	(*VoteRankedRequest)(nil),               // 38: api.VoteRankedRequest
+	(*VoteAllocationRequest)(nil),           // 39: api.VoteAllocationRequest
+	(*SubmitSurveyRequest)(nil),             // 40: api.SubmitSurveyRequest
+	(*AnswerFollowUpsRequest)(nil),          // 41: api.AnswerFollowUpsRequest
+	(*WithdrawVoteRequest)(nil),             // 42: api.WithdrawVoteRequest
+	(*BallotChange)(nil),                    // 43: api.BallotChange
+	(*VoteResponse)(nil),                    // 44: api.VoteResponse
+	(*HealthCheckRequest)(nil),              // 45: api.HealthCheckRequest
+	(*HealthCheckResponse)(nil),             // 46: api.HealthCheckResponse
+	(*VoteDefinition)(nil),                  // 47: api.VoteDefinition
+	(*RateScale)(nil),                       // 48: api.RateScale
+	(*CreateVoteRequest)(nil),               // 49: api.CreateVoteRequest
+	(*CreateVoteResponse)(nil),              // 50: api.CreateVoteResponse
+	(*UpdateVoteRequest)(nil),               // 51: api.UpdateVoteRequest
+	(*UpdateVoteResponse)(nil),              // 52: api.UpdateVoteResponse
+	(*DeleteVoteRequest)(nil),               // 53: api.DeleteVoteRequest
+	(*DeleteVoteResponse)(nil),              // 54: api.DeleteVoteResponse
+	(*ListAllVotesRequest)(nil),             // 55: api.ListAllVotesRequest
+	(*ListAllVotesResponse)(nil),            // 56: api.ListAllVotesResponse
+	(*SetVoteStatusRequest)(nil),            // 57: api.SetVoteStatusRequest
+	(*SetVoteStatusResponse)(nil),           // 58: api.SetVoteStatusResponse
+	(*Comment)(nil),                         // 59: api.Comment
+	(*ListCommentsRequest)(nil),             // 60: api.ListCommentsRequest
+	(*ListCommentsResponse)(nil),            // 61: api.ListCommentsResponse
+	(*ReviewCommentRequest)(nil),            // 62: api.ReviewCommentRequest
+	(*ReviewCommentResponse)(nil),           // 63: api.ReviewCommentResponse
+	(*CreatePetitionRequest)(nil),           // 64: api.CreatePetitionRequest
+	(*CreatePetitionResponse)(nil),          // 65: api.CreatePetitionResponse
+	(*ReviewPetitionRequest)(nil),           // 66: api.ReviewPetitionRequest
+	(*ReviewPetitionResponse)(nil),          // 67: api.ReviewPetitionResponse
+	(*PublishPetitionResponseRequest)(nil),  // 68: api.PublishPetitionResponseRequest
+	(*PublishPetitionResponseResponse)(nil), // 69: api.PublishPetitionResponseResponse
+	(*ListOverduePetitionsRequest)(nil),     // 70: api.ListOverduePetitionsRequest
+	(*ListOverduePetitionsResponse)(nil),    // 71: api.ListOverduePetitionsResponse
+	(*Organization)(nil),                    // 72: api.Organization
+	(*ListOrganizationsRequest)(nil),        // 73: api.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil),       // 74: api.ListOrganizationsResponse
+	(*GetOrganizationRequest)(nil),          // 75: api.GetOrganizationRequest
+	(*GetOrganizationResponse)(nil),         // 76: api.GetOrganizationResponse
+	(*CreateOrganizationRequest)(nil),       // 77: api.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil),      // 78: api.CreateOrganizationResponse
+	(*UpdateOrganizationRequest)(nil),       // 79: api.UpdateOrganizationRequest
+	(*UpdateOrganizationResponse)(nil),      // 80: api.UpdateOrganizationResponse
+	(*Topic)(nil),                           // 81: api.Topic
+	(*ListTopicsRequest)(nil),               // 82: api.ListTopicsRequest
+	(*ListTopicsResponse)(nil),              // 83: api.ListTopicsResponse
+	(*CreateTopicRequest)(nil),              // 84: api.CreateTopicRequest
+	(*CreateTopicResponse)(nil),             // 85: api.CreateTopicResponse
+	(*UpdateTopicRequest)(nil),              // 86: api.UpdateTopicRequest
+	(*UpdateTopicResponse)(nil),             // 87: api.UpdateTopicResponse
+	(*RoleAssignment)(nil),                  // 88: api.RoleAssignment
+	(*ListRoleAssignmentsRequest)(nil),      // 89: api.ListRoleAssignmentsRequest
+	(*ListRoleAssignmentsResponse)(nil),     // 90: api.ListRoleAssignmentsResponse
+	(*GrantRoleRequest)(nil),                // 91: api.GrantRoleRequest
+	(*GrantRoleResponse)(nil),               // 92: api.GrantRoleResponse
+	(*RevokeRoleRequest)(nil),               // 93: api.RevokeRoleRequest
+	(*RevokeRoleResponse)(nil),              // 94: api.RevokeRoleResponse
+	nil,                                     // 95: api.PetitionInfo.StatsEntry
+	nil,                                     // 96: api.ChoiceInfo.StatsEntry
+	(*timestamppb.Timestamp)(nil),           // 97: google.protobuf.Timestamp
+}
+var file_api_proto_votes_proto_depIdxs = []int32{
+	2,   // 0: api.GetVotesResponse.response:type_name -> api.Vote
+	97,  // 1: api.Vote.end:type_name -> google.protobuf.Timestamp
+	3,   // 2: api.Vote.option_details:type_name -> api.Option
+	8,   // 3: api.SearchVotesResponse.response:type_name -> api.SearchResult
+	2,   // 4: api.SearchResult.vote:type_name -> api.Vote
+	13,  // 5: api.GetRateInfoResponse.response:type_name -> api.VoteInfo
+	14,  // 6: api.GetPetitionInfoResponse.response:type_name -> api.PetitionInfo
+	17,  // 7: api.GetChoiceInfoResponse.response:type_name -> api.ChoiceInfo
+	97,  // 8: api.VoteInfo.end:type_name -> google.protobuf.Timestamp
+	33,  // 9: api.VoteInfo.pending_follow_ups:type_name -> api.FollowUp
+	59,  // 10: api.VoteInfo.comment:type_name -> api.Comment
+	59,  // 11: api.VoteInfo.comments:type_name -> api.Comment
+	43,  // 12: api.VoteInfo.last_change:type_name -> api.BallotChange
+	97,  // 13: api.PetitionInfo.end:type_name -> google.protobuf.Timestamp
+	95,  // 14: api.PetitionInfo.stats:type_name -> api.PetitionInfo.StatsEntry
+	15,  // 15: api.PetitionInfo.official_response:type_name -> api.PetitionResponse
+	97,  // 16: api.PetitionInfo.response_due:type_name -> google.protobuf.Timestamp
+	43,  // 17: api.PetitionInfo.last_change:type_name -> api.BallotChange
+	16,  // 18: api.PetitionResponse.attachments:type_name -> api.Attachment
+	97,  // 19: api.PetitionResponse.responded:type_name -> google.protobuf.Timestamp
+	97,  // 20: api.ChoiceInfo.end:type_name -> google.protobuf.Timestamp
+	96,  // 21: api.ChoiceInfo.stats:type_name -> api.ChoiceInfo.StatsEntry
+	3,   // 22: api.ChoiceInfo.option_details:type_name -> api.Option
+	33,  // 23: api.ChoiceInfo.pending_follow_ups:type_name -> api.FollowUp
+	59,  // 24: api.ChoiceInfo.comment:type_name -> api.Comment
+	59,  // 25: api.ChoiceInfo.comments:type_name -> api.Comment
+	43,  // 26: api.ChoiceInfo.last_change:type_name -> api.BallotChange
+	19,  // 27: api.GetRankedInfoResponse.response:type_name -> api.RankedInfo
+	97,  // 28: api.RankedInfo.end:type_name -> google.protobuf.Timestamp
+	3,   // 29: api.RankedInfo.options:type_name -> api.Option
+	20,  // 30: api.RankedInfo.rounds:type_name -> api.RankedRound
+	21,  // 31: api.RankedRound.tallies:type_name -> api.OptionTally
+	23,  // 32: api.GetAllocationInfoResponse.response:type_name -> api.AllocationInfo
+	97,  // 33: api.AllocationInfo.end:type_name -> google.protobuf.Timestamp
+	3,   // 34: api.AllocationInfo.options:type_name -> api.Option
+	24,  // 35: api.AllocationInfo.results:type_name -> api.OptionAllocation
+	26,  // 36: api.AllocationInfo.allocations:type_name -> api.Allocation
+	25,  // 37: api.OptionAllocation.distribution:type_name -> api.AllocationCount
+	28,  // 38: api.GetSurveyInfoResponse.response:type_name -> api.SurveyInfo
+	97,  // 39: api.SurveyInfo.end:type_name -> google.protobuf.Timestamp
+	29,  // 40: api.SurveyInfo.questions:type_name -> api.Question
+	30,  // 41: api.SurveyInfo.results:type_name -> api.QuestionResult
+	32,  // 42: api.SurveyInfo.answers:type_name -> api.SurveyAnswer
+	97,  // 43: api.SurveyInfo.updated:type_name -> google.protobuf.Timestamp
+	3,   // 44: api.Question.options:type_name -> api.Option
+	48,  // 45: api.Question.rate_scale:type_name -> api.RateScale
+	31,  // 46: api.QuestionResult.ratings:type_name -> api.RatingCount
+	21,  // 47: api.QuestionResult.options:type_name -> api.OptionTally
+	3,   // 48: api.FollowUp.options:type_name -> api.Option
+	48,  // 49: api.FollowUp.rate_scale:type_name -> api.RateScale
+	34,  // 50: api.FollowUp.trigger:type_name -> api.FollowUpTrigger
+	26,  // 51: api.VoteAllocationRequest.allocations:type_name -> api.Allocation
+	32,  // 52: api.SubmitSurveyRequest.answers:type_name -> api.SurveyAnswer
+	32,  // 53: api.AnswerFollowUpsRequest.answers:type_name -> api.SurveyAnswer
+	97,  // 54: api.BallotChange.changed:type_name -> google.protobuf.Timestamp
+	97,  // 55: api.VoteDefinition.end:type_name -> google.protobuf.Timestamp
+	97,  // 56: api.VoteDefinition.start:type_name -> google.protobuf.Timestamp
+	48,  // 57: api.VoteDefinition.rate_scale:type_name -> api.RateScale
+	3,   // 58: api.VoteDefinition.option_details:type_name -> api.Option
+	29,  // 59: api.VoteDefinition.questions:type_name -> api.Question
+	33,  // 60: api.VoteDefinition.follow_ups:type_name -> api.FollowUp
+	97,  // 61: api.VoteDefinition.response_due:type_name -> google.protobuf.Timestamp
+	47,  // 62: api.CreateVoteRequest.vote:type_name -> api.VoteDefinition
+	47,  // 63: api.CreateVoteResponse.response:type_name -> api.VoteDefinition
+	47,  // 64: api.UpdateVoteRequest.vote:type_name -> api.VoteDefinition
+	47,  // 65: api.UpdateVoteResponse.response:type_name -> api.VoteDefinition
+	47,  // 66: api.ListAllVotesResponse.response:type_name -> api.VoteDefinition
+	47,  // 67: api.SetVoteStatusResponse.response:type_name -> api.VoteDefinition
+	97,  // 68: api.Comment.created:type_name -> google.protobuf.Timestamp
+	97,  // 69: api.Comment.reviewed:type_name -> google.protobuf.Timestamp
+	59,  // 70: api.ListCommentsResponse.response:type_name -> api.Comment
+	59,  // 71: api.ReviewCommentResponse.response:type_name -> api.Comment
+	97,  // 72: api.CreatePetitionRequest.end:type_name -> google.protobuf.Timestamp
+	47,  // 73: api.ReviewPetitionResponse.response:type_name -> api.VoteDefinition
+	16,  // 74: api.PublishPetitionResponseRequest.attachments:type_name -> api.Attachment
+	15,  // 75: api.PublishPetitionResponseResponse.response:type_name -> api.PetitionResponse
+	47,  // 76: api.ListOverduePetitionsResponse.response:type_name -> api.VoteDefinition
+	72,  // 77: api.ListOrganizationsResponse.response:type_name -> api.Organization
+	72,  // 78: api.GetOrganizationResponse.response:type_name -> api.Organization
+	72,  // 79: api.CreateOrganizationRequest.organization:type_name -> api.Organization
+	72,  // 80: api.CreateOrganizationResponse.response:type_name -> api.Organization
+	72,  // 81: api.UpdateOrganizationRequest.organization:type_name -> api.Organization
+	72,  // 82: api.UpdateOrganizationResponse.response:type_name -> api.Organization
+	81,  // 83: api.ListTopicsResponse.response:type_name -> api.Topic
+	81,  // 84: api.CreateTopicRequest.topic:type_name -> api.Topic
+	81,  // 85: api.CreateTopicResponse.response:type_name -> api.Topic
+	81,  // 86: api.UpdateTopicRequest.topic:type_name -> api.Topic
+	81,  // 87: api.UpdateTopicResponse.response:type_name -> api.Topic
+	97,  // 88: api.RoleAssignment.granted:type_name -> google.protobuf.Timestamp
+	88,  // 89: api.ListRoleAssignmentsResponse.response:type_name -> api.RoleAssignment
+	88,  // 90: api.GrantRoleRequest.assignment:type_name -> api.RoleAssignment
+	88,  // 91: api.GrantRoleResponse.response:type_name -> api.RoleAssignment
+	88,  // 92: api.RevokeRoleRequest.assignment:type_name -> api.RoleAssignment
+	0,   // 93: api.VotesService.GetVotes:input_type -> api.GetVotesRequest
+	4,   // 94: api.VotesService.GetCategories:input_type -> api.GetCategoriesRequest
+	6,   // 95: api.VotesService.SearchVotes:input_type -> api.SearchVotesRequest
+	9,   // 96: api.VotesService.GetRateInfo:input_type -> api.GetVoteInfoRequest
+	9,   // 97: api.VotesService.GetPetitionInfo:input_type -> api.GetVoteInfoRequest
+	9,   // 98: api.VotesService.GetChoiceInfo:input_type -> api.GetVoteInfoRequest
+	9,   // 99: api.VotesService.GetRankedInfo:input_type -> api.GetVoteInfoRequest
+	9,   // 100: api.VotesService.GetAllocationInfo:input_type -> api.GetVoteInfoRequest
+	9,   // 101: api.VotesService.GetSurveyInfo:input_type -> api.GetVoteInfoRequest
+	35,  // 102: api.VotesService.VoteRate:input_type -> api.VoteRateRequest
+	36,  // 103: api.VotesService.VotePetition:input_type -> api.VotePetitionRequest
+	37,  // 104: api.VotesService.VoteChoice:input_type -> api.VoteChoiceRequest
+	38,  // 105: api.VotesService.VoteRanked:input_type -> api.VoteRankedRequest
+	39,  // 106: api.VotesService.VoteAllocation:input_type -> api.VoteAllocationRequest
+	40,  // 107: api.VotesService.SubmitSurvey:input_type -> api.SubmitSurveyRequest
+	41,  // 108: api.VotesService.AnswerFollowUps:input_type -> api.AnswerFollowUpsRequest
+	42,  // 109: api.VotesService.WithdrawVote:input_type -> api.WithdrawVoteRequest
+	64,  // 110: api.VotesService.CreatePetition:input_type -> api.CreatePetitionRequest
+	73,  // 111: api.VotesService.ListOrganizations:input_type -> api.ListOrganizationsRequest
+	75,  // 112: api.VotesService.GetOrganization:input_type -> api.GetOrganizationRequest
+	82,  // 113: api.VotesService.ListTopics:input_type -> api.ListTopicsRequest
+	45,  // 114: api.VotesService.HealthCheck:input_type -> api.HealthCheckRequest
+	49,  // 115: api.VotesAdminService.CreateVote:input_type -> api.CreateVoteRequest
+	51,  // 116: api.VotesAdminService.UpdateVote:input_type -> api.UpdateVoteRequest
+	53,  // 117: api.VotesAdminService.DeleteVote:input_type -> api.DeleteVoteRequest
+	55,  // 118: api.VotesAdminService.ListAllVotes:input_type -> api.ListAllVotesRequest
+	57,  // 119: api.VotesAdminService.SetVoteStatus:input_type -> api.SetVoteStatusRequest
+	60,  // 120: api.VotesAdminService.ListComments:input_type -> api.ListCommentsRequest
+	62,  // 121: api.VotesAdminService.ReviewComment:input_type -> api.ReviewCommentRequest
+	66,  // 122: api.VotesAdminService.ReviewPetition:input_type -> api.ReviewPetitionRequest
+	68,  // 123: api.VotesAdminService.PublishPetitionResponse:input_type -> api.PublishPetitionResponseRequest
+	70,  // 124: api.VotesAdminService.ListOverduePetitions:input_type -> api.ListOverduePetitionsRequest
+	77,  // 125: api.VotesAdminService.CreateOrganization:input_type -> api.CreateOrganizationRequest
+	79,  // 126: api.VotesAdminService.UpdateOrganization:input_type -> api.UpdateOrganizationRequest
+	84,  // 127: api.VotesAdminService.CreateTopic:input_type -> api.CreateTopicRequest
+	86,  // 128: api.VotesAdminService.UpdateTopic:input_type -> api.UpdateTopicRequest
+	89,  // 129: api.VotesAdminService.ListRoleAssignments:input_type -> api.ListRoleAssignmentsRequest
+	91,  // 130: api.VotesAdminService.GrantRole:input_type -> api.GrantRoleRequest
+	93,  // 131: api.VotesAdminService.RevokeRole:input_type -> api.RevokeRoleRequest
+	1,   // 132: api.VotesService.GetVotes:output_type -> api.GetVotesResponse
+	5,   // 133: api.VotesService.GetCategories:output_type -> api.GetCategoriesResponse
+	7,   // 134: api.VotesService.SearchVotes:output_type -> api.SearchVotesResponse
+	10,  // 135: api.VotesService.GetRateInfo:output_type -> api.GetRateInfoResponse
+	11,  // 136: api.VotesService.GetPetitionInfo:output_type -> api.GetPetitionInfoResponse
+	12,  // 137: api.VotesService.GetChoiceInfo:output_type -> api.GetChoiceInfoResponse
+	18,  // 138: api.VotesService.GetRankedInfo:output_type -> api.GetRankedInfoResponse
+	22,  // 139: api.VotesService.GetAllocationInfo:output_type -> api.GetAllocationInfoResponse
+	27,  // 140: api.VotesService.GetSurveyInfo:output_type -> api.GetSurveyInfoResponse
+	44,  // 141: api.VotesService.VoteRate:output_type -> api.VoteResponse
+	44,  // 142: api.VotesService.VotePetition:output_type -> api.VoteResponse
+	44,  // 143: api.VotesService.VoteChoice:output_type -> api.VoteResponse
+	44,  // 144: api.VotesService.VoteRanked:output_type -> api.VoteResponse
+	44,  // 145: api.VotesService.VoteAllocation:output_type -> api.VoteResponse
+	44,  // 146: api.VotesService.SubmitSurvey:output_type -> api.VoteResponse
+	44,  // 147: api.VotesService.AnswerFollowUps:output_type -> api.VoteResponse
+	44,  // 148: api.VotesService.WithdrawVote:output_type -> api.VoteResponse
+	65,  // 149: api.VotesService.CreatePetition:output_type -> api.CreatePetitionResponse
+	74,  // 150: api.VotesService.ListOrganizations:output_type -> api.ListOrganizationsResponse
+	76,  // 151: api.VotesService.GetOrganization:output_type -> api.GetOrganizationResponse
+	83,  // 152: api.VotesService.ListTopics:output_type -> api.ListTopicsResponse
+	46,  // 153: api.VotesService.HealthCheck:output_type -> api.HealthCheckResponse
+	50,  // 154: api.VotesAdminService.CreateVote:output_type -> api.CreateVoteResponse
+	52,  // 155: api.VotesAdminService.UpdateVote:output_type -> api.UpdateVoteResponse
+	54,  // 156: api.VotesAdminService.DeleteVote:output_type -> api.DeleteVoteResponse
+	56,  // 157: api.VotesAdminService.ListAllVotes:output_type -> api.ListAllVotesResponse
+	58,  // 158: api.VotesAdminService.SetVoteStatus:output_type -> api.SetVoteStatusResponse
+	61,  // 159: api.VotesAdminService.ListComments:output_type -> api.ListCommentsResponse
+	63,  // 160: api.VotesAdminService.ReviewComment:output_type -> api.ReviewCommentResponse
+	67,  // 161: api.VotesAdminService.ReviewPetition:output_type -> api.ReviewPetitionResponse
+	69,  // 162: api.VotesAdminService.PublishPetitionResponse:output_type -> api.PublishPetitionResponseResponse
+	71,  // 163: api.VotesAdminService.ListOverduePetitions:output_type -> api.ListOverduePetitionsResponse
+	78,  // 164: api.VotesAdminService.CreateOrganization:output_type -> api.CreateOrganizationResponse
+	80,  // 165: api.VotesAdminService.UpdateOrganization:output_type -> api.UpdateOrganizationResponse
+	85,  // 166: api.VotesAdminService.CreateTopic:output_type -> api.CreateTopicResponse
+	87,  // 167: api.VotesAdminService.UpdateTopic:output_type -> api.UpdateTopicResponse
+	90,  // 168: api.VotesAdminService.ListRoleAssignments:output_type -> api.ListRoleAssignmentsResponse
+	92,  // 169: api.VotesAdminService.GrantRole:output_type -> api.GrantRoleResponse
+	94,  // 170: api.VotesAdminService.RevokeRole:output_type -> api.RevokeRoleResponse
+	132, // [132:171] is the sub-list for method output_type
+	93,  // [93:132] is the sub-list for method input_type
+	93,  // [93:93] is the sub-list for extension type_name
+	93,  // [93:93] is the sub-list for extension extendee
+	0,   // [0:93] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_votes_proto_init() }
+func file_api_proto_votes_proto_init() {
+	if File_api_proto_votes_proto != nil {
+		return
+	}
+	file_api_proto_votes_proto_msgTypes[32].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_votes_proto_rawDesc), len(file_api_proto_votes_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   97,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_api_proto_votes_proto_goTypes,
+		DependencyIndexes: file_api_proto_votes_proto_depIdxs,
+		MessageInfos:      file_api_proto_votes_proto_msgTypes,
+	}.Build()
+	File_api_proto_votes_proto = out.File
+	file_api_proto_votes_proto_goTypes = nil
+	file_api_proto_votes_proto_depIdxs = nil
+}
@@ -0,0 +1,1653 @@
+//protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/proto/votes.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/proto/votes.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VotesService_GetVotes_FullMethodName          = "/api.VotesService/GetVotes"
+	VotesService_GetCategories_FullMethodName     = "/api.VotesService/GetCategories"
+	VotesService_SearchVotes_FullMethodName       = "/api.VotesService/SearchVotes"
+	VotesService_GetRateInfo_FullMethodName       = "/api.VotesService/GetRateInfo"
+	VotesService_GetPetitionInfo_FullMethodName   = "/api.VotesService/GetPetitionInfo"
+	VotesService_GetChoiceInfo_FullMethodName     = "/api.VotesService/GetChoiceInfo"
+	VotesService_GetRankedInfo_FullMethodName     = "/api.VotesService/GetRankedInfo"
+	VotesService_GetAllocationInfo_FullMethodName = "/api.VotesService/GetAllocationInfo"
+	VotesService_GetSurveyInfo_FullMethodName     = "/api.VotesService/GetSurveyInfo"
+	VotesService_VoteRate_FullMethodName          = "/api.VotesService/VoteRate"
+	VotesService_VotePetition_FullMethodName      = "/api.VotesService/VotePetition"
+	VotesService_VoteChoice_FullMethodName        = "/api.VotesService/VoteChoice"
+	VotesService_VoteRanked_FullMethodName        = "/api.VotesService/VoteRanked"
+	VotesService_VoteAllocation_FullMethodName    = "/api.VotesService/VoteAllocation"
+	VotesService_SubmitSurvey_FullMethodName      = "/api.VotesService/SubmitSurvey"
+	VotesService_AnswerFollowUps_FullMethodName   = "/api.VotesService/AnswerFollowUps"
+	VotesService_WithdrawVote_FullMethodName      = "/api.VotesService/WithdrawVote"
+	VotesService_CreatePetition_FullMethodName    = "/api.VotesService/CreatePetition"
+	VotesService_ListOrganizations_FullMethodName = "/api.VotesService/ListOrganizations"
+	VotesService_GetOrganization_FullMethodName   = "/api.VotesService/GetOrganization"
+	VotesService_ListTopics_FullMethodName        = "/api.VotesService/ListTopics"
+	VotesService_HealthCheck_FullMethodName       = "/api.VotesService/HealthCheck"
+)
+
+// VotesServiceClient is the client API for VotesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// User tokens are sent as "authorization: Bearer <token>" metadata or in the
+// token field of a request and are verified by the server; ballots are
+// recorded for the user the token was issued to. Invalid tokens are
+// rejected with UNAUTHENTICATED.
+type VotesServiceClient interface {
+	GetVotes(ctx context.Context, in *GetVotesRequest, opts ...grpc.CallOption) (*GetVotesResponse, error)
+	GetCategories(ctx context.Context, in *GetCategoriesRequest, opts ...grpc.CallOption) (*GetCategoriesResponse, error)
+	SearchVotes(ctx context.Context, in *SearchVotesRequest, opts ...grpc.CallOption) (*SearchVotesResponse, error)
+	GetRateInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetRateInfoResponse, error)
+	GetPetitionInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetPetitionInfoResponse, error)
+	GetChoiceInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetChoiceInfoResponse, error)
+	GetRankedInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetRankedInfoResponse, error)
+	GetAllocationInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetAllocationInfoResponse, error)
+	GetSurveyInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetSurveyInfoResponse, error)
+	VoteRate(ctx context.Context, in *VoteRateRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	VotePetition(ctx context.Context, in *VotePetitionRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	VoteChoice(ctx context.Context, in *VoteChoiceRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	VoteRanked(ctx context.Context, in *VoteRankedRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	VoteAllocation(ctx context.Context, in *VoteAllocationRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	SubmitSurvey(ctx context.Context, in *SubmitSurveyRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	AnswerFollowUps(ctx context.Context, in *AnswerFollowUpsRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	WithdrawVote(ctx context.Context, in *WithdrawVoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	CreatePetition(ctx context.Context, in *CreatePetitionRequest, opts ...grpc.CallOption) (*CreatePetitionResponse, error)
+	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error)
+	ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error)
+	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+}
+
+type votesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVotesServiceClient(cc grpc.ClientConnInterface) VotesServiceClient {
+	return &votesServiceClient{cc}
+}
+
+func (c *votesServiceClient) GetVotes(ctx context.Context, in *GetVotesRequest, opts ...grpc.CallOption) (*GetVotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVotesResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetVotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetCategories(ctx context.Context, in *GetCategoriesRequest, opts ...grpc.CallOption) (*GetCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoriesResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) SearchVotes(ctx context.Context, in *SearchVotesRequest, opts ...grpc.CallOption) (*SearchVotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchVotesResponse)
+	err := c.cc.Invoke(ctx, VotesService_SearchVotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetRateInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetRateInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRateInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetRateInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetPetitionInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetPetitionInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPetitionInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetPetitionInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetChoiceInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetChoiceInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChoiceInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetChoiceInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetRankedInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetRankedInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankedInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetRankedInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetAllocationInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetAllocationInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllocationInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetAllocationInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetSurveyInfo(ctx context.Context, in *GetVoteInfoRequest, opts ...grpc.CallOption) (*GetSurveyInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSurveyInfoResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetSurveyInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) VoteRate(ctx context.Context, in *VoteRateRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_VoteRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) VotePetition(ctx context.Context, in *VotePetitionRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_VotePetition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) VoteChoice(ctx context.Context, in *VoteChoiceRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_VoteChoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) VoteRanked(ctx context.Context, in *VoteRankedRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_VoteRanked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) VoteAllocation(ctx context.Context, in *VoteAllocationRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_VoteAllocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) SubmitSurvey(ctx context.Context, in *SubmitSurveyRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_SubmitSurvey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) AnswerFollowUps(ctx context.Context, in *AnswerFollowUpsRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_AnswerFollowUps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) WithdrawVote(ctx context.Context, in *WithdrawVoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, VotesService_WithdrawVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) CreatePetition(ctx context.Context, in *CreatePetitionRequest, opts ...grpc.CallOption) (*CreatePetitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePetitionResponse)
+	err := c.cc.Invoke(ctx, VotesService_CreatePetition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, VotesService_ListOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) GetOrganization(ctx context.Context, in *GetOrganizationRequest, opts ...grpc.CallOption) (*GetOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrganizationResponse)
+	err := c.cc.Invoke(ctx, VotesService_GetOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopicsResponse)
+	err := c.cc.Invoke(ctx, VotesService_ListTopics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesServiceClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, VotesService_HealthCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VotesServiceServer is the server API for VotesService service.
+// All implementations must embed UnimplementedVotesServiceServer
+// for forward compatibility.
+//
+// User tokens are sent as "authorization: Bearer <token>" metadata or in the
+// token field of a request and are verified by the server; ballots are
+// recorded for the user the token was issued to. Invalid tokens are
+// rejected with UNAUTHENTICATED.
+type VotesServiceServer interface {
+	GetVotes(context.Context, *GetVotesRequest) (*GetVotesResponse, error)
+	GetCategories(context.Context, *GetCategoriesRequest) (*GetCategoriesResponse, error)
+	SearchVotes(context.Context, *SearchVotesRequest) (*SearchVotesResponse, error)
+	GetRateInfo(context.Context, *GetVoteInfoRequest) (*GetRateInfoResponse, error)
+	GetPetitionInfo(context.Context, *GetVoteInfoRequest) (*GetPetitionInfoResponse, error)
+	GetChoiceInfo(context.Context, *GetVoteInfoRequest) (*GetChoiceInfoResponse, error)
+	GetRankedInfo(context.Context, *GetVoteInfoRequest) (*GetRankedInfoResponse, error)
+	GetAllocationInfo(context.Context, *GetVoteInfoRequest) (*GetAllocationInfoResponse, error)
+	GetSurveyInfo(context.Context, *GetVoteInfoRequest) (*GetSurveyInfoResponse, error)
+	VoteRate(context.Context, *VoteRateRequest) (*VoteResponse, error)
+	VotePetition(context.Context, *VotePetitionRequest) (*VoteResponse, error)
+	VoteChoice(context.Context, *VoteChoiceRequest) (*VoteResponse, error)
+	VoteRanked(context.Context, *VoteRankedRequest) (*VoteResponse, error)
+	VoteAllocation(context.Context, *VoteAllocationRequest) (*VoteResponse, error)
+	SubmitSurvey(context.Context, *SubmitSurveyRequest) (*VoteResponse, error)
+	AnswerFollowUps(context.Context, *AnswerFollowUpsRequest) (*VoteResponse, error)
+	WithdrawVote(context.Context, *WithdrawVoteRequest) (*VoteResponse, error)
+	CreatePetition(context.Context, *CreatePetitionRequest) (*CreatePetitionResponse, error)
+	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error)
+	ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error)
+	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	mustEmbedUnimplementedVotesServiceServer()
+}
+
+// UnimplementedVotesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVotesServiceServer struct{}
+
+func (UnimplementedVotesServiceServer) GetVotes(context.Context, *GetVotesRequest) (*GetVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVotes not implemented")
+}
+func (UnimplementedVotesServiceServer) GetCategories(context.Context, *GetCategoriesRequest) (*GetCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategories not implemented")
+}
+func (UnimplementedVotesServiceServer) SearchVotes(context.Context, *SearchVotesRequest) (*SearchVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchVotes not implemented")
+}
+func (UnimplementedVotesServiceServer) GetRateInfo(context.Context, *GetVoteInfoRequest) (*GetRateInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) GetPetitionInfo(context.Context, *GetVoteInfoRequest) (*GetPetitionInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPetitionInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) GetChoiceInfo(context.Context, *GetVoteInfoRequest) (*GetChoiceInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChoiceInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) GetRankedInfo(context.Context, *GetVoteInfoRequest) (*GetRankedInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRankedInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) GetAllocationInfo(context.Context, *GetVoteInfoRequest) (*GetAllocationInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllocationInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) GetSurveyInfo(context.Context, *GetVoteInfoRequest) (*GetSurveyInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSurveyInfo not implemented")
+}
+func (UnimplementedVotesServiceServer) VoteRate(context.Context, *VoteRateRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteRate not implemented")
+}
+func (UnimplementedVotesServiceServer) VotePetition(context.Context, *VotePetitionRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VotePetition not implemented")
+}
+func (UnimplementedVotesServiceServer) VoteChoice(context.Context, *VoteChoiceRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteChoice not implemented")
+}
+func (UnimplementedVotesServiceServer) VoteRanked(context.Context, *VoteRankedRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteRanked not implemented")
+}
+func (UnimplementedVotesServiceServer) VoteAllocation(context.Context, *VoteAllocationRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteAllocation not implemented")
+}
+func (UnimplementedVotesServiceServer) SubmitSurvey(context.Context, *SubmitSurveyRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSurvey not implemented")
+}
+func (UnimplementedVotesServiceServer) AnswerFollowUps(context.Context, *AnswerFollowUpsRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerFollowUps not implemented")
+}
+func (UnimplementedVotesServiceServer) WithdrawVote(context.Context, *WithdrawVoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawVote not implemented")
+}
+func (UnimplementedVotesServiceServer) CreatePetition(context.Context, *CreatePetitionRequest) (*CreatePetitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePetition not implemented")
+}
+func (UnimplementedVotesServiceServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedVotesServiceServer) GetOrganization(context.Context, *GetOrganizationRequest) (*GetOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganization not implemented")
+}
+func (UnimplementedVotesServiceServer) ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopics not implemented")
+}
+func (UnimplementedVotesServiceServer) HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedVotesServiceServer) mustEmbedUnimplementedVotesServiceServer() {}
+func (UnimplementedVotesServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeVotesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VotesServiceServer will
+// result in compilation errors.
+type UnsafeVotesServiceServer interface {
+	mustEmbedUnimplementedVotesServiceServer()
+}
+
+func RegisterVotesServiceServer(s grpc.ServiceRegistrar, srv VotesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVotesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VotesService_ServiceDesc, srv)
+}
+
+func _VotesService_GetVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetVotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetVotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetVotes(ctx, req.(*GetVotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetCategories(ctx, req.(*GetCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_SearchVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchVotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).SearchVotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_SearchVotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).SearchVotes(ctx, req.(*SearchVotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetRateInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetRateInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetRateInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetRateInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetPetitionInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetPetitionInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetPetitionInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetPetitionInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetChoiceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetChoiceInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetChoiceInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetChoiceInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetRankedInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetRankedInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetRankedInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetRankedInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetAllocationInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetAllocationInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetAllocationInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetAllocationInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetSurveyInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoteInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetSurveyInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetSurveyInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetSurveyInfo(ctx, req.(*GetVoteInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_VoteRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).VoteRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_VoteRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).VoteRate(ctx, req.(*VoteRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_VotePetition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePetitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).VotePetition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_VotePetition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).VotePetition(ctx, req.(*VotePetitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_VoteChoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteChoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).VoteChoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_VoteChoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).VoteChoice(ctx, req.(*VoteChoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_VoteRanked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRankedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).VoteRanked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_VoteRanked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).VoteRanked(ctx, req.(*VoteRankedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_VoteAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).VoteAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_VoteAllocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).VoteAllocation(ctx, req.(*VoteAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_SubmitSurvey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitSurveyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).SubmitSurvey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_SubmitSurvey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).SubmitSurvey(ctx, req.(*SubmitSurveyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_AnswerFollowUps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerFollowUpsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).AnswerFollowUps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_AnswerFollowUps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).AnswerFollowUps(ctx, req.(*AnswerFollowUpsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_WithdrawVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).WithdrawVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_WithdrawVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).WithdrawVote(ctx, req.(*WithdrawVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_CreatePetition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePetitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).CreatePetition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_CreatePetition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).CreatePetition(ctx, req.(*CreatePetitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_ListOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).ListOrganizations(ctx, req.(*ListOrganizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_GetOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).GetOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_GetOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).GetOrganization(ctx, req.(*GetOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_ListTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).ListTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_ListTopics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).ListTopics(ctx, req.(*ListTopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesServiceServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesService_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesServiceServer).HealthCheck(ctx, req.(*HealthCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VotesService_ServiceDesc is the grpc.ServiceDesc for VotesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VotesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.VotesService",
+	HandlerType: (*VotesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVotes",
+			Handler:    _VotesService_GetVotes_Handler,
+		},
+		{
+			MethodName: "GetCategories",
+			Handler:    _VotesService_GetCategories_Handler,
+		},
+		{
+			MethodName: "SearchVotes",
+			Handler:    _VotesService_SearchVotes_Handler,
+		},
+		{
+			MethodName: "GetRateInfo",
+			Handler:    _VotesService_GetRateInfo_Handler,
+		},
+		{
+			MethodName: "GetPetitionInfo",
+			Handler:    _VotesService_GetPetitionInfo_Handler,
+		},
+		{
+			MethodName: "GetChoiceInfo",
+			Handler:    _VotesService_GetChoiceInfo_Handler,
+		},
+		{
+			MethodName: "GetRankedInfo",
+			Handler:    _VotesService_GetRankedInfo_Handler,
+		},
+		{
+			MethodName: "GetAllocationInfo",
+			Handler:    _VotesService_GetAllocationInfo_Handler,
+		},
+		{
+			MethodName: "GetSurveyInfo",
+			Handler:    _VotesService_GetSurveyInfo_Handler,
+		},
+		{
+			MethodName: "VoteRate",
+			Handler:    _VotesService_VoteRate_Handler,
+		},
+		{
+			MethodName: "VotePetition",
+			Handler:    _VotesService_VotePetition_Handler,
+		},
+		{
+			MethodName: "VoteChoice",
+			Handler:    _VotesService_VoteChoice_Handler,
+		},
+		{
+			MethodName: "VoteRanked",
+			Handler:    _VotesService_VoteRanked_Handler,
+		},
+		{
+			MethodName: "VoteAllocation",
+			Handler:    _VotesService_VoteAllocation_Handler,
+		},
+		{
+			MethodName: "SubmitSurvey",
+			Handler:    _VotesService_SubmitSurvey_Handler,
+		},
+		{
+			MethodName: "AnswerFollowUps",
+			Handler:    _VotesService_AnswerFollowUps_Handler,
+		},
+		{
+			MethodName: "WithdrawVote",
+			Handler:    _VotesService_WithdrawVote_Handler,
+		},
+		{
+			MethodName: "CreatePetition",
+			Handler:    _VotesService_CreatePetition_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _VotesService_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "GetOrganization",
+			Handler:    _VotesService_GetOrganization_Handler,
+		},
+		{
+			MethodName: "ListTopics",
+			Handler:    _VotesService_ListTopics_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _VotesService_HealthCheck_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/votes.proto",
+}
+
+const (
+	VotesAdminService_CreateVote_FullMethodName              = "/api.VotesAdminService/CreateVote"
+	VotesAdminService_UpdateVote_FullMethodName              = "/api.VotesAdminService/UpdateVote"
+	VotesAdminService_DeleteVote_FullMethodName              = "/api.VotesAdminService/DeleteVote"
+	VotesAdminService_ListAllVotes_FullMethodName            = "/api.VotesAdminService/ListAllVotes"
+	VotesAdminService_SetVoteStatus_FullMethodName           = "/api.VotesAdminService/SetVoteStatus"
+	VotesAdminService_ListComments_FullMethodName            = "/api.VotesAdminService/ListComments"
+	VotesAdminService_ReviewComment_FullMethodName           = "/api.VotesAdminService/ReviewComment"
+	VotesAdminService_ReviewPetition_FullMethodName          = "/api.VotesAdminService/ReviewPetition"
+	VotesAdminService_PublishPetitionResponse_FullMethodName = "/api.VotesAdminService/PublishPetitionResponse"
+	VotesAdminService_ListOverduePetitions_FullMethodName    = "/api.VotesAdminService/ListOverduePetitions"
+	VotesAdminService_CreateOrganization_FullMethodName      = "/api.VotesAdminService/CreateOrganization"
+	VotesAdminService_UpdateOrganization_FullMethodName      = "/api.VotesAdminService/UpdateOrganization"
+	VotesAdminService_CreateTopic_FullMethodName             = "/api.VotesAdminService/CreateTopic"
+	VotesAdminService_UpdateTopic_FullMethodName             = "/api.VotesAdminService/UpdateTopic"
+	VotesAdminService_ListRoleAssignments_FullMethodName     = "/api.VotesAdminService/ListRoleAssignments"
+	VotesAdminService_GrantRole_FullMethodName               = "/api.VotesAdminService/GrantRole"
+	VotesAdminService_RevokeRole_FullMethodName              = "/api.VotesAdminService/RevokeRole"
+)
+
+// VotesAdminServiceClient is the client API for VotesAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Admin RPCs require a token of a user with a staff role: admin,
+// editor, moderator or analyst. Editors manage only the votes of their
+// organization. Callers without a matching role are refused with
+// PERMISSION_DENIED; the error details name the method, the roles that
+// would have been admitted and, for editors, the organization.
+type VotesAdminServiceClient interface {
+	CreateVote(ctx context.Context, in *CreateVoteRequest, opts ...grpc.CallOption) (*CreateVoteResponse, error)
+	UpdateVote(ctx context.Context, in *UpdateVoteRequest, opts ...grpc.CallOption) (*UpdateVoteResponse, error)
+	DeleteVote(ctx context.Context, in *DeleteVoteRequest, opts ...grpc.CallOption) (*DeleteVoteResponse, error)
+	ListAllVotes(ctx context.Context, in *ListAllVotesRequest, opts ...grpc.CallOption) (*ListAllVotesResponse, error)
+	SetVoteStatus(ctx context.Context, in *SetVoteStatusRequest, opts ...grpc.CallOption) (*SetVoteStatusResponse, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	ReviewComment(ctx context.Context, in *ReviewCommentRequest, opts ...grpc.CallOption) (*ReviewCommentResponse, error)
+	ReviewPetition(ctx context.Context, in *ReviewPetitionRequest, opts ...grpc.CallOption) (*ReviewPetitionResponse, error)
+	PublishPetitionResponse(ctx context.Context, in *PublishPetitionResponseRequest, opts ...grpc.CallOption) (*PublishPetitionResponseResponse, error)
+	ListOverduePetitions(ctx context.Context, in *ListOverduePetitionsRequest, opts ...grpc.CallOption) (*ListOverduePetitionsResponse, error)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error)
+	UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*UpdateOrganizationResponse, error)
+	CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error)
+	UpdateTopic(ctx context.Context, in *UpdateTopicRequest, opts ...grpc.CallOption) (*UpdateTopicResponse, error)
+	ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...grpc.CallOption) (*ListRoleAssignmentsResponse, error)
+	GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*GrantRoleResponse, error)
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error)
+}
+
+type votesAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVotesAdminServiceClient(cc grpc.ClientConnInterface) VotesAdminServiceClient {
+	return &votesAdminServiceClient{cc}
+}
+
+func (c *votesAdminServiceClient) CreateVote(ctx context.Context, in *CreateVoteRequest, opts ...grpc.CallOption) (*CreateVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVoteResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_CreateVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) UpdateVote(ctx context.Context, in *UpdateVoteRequest, opts ...grpc.CallOption) (*UpdateVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateVoteResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_UpdateVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) DeleteVote(ctx context.Context, in *DeleteVoteRequest, opts ...grpc.CallOption) (*DeleteVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVoteResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_DeleteVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ListAllVotes(ctx context.Context, in *ListAllVotesRequest, opts ...grpc.CallOption) (*ListAllVotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAllVotesResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ListAllVotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) SetVoteStatus(ctx context.Context, in *SetVoteStatusRequest, opts ...grpc.CallOption) (*SetVoteStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetVoteStatusResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_SetVoteStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ReviewComment(ctx context.Context, in *ReviewCommentRequest, opts ...grpc.CallOption) (*ReviewCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewCommentResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ReviewComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ReviewPetition(ctx context.Context, in *ReviewPetitionRequest, opts ...grpc.CallOption) (*ReviewPetitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewPetitionResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ReviewPetition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) PublishPetitionResponse(ctx context.Context, in *PublishPetitionResponseRequest, opts ...grpc.CallOption) (*PublishPetitionResponseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishPetitionResponseResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_PublishPetitionResponse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ListOverduePetitions(ctx context.Context, in *ListOverduePetitionsRequest, opts ...grpc.CallOption) (*ListOverduePetitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOverduePetitionsResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ListOverduePetitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*CreateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrganizationResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) UpdateOrganization(ctx context.Context, in *UpdateOrganizationRequest, opts ...grpc.CallOption) (*UpdateOrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateOrganizationResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_UpdateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTopicResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_CreateTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) UpdateTopic(ctx context.Context, in *UpdateTopicRequest, opts ...grpc.CallOption) (*UpdateTopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTopicResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_UpdateTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...grpc.CallOption) (*ListRoleAssignmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleAssignmentsResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_ListRoleAssignments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*GrantRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantRoleResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_GrantRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *votesAdminServiceClient) RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeRoleResponse)
+	err := c.cc.Invoke(ctx, VotesAdminService_RevokeRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VotesAdminServiceServer is the server API for VotesAdminService service.
+// All implementations must embed UnimplementedVotesAdminServiceServer
+// for forward compatibility.
+//
+// Admin RPCs require a token of a user with a staff role: admin,
+// editor, moderator or analyst. Editors manage only the votes of their
+// organization. Callers without a matching role are refused with
+// PERMISSION_DENIED; the error details name the method, the roles that
+// would have been admitted and, for editors, the organization.
+type VotesAdminServiceServer interface {
+	CreateVote(context.Context, *CreateVoteRequest) (*CreateVoteResponse, error)
+	UpdateVote(context.Context, *UpdateVoteRequest) (*UpdateVoteResponse, error)
+	DeleteVote(context.Context, *DeleteVoteRequest) (*DeleteVoteResponse, error)
+	ListAllVotes(context.Context, *ListAllVotesRequest) (*ListAllVotesResponse, error)
+	SetVoteStatus(context.Context, *SetVoteStatusRequest) (*SetVoteStatusResponse, error)
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	ReviewComment(context.Context, *ReviewCommentRequest) (*ReviewCommentResponse, error)
+	ReviewPetition(context.Context, *ReviewPetitionRequest) (*ReviewPetitionResponse, error)
+	PublishPetitionResponse(context.Context, *PublishPetitionResponseRequest) (*PublishPetitionResponseResponse, error)
+	ListOverduePetitions(context.Context, *ListOverduePetitionsRequest) (*ListOverduePetitionsResponse, error)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error)
+	UpdateOrganization(context.Context, *UpdateOrganizationRequest) (*UpdateOrganizationResponse, error)
+	CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error)
+	UpdateTopic(context.Context, *UpdateTopicRequest) (*UpdateTopicResponse, error)
+	ListRoleAssignments(context.Context, *ListRoleAssignmentsRequest) (*ListRoleAssignmentsResponse, error)
+	GrantRole(context.Context, *GrantRoleRequest) (*GrantRoleResponse, error)
+	RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error)
+	mustEmbedUnimplementedVotesAdminServiceServer()
+}
+
+// UnimplementedVotesAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVotesAdminServiceServer struct{}
+
+func (UnimplementedVotesAdminServiceServer) CreateVote(context.Context, *CreateVoteRequest) (*CreateVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVote not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) UpdateVote(context.Context, *UpdateVoteRequest) (*UpdateVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVote not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) DeleteVote(context.Context, *DeleteVoteRequest) (*DeleteVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVote not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ListAllVotes(context.Context, *ListAllVotesRequest) (*ListAllVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllVotes not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) SetVoteStatus(context.Context, *SetVoteStatusRequest) (*SetVoteStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVoteStatus not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ReviewComment(context.Context, *ReviewCommentRequest) (*ReviewCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewComment not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ReviewPetition(context.Context, *ReviewPetitionRequest) (*ReviewPetitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewPetition not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) PublishPetitionResponse(context.Context, *PublishPetitionResponseRequest) (*PublishPetitionResponseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishPetitionResponse not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ListOverduePetitions(context.Context, *ListOverduePetitionsRequest) (*ListOverduePetitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverduePetitions not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*CreateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) UpdateOrganization(context.Context, *UpdateOrganizationRequest) (*UpdateOrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrganization not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopic not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) UpdateTopic(context.Context, *UpdateTopicRequest) (*UpdateTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTopic not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) ListRoleAssignments(context.Context, *ListRoleAssignmentsRequest) (*ListRoleAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleAssignments not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) GrantRole(context.Context, *GrantRoleRequest) (*GrantRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedVotesAdminServiceServer) mustEmbedUnimplementedVotesAdminServiceServer() {}
+func (UnimplementedVotesAdminServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeVotesAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VotesAdminServiceServer will
+// result in compilation errors.
+type UnsafeVotesAdminServiceServer interface {
+	mustEmbedUnimplementedVotesAdminServiceServer()
+}
+
+func RegisterVotesAdminServiceServer(s grpc.ServiceRegistrar, srv VotesAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVotesAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VotesAdminService_ServiceDesc, srv)
+}
+
+func _VotesAdminService_CreateVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).CreateVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_CreateVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).CreateVote(ctx, req.(*CreateVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_UpdateVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).UpdateVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_UpdateVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).UpdateVote(ctx, req.(*UpdateVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_DeleteVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).DeleteVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_DeleteVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).DeleteVote(ctx, req.(*DeleteVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ListAllVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllVotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ListAllVotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ListAllVotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ListAllVotes(ctx, req.(*ListAllVotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_SetVoteStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVoteStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).SetVoteStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_SetVoteStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).SetVoteStatus(ctx, req.(*SetVoteStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ReviewComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ReviewComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ReviewComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ReviewComment(ctx, req.(*ReviewCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ReviewPetition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPetitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ReviewPetition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ReviewPetition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ReviewPetition(ctx, req.(*ReviewPetitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_PublishPetitionResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishPetitionResponseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).PublishPetitionResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_PublishPetitionResponse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).PublishPetitionResponse(ctx, req.(*PublishPetitionResponseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ListOverduePetitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverduePetitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ListOverduePetitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ListOverduePetitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ListOverduePetitions(ctx, req.(*ListOverduePetitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_UpdateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).UpdateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_UpdateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).UpdateOrganization(ctx, req.(*UpdateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_CreateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).CreateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_CreateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).CreateTopic(ctx, req.(*CreateTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_UpdateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).UpdateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_UpdateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).UpdateTopic(ctx, req.(*UpdateTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_ListRoleAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).ListRoleAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_ListRoleAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).ListRoleAssignments(ctx, req.(*ListRoleAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_GrantRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).GrantRole(ctx, req.(*GrantRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VotesAdminService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VotesAdminServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VotesAdminService_RevokeRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VotesAdminServiceServer).RevokeRole(ctx, req.(*RevokeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VotesAdminService_ServiceDesc is the grpc.ServiceDesc for VotesAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VotesAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.VotesAdminService",
+	HandlerType: (*VotesAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateVote",
+			Handler:    _VotesAdminService_CreateVote_Handler,
+		},
+		{
+			MethodName: "UpdateVote",
+			Handler:    _VotesAdminService_UpdateVote_Handler,
+		},
+		{
+			MethodName: "DeleteVote",
+			Handler:    _VotesAdminService_DeleteVote_Handler,
+		},
+		{
+			MethodName: "ListAllVotes",
+			Handler:    _VotesAdminService_ListAllVotes_Handler,
+		},
+		{
+			MethodName: "SetVoteStatus",
+			Handler:    _VotesAdminService_SetVoteStatus_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _VotesAdminService_ListComments_Handler,
+		},
+		{
+			MethodName: "ReviewComment",
+			Handler:    _VotesAdminService_ReviewComment_Handler,
+		},
+		{
+			MethodName: "ReviewPetition",
+			Handler:    _VotesAdminService_ReviewPetition_Handler,
+		},
+		{
+			MethodName: "PublishPetitionResponse",
+			Handler:    _VotesAdminService_PublishPetitionResponse_Handler,
+		},
+		{
+			MethodName: "ListOverduePetitions",
+			Handler:    _VotesAdminService_ListOverduePetitions_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _VotesAdminService_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "UpdateOrganization",
+			Handler:    _VotesAdminService_UpdateOrganization_Handler,
+		},
+		{
+			MethodName: "CreateTopic",
+			Handler:    _VotesAdminService_CreateTopic_Handler,
+		},
+		{
+			MethodName: "UpdateTopic",
+			Handler:    _VotesAdminService_UpdateTopic_Handler,
+		},
+		{
+			MethodName: "ListRoleAssignments",
+			Handler:    _VotesAdminService_ListRoleAssignments_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _VotesAdminService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _VotesAdminService_RevokeRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/votes.proto",
+}
@@ -2,9 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/GP-Hacks/kdt2024-commons/prettylogger"
 	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
 	"github.com/GP-Hacks/kdt2024-votes/internal/grpc-server/handler"
+	"github.com/GP-Hacks/kdt2024-votes/internal/lifecycle"
+	"github.com/GP-Hacks/kdt2024-votes/internal/moderation"
+	"github.com/GP-Hacks/kdt2024-votes/internal/pseudonym"
 	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
 	"google.golang.org/grpc"
 	"log/slog"
@@ -17,7 +26,10 @@ func main() {
 	log.Info("Configuration loaded", slog.String("env", cfg.Env))
 	log.Info("Logger initialized")
 
-	grpcServer := grpc.NewServer()
+	authenticator, err := setupAuthenticator(cfg, log)
+	if err != nil {
+		return
+	}
 
 	log.Info("Starting TCP listener", slog.String("address", cfg.Address))
 	l, err := net.Listen("tcp", cfg.Address)
@@ -32,27 +44,146 @@ func main() {
 	}()
 	log.Info("TCP listener started successfully", slog.String("address", cfg.Address))
 
-	storage, err := setupPostgreSQL(cfg, log)
+	voters, err := setupVoterKeys(cfg, log)
+	if err != nil {
+		return
+	}
 
-	handler.NewGRPCHandler(cfg, grpcServer, storage, log)
+	storage, err := setupStorage(cfg, voters, log)
+	if err != nil {
+		return
+	}
+
+	if len(cfg.Admins) == 0 {
+		log.Warn("ADMIN_USERS is not set, only users with an admin role assignment can administer votes")
+	}
+	policy := handler.NewPolicy(storage, cfg.Admins)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		handler.RequestIDInterceptor(),
+		handler.AuthInterceptor(authenticator, log),
+		handler.PolicyInterceptor(policy, log),
+	))
+
+	filter, err := setupFilter(cfg, log)
+	if err != nil {
+		return
+	}
+
+	go lifecycle.NewJob(storage, cfg.StatusInterval, log).Run(context.Background())
+
+	handler.NewGRPCHandler(cfg, grpcServer, storage, filter, voters, clock.System, log)
+	handler.NewAdminHandler(cfg, grpcServer, storage, clock.System, log)
 	if err := grpcServer.Serve(l); err != nil {
 		log.Error("Error serving gRPC server for VotesService", slog.String("address", cfg.Address), slog.String("error", err.Error()))
 	}
 }
 
-func setupPostgreSQL(cfg *config.Config, log *slog.Logger) (*storage.PostgresStorage, error) {
-	storage, err := storage.NewPostgresStorage(cfg.PostgresAddress + "?sslmode=disable")
+func setupStorage(cfg *config.Config, voters *pseudonym.Keys, log *slog.Logger) (storage.Repository, error) {
+	if cfg.Storage == "memory" {
+		return setupMemory(cfg, log)
+	}
+	return setupPostgreSQL(cfg, voters, log)
+}
+
+// setupVoterKeys loads the peppers of voter pseudonyms. In-memory storage
+// keeps no ballots across restarts, so it gets a random pepper when none is
+// configured.
+func setupVoterKeys(cfg *config.Config, log *slog.Logger) (*pseudonym.Keys, error) {
+	peppers := cfg.BallotPeppers()
+	if cfg.BallotPepper == "" {
+		if cfg.Storage != "memory" {
+			err := errors.New("BALLOT_PEPPER is required")
+			log.Error("Failed to set up voter pseudonyms", slog.String("error", err.Error()))
+			return nil, err
+		}
+		pepper := make([]byte, 32)
+		if _, err := rand.Read(pepper); err != nil {
+			log.Error("Failed to generate ballot pepper", slog.String("error", err.Error()))
+			return nil, err
+		}
+		log.Warn("BALLOT_PEPPER is not set, using a random pepper")
+		peppers = append(peppers, hex.EncodeToString(pepper))
+	}
+
+	voters, err := pseudonym.New(peppers...)
+	if err != nil {
+		log.Error("Failed to set up voter pseudonyms", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return voters, nil
+}
+
+func setupAuthenticator(cfg *config.Config, log *slog.Logger) (auth.Authenticator, error) {
+	switch cfg.Auth {
+	case "stub":
+		log.Warn("Using stub authenticator, user tokens are not verified")
+		return auth.NewStub(nil), nil
+	case "jwt":
+	default:
+		err := fmt.Errorf("unknown authenticator %q", cfg.Auth)
+		log.Error("Failed to set up authentication", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if cfg.JWKSFile == "" {
+		err := errors.New("AUTH_JWKS_FILE is required for JWT authentication")
+		log.Error("Failed to set up authentication", slog.String("error", err.Error()))
+		return nil, err
+	}
+	authenticator, err := auth.NewJWT(cfg.JWKSFile, auth.WithIssuer(cfg.JWTIssuer), auth.WithAudience(cfg.JWTAudience))
+	if err != nil {
+		log.Error("Failed to load JWKS", slog.String("error", err.Error()), slog.String("path", cfg.JWKSFile))
+		return nil, err
+	}
+	log.Info("JWT authentication enabled", slog.String("jwks", cfg.JWKSFile))
+	return authenticator, nil
+}
+
+func setupFilter(cfg *config.Config, log *slog.Logger) (moderation.Filter, error) {
+	if cfg.ProfanityWordList == "" {
+		log.Info("Using built-in Russian profanity word list")
+		return moderation.Russian(), nil
+	}
+	filter, err := moderation.LoadWordList(cfg.ProfanityWordList)
+	if err != nil {
+		log.Error("Failed to load profanity word list", slog.String("error", err.Error()), slog.String("path", cfg.ProfanityWordList))
+		return nil, err
+	}
+	log.Info("Profanity word list loaded", slog.String("path", cfg.ProfanityWordList))
+	return filter, nil
+}
+
+func setupMemory(cfg *config.Config, log *slog.Logger) (*storage.MemoryStorage, error) {
+	storage := storage.NewMemoryStorage(storage.WithResponseWindow(cfg.PetitionResponseWindow), storage.WithLogger(log))
+	log.Warn("Using in-memory storage, data will be lost on restart")
+
+	if err := storage.FetchAndStoreData(context.Background()); err != nil {
+		log.Error("Failed to fetch and store initial data", slog.String("error", err.Error()))
+		return nil, err
+	}
+	log.Info("Initial data fetched and stored successfully")
+	return storage, nil
+}
+
+func setupPostgreSQL(cfg *config.Config, voters *pseudonym.Keys, log *slog.Logger) (*storage.PostgresStorage, error) {
+	storage, err := storage.NewPostgresStorage(cfg.PostgresAddress+"?sslmode=disable",
+		storage.WithResponseWindow(cfg.PetitionResponseWindow), storage.WithLogger(log))
 	if err != nil {
 		log.Error("Failed to connect to PostgreSQL", slog.String("error", err.Error()), slog.String("postgres_address", cfg.PostgresAddress))
 		return nil, err
 	}
 	log.Info("PostgreSQL connected", slog.String("postgres_address", cfg.PostgresAddress))
 
-	if err := storage.CreateTables(context.Background()); err != nil {
-		log.Error("Error creating tables", slog.String("error", err.Error()))
+	if err := storage.Migrate(context.Background()); err != nil {
+		log.Error("Error applying migrations", slog.String("error", err.Error()))
+		return nil, err
+	}
+	log.Info("Database migrations applied")
+
+	if err := storage.CheckVoterKeys(context.Background(), voters.Fingerprints()); err != nil {
+		log.Error("Ballots are not keyed with the configured peppers", slog.String("error", err.Error()))
 		return nil, err
 	}
-	log.Info("Tables created or already exist")
 
 	log.Info("Fetching and storing initial data")
 	if err := storage.FetchAndStoreData(context.Background()); err != nil {
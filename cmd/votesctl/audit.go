@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// auditPage is how many audit entries are read at a time.
+const auditPage = 1000
+
+// runAudit verifies the hash chains of the audit log. With -heads it also
+// checks that the log still reaches the stream heads recorded in the file
+// by the previous run, and records the current ones. Keeping that file
+// outside of the database reveals chains that were cut short, removed or
+// rewritten as a whole.
+func runAudit(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	headsFile := fs.String("heads", "", "file with the stream heads of the previous verification, updated on success")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: votesctl audit verify [-heads file]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 || fs.Arg(0) != "verify" {
+		fs.Usage()
+		return fmt.Errorf("audit: unknown action %q", fs.Arg(0))
+	}
+	fs.Parse(fs.Args()[1:])
+
+	verifier := audit.NewVerifier()
+	if *headsFile != "" {
+		heads, err := readHeads(*headsFile)
+		if err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+		for _, head := range heads {
+			verifier.Anchor(head)
+		}
+	}
+
+	s, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	defer s.Close()
+
+	ctx := context.Background()
+	var afterStream string
+	var afterSeq int64
+	for {
+		entries, err := s.AuditLog(ctx, afterStream, afterSeq, auditPage)
+		if err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+		for i := range entries {
+			if err := verifier.Check(&entries[i]); err != nil {
+				return fmt.Errorf("audit: %w (%d entries verified before)", err, verifier.Checked())
+			}
+			afterStream, afterSeq = entries[i].Stream, entries[i].Seq
+		}
+		if len(entries) < auditPage {
+			break
+		}
+	}
+	if err := verifier.Finish(); err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+
+	heads := verifier.Heads()
+	fmt.Printf("verified %d entries in %d streams\n", verifier.Checked(), len(heads))
+	if *headsFile != "" {
+		if err := writeHeads(*headsFile, heads); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+	}
+	return nil
+}
+
+// readHeads reads a heads file of lines "stream seq hash". A missing file
+// has no heads.
+func readHeads(path string) ([]audit.Head, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var heads []audit.Head
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: want stream, seq and hash", path, line)
+		}
+		seq, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		heads = append(heads, audit.Head{Stream: fields[0], Seq: seq, Hash: fields[2]})
+	}
+	return heads, scanner.Err()
+}
+
+func writeHeads(path string, heads []audit.Head) error {
+	var b strings.Builder
+	for _, head := range heads {
+		fmt.Fprintf(&b, "%s %d %s\n", head.Stream, head.Seq, head.Hash)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"os"
+	"time"
+)
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "", "path to the JSON, YAML or CSV file to import")
+	format := fs.String("format", "", "file format (json, yaml or csv); detected from the extension by default")
+	dryRun := fs.Bool("dry-run", false, "validate and write the rows, then roll the transaction back")
+	upsert := fs.Bool("upsert", false, "update votes whose external_key already exists instead of rejecting them")
+	fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("import: -file is required")
+	}
+
+	importFormat := storage.ImportFormat(*format)
+	if importFormat == "" {
+		var err error
+		importFormat, err = storage.FormatFromPath(*file)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	defer f.Close()
+
+	records, err := storage.DecodeVotes(f, importFormat, time.Now())
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	s, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	defer s.Close()
+
+	report, err := s.ImportVotes(context.Background(), records, storage.ImportOptions{DryRun: *dryRun, Upsert: *upsert})
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	for _, rowErr := range report.Errors {
+		fmt.Fprintln(os.Stderr, rowErr.Error())
+	}
+	fmt.Printf("rows: %d, created: %d, updated: %d, failed: %d\n", len(records), report.Created, report.Updated, len(report.Errors))
+
+	switch {
+	case len(report.Errors) > 0:
+		return fmt.Errorf("import: %d row(s) failed, nothing was written", len(report.Errors))
+	case !report.Committed:
+		fmt.Println("dry run: transaction rolled back")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"os"
+)
+
+const usage = `Usage: votesctl <command> [flags]
+
+Commands:
+  audit     verify the hash chain of the audit log
+  import    load votes from a JSON, YAML or CSV file
+  migrate   apply, revert or list schema migrations
+  rekey     re-key stored ballots after a ballot pepper rotation
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "audit":
+		err = runAudit(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	case "migrate":
+		err = runMigrate(os.Args[2:])
+	case "rekey":
+		err = runRekey(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "votesctl:", err)
+		os.Exit(1)
+	}
+}
+
+func openStorage() (*storage.PostgresStorage, error) {
+	cfg := config.MustLoad()
+	if cfg.PostgresAddress == "" {
+		return nil, fmt.Errorf("POSTGRES_ADDRESS is not set")
+	}
+	return storage.NewPostgresStorage(cfg.PostgresAddress + "?sslmode=disable")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+)
+
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	steps := fs.Int("steps", 1, "number of migrations to revert with \"down\"")
+	version := fs.Int("version", -1, "target version for \"to\"")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: votesctl migrate [flags] up|down|to|status")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	action := "up"
+	if fs.NArg() > 0 {
+		action = fs.Arg(0)
+	}
+
+	s, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	defer s.Close()
+
+	ctx := context.Background()
+	switch action {
+	case "up":
+		err = s.Migrate(ctx)
+	case "down":
+		err = s.MigrateDown(ctx, *steps)
+	case "to":
+		if *version < 0 {
+			return fmt.Errorf("migrate: -version is required for \"to\"")
+		}
+		err = s.MigrateTo(ctx, *version)
+	case "status":
+	default:
+		fs.Usage()
+		return fmt.Errorf("migrate: unknown action %q", action)
+	}
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	statuses, err := s.MigrationStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	for _, st := range statuses {
+		applied := "pending"
+		if st.Applied {
+			applied = "applied " + st.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d %-40s %s\n", st.Version, st.Name, applied)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/pseudonym"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"time"
+)
+
+// runRekey applies the peppers configured with BALLOT_PEPPER_HISTORY and
+// BALLOT_PEPPER that the stored voter pseudonyms are not keyed with yet. To
+// rotate the pepper, append the current one to BALLOT_PEPPER_HISTORY, set
+// BALLOT_PEPPER to the new one and run "votesctl rekey apply" while the
+// service is stopped.
+//
+// The first pepper also resolves the raw tokens stored before the service
+// authenticated users, with the authenticator configured with AUTH. Their
+// ballots are dropped when a token does not resolve, and all but one are
+// dropped when several tokens of a user voted in the same vote.
+func runRekey(args []string) error {
+	fs := flag.NewFlagSet("rekey", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: votesctl rekey apply|status")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	action := "status"
+	if fs.NArg() > 0 {
+		action = fs.Arg(0)
+	}
+	if action != "apply" && action != "status" {
+		fs.Usage()
+		return fmt.Errorf("rekey: unknown action %q", action)
+	}
+
+	cfg := config.MustLoad()
+	peppers := cfg.BallotPeppers()
+	keys, err := pseudonym.New(peppers...)
+	if err != nil {
+		return fmt.Errorf("rekey: %w", err)
+	}
+	fingerprints := keys.Fingerprints()
+
+	s, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("rekey: %w", err)
+	}
+	defer s.Close()
+
+	ctx := context.Background()
+	layers, err := s.KeyLayers(ctx)
+	if err != nil {
+		return fmt.Errorf("rekey: %w", err)
+	}
+	if len(layers) > len(fingerprints) {
+		return fmt.Errorf("rekey: ballots are keyed with %d peppers but only %d are configured", len(layers), len(fingerprints))
+	}
+	for i, layer := range layers {
+		if layer.Fingerprint != fingerprints[i] {
+			return fmt.Errorf("rekey: pepper %d is %s in the database but %s is configured", layer.Position, layer.Fingerprint, fingerprints[i])
+		}
+	}
+
+	if action == "apply" {
+		var legacy storage.LegacyResolver
+		if len(layers) == 0 {
+			if legacy, err = legacyResolver(cfg); err != nil {
+				return fmt.Errorf("rekey: %w", err)
+			}
+		}
+		for i := len(layers); i < len(peppers); i++ {
+			report, err := s.ApplyVoterKey(ctx, i+1, peppers[i], legacy)
+			if err != nil {
+				return fmt.Errorf("rekey: %w", err)
+			}
+			if report.Resolved > 0 || report.Unresolved > 0 {
+				fmt.Printf("resolved %d stored tokens, %d did not resolve, dropped rows: %d\n",
+					report.Resolved, report.Unresolved, report.Dropped)
+			}
+			fmt.Printf("applied pepper %d (%s), rows: %d\n", i+1, fingerprints[i], report.Rows)
+		}
+		if layers, err = s.KeyLayers(ctx); err != nil {
+			return fmt.Errorf("rekey: %w", err)
+		}
+	}
+
+	for i, fingerprint := range fingerprints {
+		applied := "pending"
+		if i < len(layers) {
+			applied = "applied " + layers[i].AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%2d %s %s\n", i+1, fingerprint, applied)
+	}
+	return nil
+}
+
+// legacyResolver resolves stored tokens with the configured authenticator.
+func legacyResolver(cfg *config.Config) (storage.LegacyResolver, error) {
+	var resolver auth.Resolver
+	switch cfg.Auth {
+	case "stub":
+		resolver = auth.NewStub(nil)
+	case "jwt":
+		if cfg.JWKSFile == "" {
+			return nil, errors.New("AUTH_JWKS_FILE is required to resolve stored tokens")
+		}
+		authenticator, err := auth.NewJWT(cfg.JWKSFile, auth.WithIssuer(cfg.JWTIssuer), auth.WithAudience(cfg.JWTAudience))
+		if err != nil {
+			return nil, err
+		}
+		resolver = authenticator
+	default:
+		return nil, fmt.Errorf("unknown authenticator %q", cfg.Auth)
+	}
+	return func(token string) (string, bool) {
+		userId, err := resolver.Resolve(token)
+		return userId, err == nil
+	}, nil
+}
@@ -1,17 +1,99 @@
 package config
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Config struct {
-	Env             string
-	Address         string
-	PostgresAddress string
+	Env                    string
+	Address                string
+	PostgresAddress        string
+	Storage                string
+	StatusInterval         time.Duration
+	ProfanityWordList      string
+	PetitionSignatureGoal  int
+	PetitionResponseWindow time.Duration
+	Auth                   string
+	JWKSFile               string
+	JWTIssuer              string
+	JWTAudience            string
+	BallotPepper           string
+	BallotPepperHistory    []string
+	Admins                 []string
 }
 
 func MustLoad() *Config {
 	return &Config{
-		Env:             "local",
-		Address:         os.Getenv("SERVICE_ADDRESS"),
-		PostgresAddress: os.Getenv("POSTGRES_ADDRESS"),
+		Env:                    "local",
+		Address:                os.Getenv("SERVICE_ADDRESS"),
+		PostgresAddress:        os.Getenv("POSTGRES_ADDRESS"),
+		Storage:                getEnv("STORAGE", "postgres"),
+		StatusInterval:         getDuration("STATUS_INTERVAL", time.Minute),
+		ProfanityWordList:      os.Getenv("PROFANITY_WORDLIST"),
+		PetitionSignatureGoal:  getInt("PETITION_SIGNATURE_GOAL", 100),
+		PetitionResponseWindow: getDuration("PETITION_RESPONSE_WINDOW", 30*24*time.Hour),
+		Auth:                   getEnv("AUTH", "jwt"),
+		JWKSFile:               os.Getenv("AUTH_JWKS_FILE"),
+		JWTIssuer:              os.Getenv("AUTH_JWT_ISSUER"),
+		JWTAudience:            os.Getenv("AUTH_JWT_AUDIENCE"),
+		BallotPepper:           os.Getenv("BALLOT_PEPPER"),
+		BallotPepperHistory:    getList("BALLOT_PEPPER_HISTORY"),
+		Admins:                 getList("ADMIN_USERS"),
 	}
 }
+
+// BallotPeppers returns the peppers voter pseudonyms are keyed with, oldest
+// first: the retired ones from BALLOT_PEPPER_HISTORY, then BALLOT_PEPPER.
+func (c *Config) BallotPeppers() []string {
+	peppers := append([]string{}, c.BallotPepperHistory...)
+	if c.BallotPepper != "" {
+		peppers = append(peppers, c.BallotPepper)
+	}
+	return peppers
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		panic(fmt.Sprintf("config: %s must be a positive duration, got %q", key, value))
+	}
+	return d
+}
+
+func getInt(key string, fallback int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		panic(fmt.Sprintf("config: %s must be a non-negative integer, got %q", key, value))
+	}
+	return n
+}
+
+// getList splits a comma-separated variable, dropping empty items.
+func getList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
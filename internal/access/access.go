@@ -0,0 +1,213 @@
+// Package access decides which staff roles may call which RPCs.
+//
+// Every RPC has a Rule. Public rules admit anyone, including anonymous
+// callers; the others admit authenticated users holding one of the listed
+// roles. Admins are admitted everywhere. Editors are admitted where a rule
+// has a Scope, provided they edit every organization the request touches
+// and the rule does not reserve the request for admins.
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"sort"
+	"strings"
+)
+
+// ErrUnauthenticated is returned when a staff RPC is called without a
+// token.
+var ErrUnauthenticated = errors.New("authentication required")
+
+// RoleStore looks up the roles assigned to a user.
+type RoleStore interface {
+	GetRoleAssignments(ctx context.Context, userId string) ([]storage.RoleAssignment, error)
+}
+
+// Scope returns the organizations a request touches. An empty result
+// admits every editor; the handler then limits what they see to their
+// organizations. A vote without an organization is reported as 0, which no
+// editor edits.
+type Scope func(ctx context.Context, req interface{}) ([]int, error)
+
+// Rule admits callers of one RPC. AdminOnly, when set, reports whether a
+// request changes something only admins may change, such as the
+// verification of an organization, and so admits nobody but admins.
+type Rule struct {
+	Public    bool
+	Roles     []string
+	Scope     Scope
+	AdminOnly func(ctx context.Context, req interface{}) (bool, error)
+}
+
+// Policy maps full gRPC method names to rules. Methods without a rule are
+// denied to everyone but admins.
+type Policy struct {
+	rules  map[string]Rule
+	roles  RoleStore
+	admins map[string]bool
+}
+
+// NewPolicy returns a policy over rules. The users in admins are admins in
+// addition to those with an admin role assignment, so that the first
+// assignments can be granted.
+func NewPolicy(rules map[string]Rule, roles RoleStore, admins []string) *Policy {
+	p := &Policy{rules: rules, roles: roles, admins: make(map[string]bool, len(admins))}
+	for _, userId := range admins {
+		p.admins[userId] = true
+	}
+	return p
+}
+
+// Authorize decides whether the user in ctx may call method with req. On
+// success it returns the caller; public methods are admitted without
+// looking the caller up and return nil. A refusal is a *DeniedError.
+func (p *Policy) Authorize(ctx context.Context, method string, req interface{}) (*Subject, error) {
+	rule := p.rules[method]
+	if rule.Public {
+		return nil, nil
+	}
+	userId := auth.UserID(ctx)
+	if userId == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	subject, err := p.subject(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if subject.Admin() {
+		return subject, nil
+	}
+	if rule.AdminOnly != nil {
+		adminOnly, err := rule.AdminOnly(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if adminOnly {
+			return nil, &DeniedError{Method: method, UserID: userId, Roles: []string{storage.RoleAdmin}}
+		}
+	}
+	for _, role := range rule.Roles {
+		if subject.Has(role) {
+			return subject, nil
+		}
+	}
+
+	denied := &DeniedError{Method: method, UserID: userId, Roles: append([]string{storage.RoleAdmin}, rule.Roles...)}
+	if rule.Scope == nil {
+		return nil, denied
+	}
+	denied.Roles = append(denied.Roles, storage.RoleEditor)
+	if !subject.Has(storage.RoleEditor) {
+		return nil, denied
+	}
+	organizations, err := rule.Scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, organizationId := range organizations {
+		if !subject.Edits(organizationId) {
+			denied.OrganizationID = organizationId
+			return nil, denied
+		}
+	}
+	return subject, nil
+}
+
+func (p *Policy) subject(ctx context.Context, userId string) (*Subject, error) {
+	assignments, err := p.roles.GetRoleAssignments(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("access: roles of %q: %w", userId, err)
+	}
+	if p.admins[userId] {
+		assignments = append(assignments, storage.RoleAssignment{UserID: userId, Role: storage.RoleAdmin})
+	}
+	return NewSubject(userId, assignments), nil
+}
+
+// DeniedError explains a refusal: the caller holds none of Roles, or is an
+// editor but not of OrganizationID, which the request touches.
+type DeniedError struct {
+	Method         string
+	UserID         string
+	Roles          []string
+	OrganizationID int
+}
+
+func (e *DeniedError) Error() string {
+	msg := fmt.Sprintf("%s requires one of the roles %s", e.Method, strings.Join(e.Roles, ", "))
+	if e.OrganizationID != 0 {
+		msg += fmt.Sprintf("; the caller does not edit organization %d", e.OrganizationID)
+	}
+	return msg
+}
+
+// Subject is an authenticated caller with their roles.
+type Subject struct {
+	UserID        string
+	roles         map[string]bool
+	organizations map[int]bool
+}
+
+// NewSubject returns the caller with userId holding assignments.
+func NewSubject(userId string, assignments []storage.RoleAssignment) *Subject {
+	s := &Subject{UserID: userId, roles: make(map[string]bool), organizations: make(map[int]bool)}
+	for _, assignment := range assignments {
+		s.roles[assignment.Role] = true
+		if assignment.Role == storage.RoleEditor {
+			s.organizations[assignment.OrganizationID] = true
+		}
+	}
+	return s
+}
+
+// Admin reports whether the caller is an admin.
+func (s *Subject) Admin() bool {
+	return s != nil && s.roles[storage.RoleAdmin]
+}
+
+// Has reports whether the caller holds role.
+func (s *Subject) Has(role string) bool {
+	return s != nil && s.roles[role]
+}
+
+// Edits reports whether the caller may manage the votes of an
+// organization.
+func (s *Subject) Edits(organizationId int) bool {
+	return s.Admin() || (s != nil && organizationId != 0 && s.organizations[organizationId])
+}
+
+// Restricted reports whether the caller sees only the votes of the
+// organizations they edit: editors without a global staff role.
+func (s *Subject) Restricted() bool {
+	return !s.Admin() && !s.Has(storage.RoleModerator) && !s.Has(storage.RoleAnalyst)
+}
+
+// Organizations returns the organizations the caller edits, in order.
+func (s *Subject) Organizations() []int {
+	if s == nil {
+		return nil
+	}
+	organizations := make([]int, 0, len(s.organizations))
+	for organizationId := range s.organizations {
+		organizations = append(organizations, organizationId)
+	}
+	sort.Ints(organizations)
+	return organizations
+}
+
+type subjectKey struct{}
+
+// WithSubject returns a copy of ctx carrying the authorized caller.
+func WithSubject(ctx context.Context, subject *Subject) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// FromContext returns the authorized caller, or nil for a public method.
+func FromContext(ctx context.Context) *Subject {
+	subject, _ := ctx.Value(subjectKey{}).(*Subject)
+	return subject
+}
@@ -0,0 +1,190 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"testing"
+)
+
+// roleStore is a RoleStore over a fixed table of assignments.
+type roleStore map[string][]storage.RoleAssignment
+
+func (s roleStore) GetRoleAssignments(ctx context.Context, userId string) ([]storage.RoleAssignment, error) {
+	return s[userId], nil
+}
+
+// scopeOf touches the organizations in the request.
+func scopeOf(ctx context.Context, req interface{}) ([]int, error) {
+	return req.([]int), nil
+}
+
+func TestAuthorize(t *testing.T) {
+	rules := map[string]Rule{
+		"/public":    {Public: true},
+		"/moderated": {Roles: []string{storage.RoleModerator}},
+		"/scoped":    {Scope: scopeOf},
+		"/staff":     {Roles: []string{storage.RoleModerator, storage.RoleAnalyst}, Scope: scopeOf},
+	}
+	roles := roleStore{
+		"admin":     {{UserID: "admin", Role: storage.RoleAdmin}},
+		"moderator": {{UserID: "moderator", Role: storage.RoleModerator}},
+		"editor": {
+			{UserID: "editor", Role: storage.RoleEditor, OrganizationID: 1},
+			{UserID: "editor", Role: storage.RoleEditor, OrganizationID: 2},
+		},
+	}
+	policy := NewPolicy(rules, roles, []string{"configured"})
+
+	tests := []struct {
+		name    string
+		user    string
+		method  string
+		req     interface{}
+		allowed bool
+		err     error
+		// denied is the organization reported by a refusal, -1 for none.
+		denied int
+	}{
+		{"public anonymous", "", "/public", nil, true, nil, 0},
+		{"public user", "citizen", "/public", nil, true, nil, 0},
+		{"anonymous", "", "/moderated", nil, false, ErrUnauthenticated, 0},
+		{"anonymous on an unknown method", "", "/unknown", nil, false, ErrUnauthenticated, 0},
+		{"unknown method for a user", "moderator", "/unknown", nil, false, nil, -1},
+		{"unknown method for an admin", "admin", "/unknown", nil, true, nil, 0},
+		{"unknown method for a configured admin", "configured", "/unknown", nil, true, nil, 0},
+		{"role match", "moderator", "/moderated", nil, true, nil, 0},
+		{"role missing", "citizen", "/moderated", nil, false, nil, -1},
+		{"editor without a scope", "editor", "/moderated", nil, false, nil, -1},
+		{"editor of the organization", "editor", "/scoped", []int{1}, true, nil, 0},
+		{"editor of every organization", "editor", "/scoped", []int{1, 2}, true, nil, 0},
+		{"editor of another organization", "editor", "/scoped", []int{1, 3}, false, nil, 3},
+		{"editor on a vote without organization", "editor", "/scoped", []int{0}, false, nil, 0},
+		{"editor on any organization", "editor", "/staff", []int{}, true, nil, 0},
+		{"role match with a scope", "moderator", "/staff", []int{3}, true, nil, 0},
+		{"citizen with a scope", "citizen", "/scoped", []int{1}, false, nil, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.user != "" {
+				ctx = auth.WithUser(ctx, tt.user)
+			}
+			subject, err := policy.Authorize(ctx, tt.method, tt.req)
+			if tt.allowed {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tt.method == "/public" {
+					if subject != nil {
+						t.Errorf("got subject %q for a public method, want none", subject.UserID)
+					}
+				} else if subject == nil || subject.UserID != tt.user {
+					t.Errorf("got subject %v, want %q", subject, tt.user)
+				}
+				return
+			}
+			if subject != nil {
+				t.Errorf("got subject %q, want a refusal", subject.UserID)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("got error %v, want %v", err, tt.err)
+				}
+				return
+			}
+			var denied *DeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("got error %v, want a *DeniedError", err)
+			}
+			if denied.Method != tt.method || denied.UserID != tt.user {
+				t.Errorf("got refusal of %s for %q, want %s for %q", denied.Method, denied.UserID, tt.method, tt.user)
+			}
+			if tt.denied >= 0 && denied.OrganizationID != tt.denied {
+				t.Errorf("got refused organization %d, want %d", denied.OrganizationID, tt.denied)
+			}
+		})
+	}
+}
+
+func TestAuthorizeRoles(t *testing.T) {
+	policy := NewPolicy(map[string]Rule{
+		"/moderated": {Roles: []string{storage.RoleModerator}},
+		"/scoped":    {Roles: []string{storage.RoleAnalyst}, Scope: scopeOf},
+	}, roleStore{}, nil)
+	tests := []struct {
+		method string
+		roles  []string
+	}{
+		{"/moderated", []string{storage.RoleAdmin, storage.RoleModerator}},
+		{"/scoped", []string{storage.RoleAdmin, storage.RoleAnalyst, storage.RoleEditor}},
+		{"/unknown", []string{storage.RoleAdmin}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			_, err := policy.Authorize(auth.WithUser(context.Background(), "citizen"), tt.method, []int{1})
+			var denied *DeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("got error %v, want a *DeniedError", err)
+			}
+			if len(denied.Roles) != len(tt.roles) {
+				t.Fatalf("got roles %q, want %q", denied.Roles, tt.roles)
+			}
+			for i, role := range denied.Roles {
+				if role != tt.roles[i] {
+					t.Errorf("got roles %q, want %q", denied.Roles, tt.roles)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizeScopeError(t *testing.T) {
+	failed := errors.New("vote lookup failed")
+	policy := NewPolicy(map[string]Rule{
+		"/scoped": {Scope: func(ctx context.Context, req interface{}) ([]int, error) { return nil, failed }},
+	}, roleStore{"editor": {{UserID: "editor", Role: storage.RoleEditor, OrganizationID: 1}}}, nil)
+
+	_, err := policy.Authorize(auth.WithUser(context.Background(), "editor"), "/scoped", nil)
+	if !errors.Is(err, failed) {
+		t.Errorf("got error %v, want %v", err, failed)
+	}
+}
+
+func TestAuthorizeAdminOnly(t *testing.T) {
+	policy := NewPolicy(map[string]Rule{
+		"/scoped": {
+			Scope: scopeOf,
+			AdminOnly: func(ctx context.Context, req interface{}) (bool, error) {
+				return len(req.([]int)) > 1, nil
+			},
+		},
+	}, roleStore{
+		"admin":  {{UserID: "admin", Role: storage.RoleAdmin}},
+		"editor": {{UserID: "editor", Role: storage.RoleEditor, OrganizationID: 1}, {UserID: "editor", Role: storage.RoleEditor, OrganizationID: 2}},
+	}, nil)
+
+	if _, err := policy.Authorize(auth.WithUser(context.Background(), "editor"), "/scoped", []int{1}); err != nil {
+		t.Errorf("got error %v for an editor's plain request", err)
+	}
+	_, err := policy.Authorize(auth.WithUser(context.Background(), "editor"), "/scoped", []int{1, 2})
+	var denied *DeniedError
+	if !errors.As(err, &denied) {
+		t.Fatalf("got error %v for an editor's admin-only request, want a *DeniedError", err)
+	}
+	if len(denied.Roles) != 1 || denied.Roles[0] != storage.RoleAdmin || denied.OrganizationID != 0 {
+		t.Errorf("got refusal for roles %q and organization %d, want admin only", denied.Roles, denied.OrganizationID)
+	}
+	if _, err := policy.Authorize(auth.WithUser(context.Background(), "admin"), "/scoped", []int{1, 2}); err != nil {
+		t.Errorf("got error %v for an admin", err)
+	}
+}
+
+func TestDeniedErrorHidesUser(t *testing.T) {
+	denied := &DeniedError{Method: "/scoped", UserID: "user-1", Roles: []string{storage.RoleAdmin, storage.RoleEditor}, OrganizationID: 3}
+	want := "/scoped requires one of the roles admin, editor; the caller does not edit organization 3"
+	if got := denied.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
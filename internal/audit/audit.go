@@ -0,0 +1,185 @@
+// Package audit defines the entries of the append-only audit log and the
+// SHA-256 hash chains that make tampering with it detectable.
+//
+// The log is split into streams, each a chain of its own, so that changes
+// to different votes are appended independently. Every entry stores the
+// hash of its predecessor in its stream and a hash over its own fields
+// including that link, so changing, removing or reordering entries breaks
+// the chain from that point on. A Verifier walks the chains and reports the
+// first entry that does not fit. Removing the tail of a chain, or a whole
+// stream, leaves no gap; the heads of the chains, recorded outside of the
+// database and anchored in the next verification, reveal it.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// GenesisHash is the predecessor hash of the first entry.
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// ErrBrokenChain is returned by a Verifier for an entry that was altered,
+// removed or inserted.
+var ErrBrokenChain = errors.New("audit chain is broken")
+
+// Entry records one change. Actor is the user ID of a staff member, the
+// pseudonym of a voter as it was at the time or a "system:" actor for
+// changes the service makes on its own; re-keying ballots after a pepper
+// rotation leaves the log alone. OldValue and NewValue are JSON documents,
+// empty when the entity did not exist before or after the change. Seq
+// numbers the entries of Stream from 1 without gaps.
+type Entry struct {
+	Stream    string
+	Seq       int64
+	Time      time.Time
+	Actor     string
+	RequestID string
+	Action    string
+	Entity    string
+	EntityID  string
+	OldValue  string
+	NewValue  string
+	PrevHash  string
+	Hash      string
+}
+
+// Seal links the entry to its predecessor in its stream and computes its
+// hash.
+func (e *Entry) Seal(seq int64, prevHash string) {
+	e.Seq = seq
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash returns the hash of the entry's fields and predecessor hash.
+// Each field is length-prefixed so that no two entries encode alike. The
+// time is hashed in UTC with microsecond precision, which is what the
+// database keeps.
+func (e *Entry) ComputeHash() string {
+	h := sha256.New()
+	for _, field := range []string{
+		e.Stream,
+		strconv.FormatInt(e.Seq, 10),
+		e.Time.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		e.Actor, e.RequestID, e.Action, e.Entity, e.EntityID,
+		e.OldValue, e.NewValue, e.PrevHash,
+	} {
+		fmt.Fprintf(h, "%d:%s", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verifier checks entries one at a time, in order of Seq within each
+// stream. Entries of different streams may come in any order.
+type Verifier struct {
+	heads   map[string]Head
+	anchors map[string]Head
+	checked int64
+}
+
+// Head is the last entry of a stream.
+type Head struct {
+	Stream string
+	Seq    int64
+	Hash   string
+}
+
+// NewVerifier returns a Verifier expecting the first entry of every stream.
+func NewVerifier() *Verifier {
+	return &Verifier{heads: make(map[string]Head), anchors: make(map[string]Head)}
+}
+
+// Anchor requires the log to contain head, typically recorded by an
+// earlier verification outside of the database. Check refuses an entry
+// that replaces it and Finish reports it when it was removed.
+func (v *Verifier) Anchor(head Head) {
+	v.anchors[head.Stream] = head
+}
+
+// Check verifies that entry follows the entries of its stream checked
+// before.
+func (v *Verifier) Check(entry *Entry) error {
+	last, ok := v.heads[entry.Stream]
+	if !ok {
+		last.Hash = GenesisHash
+	}
+	anchor, anchored := v.anchors[entry.Stream]
+	switch {
+	case entry.Seq != last.Seq+1:
+		return fmt.Errorf("%w: %s entry %d follows entry %d", ErrBrokenChain, entry.Stream, entry.Seq, last.Seq)
+	case entry.PrevHash != last.Hash:
+		return fmt.Errorf("%w: %s entry %d does not link to entry %d", ErrBrokenChain, entry.Stream, entry.Seq, last.Seq)
+	case entry.ComputeHash() != entry.Hash:
+		return fmt.Errorf("%w: %s entry %d does not match its hash", ErrBrokenChain, entry.Stream, entry.Seq)
+	case anchored && entry.Seq == anchor.Seq && entry.Hash != anchor.Hash:
+		return fmt.Errorf("%w: %s entry %d does not match the recorded head", ErrBrokenChain, entry.Stream, entry.Seq)
+	}
+	v.heads[entry.Stream] = Head{Stream: entry.Stream, Seq: entry.Seq, Hash: entry.Hash}
+	v.checked++
+	return nil
+}
+
+// Finish verifies that the log reached every anchor.
+func (v *Verifier) Finish() error {
+	for _, anchor := range sortedHeads(v.anchors) {
+		if v.heads[anchor.Stream].Seq < anchor.Seq {
+			return fmt.Errorf("%w: %s ends at entry %d, entry %d was recorded",
+				ErrBrokenChain, anchor.Stream, v.heads[anchor.Stream].Seq, anchor.Seq)
+		}
+	}
+	return nil
+}
+
+// Checked returns how many entries passed.
+func (v *Verifier) Checked() int64 {
+	return v.checked
+}
+
+// Heads returns the last entry checked of every stream, by stream name.
+func (v *Verifier) Heads() []Head {
+	return sortedHeads(v.heads)
+}
+
+func sortedHeads(heads map[string]Head) []Head {
+	sorted := make([]Head, 0, len(heads))
+	for _, head := range heads {
+		sorted = append(sorted, head)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Stream < sorted[j].Stream })
+	return sorted
+}
+
+type requestIDKey struct{}
+
+type actorKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request that
+// causes the changes.
+func WithRequestID(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestId)
+}
+
+// RequestID returns the request ID of ctx, or "".
+func RequestID(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIDKey{}).(string)
+	return requestId
+}
+
+// WithActor returns a copy of ctx carrying the staff member who makes the
+// changes. Ballots name their voter themselves.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor of ctx, or "".
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,249 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var auditNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// chain returns n sealed entries of stream linked from the genesis hash.
+func chain(stream string, n int) []Entry {
+	entries := make([]Entry, 0, n)
+	prevHash := GenesisHash
+	for i := 0; i < n; i++ {
+		entry := Entry{
+			Stream:    stream,
+			Time:      auditNow.Add(time.Duration(i) * time.Second),
+			Actor:     "admin",
+			RequestID: "request",
+			Action:    "vote.update",
+			Entity:    "vote",
+			EntityID:  "1",
+			OldValue:  `{"Name":"Before"}`,
+			NewValue:  `{"Name":"After"}`,
+		}
+		entry.Seal(int64(i+1), prevHash)
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestSeal(t *testing.T) {
+	entries := chain("vote:1", 2)
+	first, second := entries[0], entries[1]
+	if first.Seq != 1 || first.PrevHash != GenesisHash {
+		t.Errorf("first entry is %d after %s, want 1 after the genesis hash", first.Seq, first.PrevHash)
+	}
+	if second.Seq != 2 || second.PrevHash != first.Hash {
+		t.Errorf("second entry is %d after %s, want 2 after %s", second.Seq, second.PrevHash, first.Hash)
+	}
+	if len(first.Hash) != 64 || first.Hash != first.ComputeHash() {
+		t.Errorf("got hash %q, want the 64 hex digits of ComputeHash", first.Hash)
+	}
+	if first.Hash == second.Hash {
+		t.Error("two entries share a hash")
+	}
+}
+
+func TestComputeHash(t *testing.T) {
+	base := chain("vote:1", 1)[0]
+	edits := map[string]func(e *Entry){
+		"stream":     func(e *Entry) { e.Stream = "vote:2" },
+		"seq":        func(e *Entry) { e.Seq++ },
+		"time":       func(e *Entry) { e.Time = e.Time.Add(time.Microsecond) },
+		"actor":      func(e *Entry) { e.Actor = "editor" },
+		"request ID": func(e *Entry) { e.RequestID = "other" },
+		"action":     func(e *Entry) { e.Action = "vote.delete" },
+		"entity":     func(e *Entry) { e.Entity = "topic" },
+		"entity ID":  func(e *Entry) { e.EntityID = "2" },
+		"old value":  func(e *Entry) { e.OldValue = `{"Name":"Other"}` },
+		"new value":  func(e *Entry) { e.NewValue = "" },
+		"prev hash":  func(e *Entry) { e.PrevHash = strings.Repeat("1", 64) },
+		// Length prefixes keep a boundary shift from hashing alike.
+		"shifted field": func(e *Entry) { e.Actor, e.RequestID = "adminrequest", "" },
+	}
+	for name, edit := range edits {
+		t.Run(name, func(t *testing.T) {
+			edited := base
+			edit(&edited)
+			if edited.ComputeHash() == base.Hash {
+				t.Error("the edit does not change the hash")
+			}
+		})
+	}
+
+	t.Run("time zone and precision", func(t *testing.T) {
+		edited := base
+		edited.Time = base.Time.In(time.FixedZone("MSK", 3*60*60)).Add(500 * time.Nanosecond)
+		if edited.ComputeHash() != base.Hash {
+			t.Error("the hash depends on the time zone or on sub-microsecond precision")
+		}
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(entries []Entry) []Entry
+		// failed is the Seq of the entry the verifier refuses, 0 for none.
+		failed int64
+		reason string
+	}{
+		{"intact", func(entries []Entry) []Entry { return entries }, 0, ""},
+		{"edited entry", func(entries []Entry) []Entry {
+			entries[2].NewValue = `{"Name":"Forged"}`
+			return entries
+		}, 3, "does not match its hash"},
+		{"edited and resealed entry", func(entries []Entry) []Entry {
+			entries[2].NewValue = `{"Name":"Forged"}`
+			entries[2].Hash = entries[2].ComputeHash()
+			return entries
+		}, 4, "does not link to entry 3"},
+		{"reordered entries", func(entries []Entry) []Entry {
+			entries[1], entries[2] = entries[2], entries[1]
+			return entries
+		}, 3, "follows entry 1"},
+		{"reordered and renumbered entries", func(entries []Entry) []Entry {
+			entries[1], entries[2] = entries[2], entries[1]
+			entries[1].Seq, entries[2].Seq = 2, 3
+			return entries
+		}, 2, "does not link to entry 1"},
+		{"removed entry", func(entries []Entry) []Entry {
+			return append(entries[:1], entries[2:]...)
+		}, 3, "follows entry 1"},
+		{"removed and renumbered entry", func(entries []Entry) []Entry {
+			entries = append(entries[:1], entries[2:]...)
+			for i := range entries {
+				entries[i].Seq = int64(i + 1)
+			}
+			return entries
+		}, 2, "does not link to entry 1"},
+		{"removed first entry", func(entries []Entry) []Entry { return entries[1:] }, 2, "follows entry 0"},
+		{"inserted entry", func(entries []Entry) []Entry {
+			forged := entries[1]
+			forged.Actor = "intruder"
+			forged.Hash = forged.ComputeHash()
+			return append(entries[:2], append([]Entry{forged}, entries[2:]...)...)
+		}, 2, "vote:1 entry 2 follows entry 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.edit(chain("vote:1", 5))
+			verifier := NewVerifier()
+			var failed int64
+			var err error
+			for i := range entries {
+				if err = verifier.Check(&entries[i]); err != nil {
+					failed = entries[i].Seq
+					break
+				}
+			}
+			if tt.failed == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if verifier.Checked() != int64(len(entries)) {
+					t.Errorf("checked %d entries, want %d", verifier.Checked(), len(entries))
+				}
+				return
+			}
+			if !errors.Is(err, ErrBrokenChain) {
+				t.Fatalf("got error %v, want ErrBrokenChain", err)
+			}
+			if failed != tt.failed || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("refused entry %d with %q, want entry %d with %q", failed, err, tt.failed, tt.reason)
+			}
+		})
+	}
+}
+
+func TestVerifierStreams(t *testing.T) {
+	first, second := chain("vote:1", 3), chain("vote:2", 2)
+	interleaved := []Entry{first[0], second[0], first[1], second[1], first[2]}
+
+	verifier := NewVerifier()
+	for i := range interleaved {
+		if err := verifier.Check(&interleaved[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if verifier.Checked() != 5 {
+		t.Errorf("checked %d entries, want 5", verifier.Checked())
+	}
+	heads := verifier.Heads()
+	want := []Head{{"vote:1", 3, first[2].Hash}, {"vote:2", 2, second[1].Hash}}
+	if len(heads) != len(want) || heads[0] != want[0] || heads[1] != want[1] {
+		t.Errorf("got heads %v, want %v", heads, want)
+	}
+
+	// An entry moved to another stream no longer matches its hash.
+	moved := second[1]
+	moved.Stream = "vote:1"
+	moved.Seq, moved.PrevHash = 4, first[2].Hash
+	if err := verifier.Check(&moved); !errors.Is(err, ErrBrokenChain) {
+		t.Errorf("got error %v for a moved entry, want ErrBrokenChain", err)
+	}
+}
+
+func TestVerifierAnchors(t *testing.T) {
+	first, second := chain("vote:1", 3), chain("vote:2", 2)
+	recorded := []Head{{"vote:1", 2, first[1].Hash}, {"vote:2", 2, second[1].Hash}}
+	forged := chain("vote:1", 3)
+	forged[1].Actor = "intruder"
+	for i := 1; i < len(forged); i++ {
+		forged[i].Seal(forged[i].Seq, forged[i-1].Hash)
+	}
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		err     string
+	}{
+		{"appended", append(append([]Entry{}, first...), second...), ""},
+		{"cut short", append(append([]Entry{}, first[:1]...), second...), "vote:1 ends at entry 1, entry 2 was recorded"},
+		{"stream removed", first, "vote:2 ends at entry 0, entry 2 was recorded"},
+		{"rewritten", append(append([]Entry{}, forged...), second...), "vote:1 entry 2 does not match the recorded head"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewVerifier()
+			for _, head := range recorded {
+				verifier.Anchor(head)
+			}
+			var err error
+			for i := range tt.entries {
+				if err = verifier.Check(&tt.entries[i]); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = verifier.Finish()
+			}
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrBrokenChain) || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if RequestID(ctx) != "" || Actor(ctx) != "" {
+		t.Error("an empty context carries a request ID or an actor")
+	}
+	ctx = WithActor(WithRequestID(ctx, "request"), "admin")
+	if RequestID(ctx) != "request" || Actor(ctx) != "admin" {
+		t.Errorf("got request ID %q and actor %q", RequestID(ctx), Actor(ctx))
+	}
+}
@@ -0,0 +1,47 @@
+// Package auth resolves the tokens sent by clients to stable user IDs.
+//
+// Ballots used to be stored under the raw token of the voter; now they are
+// stored under a pseudonym of the user ID, so rows written before the
+// switch no longer match their voter. The service refuses to start on such
+// a database until "votesctl rekey apply" has resolved the stored tokens to
+// user IDs with a Resolver. Ballots of tokens that do not resolve any more
+// are dropped, so keep retired signing keys in the JWKS file until then.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken is returned by an Authenticator for a token it does not
+// accept: malformed, expired, badly signed or unknown.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Authenticator resolves a token to the ID of the user it was issued to.
+// The same user always gets the same ID, so it can be stored with ballots
+// in place of the token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (string, error)
+}
+
+// Resolver is implemented by Authenticators that can resolve a stored
+// token long after it was issued.
+type Resolver interface {
+	// Resolve returns the ID of the user a token was issued to. Unlike
+	// Authenticate it accepts expired tokens.
+	Resolve(token string) (string, error)
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the ID of the authenticated user.
+func WithUser(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, userKey{}, userId)
+}
+
+// UserID returns the ID of the authenticated user, or "" when the request
+// carried no token.
+func UserID(ctx context.Context) string {
+	userId, _ := ctx.Value(userKey{}).(string)
+	return userId
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"github.com/golang-jwt/jwt/v5"
+	"slices"
+	"time"
+)
+
+// signingMethods are the asymmetric algorithms a token may be signed with.
+// Shared-secret and unsigned tokens are never accepted.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// leeway absorbs clock skew between the token issuer and this service.
+const leeway = 30 * time.Second
+
+// JWT authenticates signed JSON Web Tokens. The signature must verify
+// against a key of a local JWKS file, the token must not be expired and the
+// user ID is its "sub" claim. The file is read once; restart the service
+// after rotating keys.
+type JWT struct {
+	keys     map[string]publicKey
+	issuer   string
+	audience string
+	parser   *jwt.Parser
+	stored   *jwt.Parser
+}
+
+type jwtOptions struct {
+	issuer   string
+	audience string
+	clock    clock.Clock
+}
+
+type JWTOption func(*jwtOptions)
+
+// WithIssuer requires the "iss" claim to be issuer.
+func WithIssuer(issuer string) JWTOption {
+	return func(o *jwtOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience requires the "aud" claim to contain audience.
+func WithAudience(audience string) JWTOption {
+	return func(o *jwtOptions) {
+		o.audience = audience
+	}
+}
+
+// WithClock replaces the wall clock used to check expiry.
+func WithClock(c clock.Clock) JWTOption {
+	return func(o *jwtOptions) {
+		o.clock = c
+	}
+}
+
+// NewJWT loads the keys of the JWKS file at jwksPath.
+func NewJWT(jwksPath string, opts ...JWTOption) (*JWT, error) {
+	const op = "auth.NewJWT"
+
+	o := jwtOptions{clock: clock.System}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	keys, err := loadJWKS(jwksPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+		jwt.WithTimeFunc(o.clock.Now),
+	}
+	if o.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(o.issuer))
+	}
+	if o.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(o.audience))
+	}
+	return &JWT{
+		keys:     keys,
+		issuer:   o.issuer,
+		audience: o.audience,
+		parser:   jwt.NewParser(parserOpts...),
+		stored:   jwt.NewParser(jwt.WithValidMethods(signingMethods), jwt.WithoutClaimsValidation()),
+	}, nil
+}
+
+func (a *JWT) Authenticate(ctx context.Context, token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.key); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	return claims.Subject, nil
+}
+
+// Resolve verifies the signature, issuer and audience of a stored token
+// like Authenticate does, but not its lifetime.
+func (a *JWT) Resolve(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+	if _, err := a.stored.ParseWithClaims(token, &claims, a.key); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return "", fmt.Errorf("%w: issued by %q", ErrInvalidToken, claims.Issuer)
+	}
+	if a.audience != "" && !slices.Contains(claims.Audience, a.audience) {
+		return "", fmt.Errorf("%w: not issued for %q", ErrInvalidToken, a.audience)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	return claims.Subject, nil
+}
+
+// key picks the key named by the "kid" header. A token without one may
+// only be verified when the JWKS has a single key.
+func (a *JWT) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := a.keys[kid]
+	if !ok && kid == "" && len(a.keys) == 1 {
+		for _, only := range a.keys {
+			key, ok = only, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if key.alg != "" && key.alg != token.Method.Alg() {
+		return nil, errors.New("key is not for " + token.Method.Alg())
+	}
+	return key.key, nil
+}
@@ -0,0 +1,336 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var jwtNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+const (
+	testIssuer   = "https://id.example.org"
+	testAudience = "votes"
+)
+
+// testKeys are the signing keys of the test JWKS.
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ed25519 ed25519.PrivateKey
+	path    string
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := &testKeys{rsa: rsaKey, ed25519: edKey}
+	keys.path = writeJWKS(t, rsaJWK("rsa", "RS256", &rsaKey.PublicKey), map[string]string{
+		"kty": "OKP",
+		"kid": "ed",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey)),
+	})
+	return keys
+}
+
+func rsaJWK(kid, alg string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"alg": alg,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// validClaims are accepted by an authenticator requiring testIssuer and
+// testAudience at jwtNow.
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"iss": testIssuer,
+		"aud": testAudience,
+		"iat": jwtNow.Add(-time.Minute).Unix(),
+		"exp": jwtNow.Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func withClaim(name string, value interface{}) jwt.MapClaims {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	keys := newTestKeys(t)
+	publicPEM, err := x509.MarshalPKIXPublicKey(&keys.rsa.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicPEM})
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+		user  string
+	}{
+		{"RS256", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", validClaims(), keys.rsa)
+		}, "user-1"},
+		{"EdDSA", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodEdDSA, "ed", validClaims(), keys.ed25519)
+		}, "user-1"},
+		{"alg none", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodNone, "rsa", validClaims(), jwt.UnsafeAllowNoneSignatureType)
+		}, ""},
+		{"HS256 with the public key", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, "rsa", validClaims(), publicPEM)
+		}, ""},
+		{"HS256 with the modulus", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodHS256, "rsa", validClaims(), keys.rsa.N.Bytes())
+		}, ""},
+		{"algorithm the key is not for", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodPS256, "rsa", validClaims(), keys.rsa)
+		}, ""},
+		{"unknown key", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "other", validClaims(), keys.rsa)
+		}, ""},
+		{"no key ID with two keys", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "", validClaims(), keys.rsa)
+		}, ""},
+		{"signed by another key", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodEdDSA, "ed", validClaims(), ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+		}, ""},
+		{"missing exp", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("exp", nil), keys.rsa)
+		}, ""},
+		{"expired within the leeway", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("exp", jwtNow.Add(-leeway+time.Second).Unix()), keys.rsa)
+		}, "user-1"},
+		{"expired beyond the leeway", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("exp", jwtNow.Add(-leeway-time.Second).Unix()), keys.rsa)
+		}, ""},
+		{"not yet valid within the leeway", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("nbf", jwtNow.Add(leeway-time.Second).Unix()), keys.rsa)
+		}, "user-1"},
+		{"not yet valid beyond the leeway", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("nbf", jwtNow.Add(leeway+time.Second).Unix()), keys.rsa)
+		}, ""},
+		{"wrong issuer", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("iss", "https://evil.example.org"), keys.rsa)
+		}, ""},
+		{"missing issuer", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("iss", nil), keys.rsa)
+		}, ""},
+		{"wrong audience", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("aud", "billing"), keys.rsa)
+		}, ""},
+		{"one of several audiences", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("aud", []string{"billing", testAudience}), keys.rsa)
+		}, "user-1"},
+		{"missing subject", func(t *testing.T) string {
+			return sign(t, jwt.SigningMethodRS256, "rsa", withClaim("sub", nil), keys.rsa)
+		}, ""},
+		{"garbage", func(t *testing.T) string { return "not.a.token" }, ""},
+	}
+
+	authenticator, err := NewJWT(keys.path, WithIssuer(testIssuer), WithAudience(testAudience), WithClock(clock.NewFake(jwtNow)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := authenticator.Authenticate(context.Background(), tt.token(t))
+			if tt.user == "" {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("got user %q, error %v; want ErrInvalidToken", user, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != tt.user {
+				t.Errorf("got user %q, want %q", user, tt.user)
+			}
+		})
+	}
+}
+
+func TestJWTSingleKeyWithoutKeyID(t *testing.T) {
+	keys := newTestKeys(t)
+	path := writeJWKS(t, rsaJWK("rsa", "", &keys.rsa.PublicKey))
+	authenticator, err := NewJWT(path, WithClock(clock.NewFake(jwtNow)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := authenticator.Authenticate(context.Background(), sign(t, jwt.SigningMethodRS256, "", validClaims(), keys.rsa))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != "user-1" {
+		t.Errorf("got user %q, want user-1", user)
+	}
+}
+
+func TestJWTResolve(t *testing.T) {
+	keys := newTestKeys(t)
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		key    interface{}
+		user   string
+	}{
+		{"valid", validClaims(), keys.rsa, "user-1"},
+		{"expired long ago", withClaim("exp", jwtNow.AddDate(-1, 0, 0).Unix()), keys.rsa, "user-1"},
+		{"missing exp", withClaim("exp", nil), keys.rsa, "user-1"},
+		{"wrong issuer", withClaim("iss", "https://evil.example.org"), keys.rsa, ""},
+		{"wrong audience", withClaim("aud", "billing"), keys.rsa, ""},
+		{"missing subject", withClaim("sub", nil), keys.rsa, ""},
+		{"signed by another key", validClaims(), mustRSAKey(t), ""},
+	}
+
+	authenticator, err := NewJWT(keys.path, WithIssuer(testIssuer), WithAudience(testAudience), WithClock(clock.NewFake(jwtNow)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := authenticator.Resolve(sign(t, jwt.SigningMethodRS256, "rsa", tt.claims, tt.key))
+			if tt.user == "" {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("got user %q, error %v; want ErrInvalidToken", user, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != tt.user {
+				t.Errorf("got user %q, want %q", user, tt.user)
+			}
+		})
+	}
+}
+
+func mustRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestLoadJWKS(t *testing.T) {
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := newTestKeys(t)
+	tests := []struct {
+		name string
+		keys []map[string]string
+		ok   bool
+	}{
+		{"RSA 2048", []map[string]string{rsaJWK("rsa", "RS256", &keys.rsa.PublicKey)}, true},
+		{"RSA 1024", []map[string]string{rsaJWK("small", "RS256", &small.PublicKey)}, false},
+		{"RSA 1024 next to a good key", []map[string]string{rsaJWK("rsa", "RS256", &keys.rsa.PublicKey), rsaJWK("small", "RS256", &small.PublicKey)}, false},
+		{"duplicate key ID", []map[string]string{rsaJWK("rsa", "RS256", &keys.rsa.PublicKey), rsaJWK("rsa", "PS256", &keys.rsa.PublicKey)}, false},
+		{"only an encryption key", []map[string]string{{"kty": "RSA", "use": "enc", "n": "AQAB", "e": "AQAB"}}, false},
+		{"symmetric key skipped", []map[string]string{{"kty": "oct", "k": "c2VjcmV0"}, rsaJWK("rsa", "RS256", &keys.rsa.PublicKey)}, true},
+		{"unknown curve", []map[string]string{{"kty": "OKP", "crv": "X25519", "x": "AAAA"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJWT(writeJWKS(t, tt.keys...))
+			if (err == nil) != tt.ok {
+				t.Errorf("got error %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestStub(t *testing.T) {
+	tests := []struct {
+		name  string
+		users map[string]string
+		token string
+		user  string
+	}{
+		{"any token", nil, "token", "token"},
+		{"empty token", nil, "", ""},
+		{"known token", map[string]string{"token": "user-1"}, "token", "user-1"},
+		{"unknown token", map[string]string{"token": "user-1"}, "other", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := NewStub(tt.users)
+			for _, resolve := range []func(string) (string, error){
+				func(token string) (string, error) { return stub.Authenticate(context.Background(), token) },
+				stub.Resolve,
+			} {
+				user, err := resolve(tt.token)
+				if tt.user == "" {
+					if !errors.Is(err, ErrInvalidToken) {
+						t.Errorf("got user %q, error %v; want ErrInvalidToken", user, err)
+					}
+					continue
+				}
+				if err != nil || user != tt.user {
+					t.Errorf("got user %q, error %v; want %q", user, err, tt.user)
+				}
+			}
+		})
+	}
+}
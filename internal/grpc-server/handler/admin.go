@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+type AdminHandler struct {
+	cfg *config.Config
+	proto.UnimplementedVotesAdminServiceServer
+	storage storage.Repository
+	clock   clock.Clock
+	logger  *slog.Logger
+}
+
+func NewAdminHandler(cfg *config.Config, server *grpc.Server, storage storage.Repository, clock clock.Clock, logger *slog.Logger) *AdminHandler {
+	handler := &AdminHandler{cfg: cfg, storage: storage, clock: clock, logger: logger}
+	proto.RegisterVotesAdminServiceServer(server, handler)
+	logger.Info("AdminHandler initialized", slog.String("address", cfg.Address))
+	return handler
+}
+
+func (h *AdminHandler) CreateVote(ctx context.Context, request *proto.CreateVoteRequest) (*proto.CreateVoteResponse, error) {
+	h.logger.Debug("Received CreateVote request", slog.Any("request", request))
+
+	vote := voteFromProto(request.GetVote())
+	if err := storage.ValidateVote(vote, h.clock.Now()); err != nil {
+		return nil, invalidRequest("Invalid vote: "+err.Error(), nil)
+	}
+
+	created, err := h.storage.CreateVote(ctx, vote)
+	if err != nil {
+		return nil, h.handleStorageError(err, "creating vote")
+	}
+
+	h.logger.Info("Vote created", slog.Int("vote_id", created.ID), slog.String("category", created.Category))
+	return &proto.CreateVoteResponse{Response: voteToProto(created)}, nil
+}
+
+func (h *AdminHandler) UpdateVote(ctx context.Context, request *proto.UpdateVoteRequest) (*proto.UpdateVoteResponse, error) {
+	h.logger.Debug("Received UpdateVote request", slog.Any("request", request))
+
+	vote := voteFromProto(request.GetVote())
+	if vote.ID <= 0 {
+		return nil, invalidRequest("Invalid vote: id is required", map[string]string{"field": "id"})
+	}
+	vote.Status = ""
+	if err := storage.ValidateVote(vote, h.clock.Now()); err != nil {
+		return nil, invalidRequest("Invalid vote: "+err.Error(), nil)
+	}
+
+	updated, err := h.storage.UpdateVote(ctx, vote)
+	if err != nil {
+		return nil, h.handleStorageError(err, "updating vote")
+	}
+
+	h.logger.Info("Vote updated", slog.Int("vote_id", updated.ID))
+	return &proto.UpdateVoteResponse{Response: voteToProto(updated)}, nil
+}
+
+func (h *AdminHandler) DeleteVote(ctx context.Context, request *proto.DeleteVoteRequest) (*proto.DeleteVoteResponse, error) {
+	h.logger.Debug("Received DeleteVote request", slog.Any("request", request))
+
+	if err := h.storage.DeleteVote(ctx, int(request.VoteId)); err != nil {
+		return nil, h.handleStorageError(err, "deleting vote")
+	}
+
+	h.logger.Info("Vote deleted", slog.Int("vote_id", int(request.VoteId)))
+	return &proto.DeleteVoteResponse{Response: "Vote deleted successfully"}, nil
+}
+
+func (h *AdminHandler) ListAllVotes(ctx context.Context, request *proto.ListAllVotesRequest) (*proto.ListAllVotesResponse, error) {
+	h.logger.Debug("Received ListAllVotes request", slog.Any("request", request))
+
+	if request.Status != "" && !storage.IsKnownStatus(request.Status) {
+		return nil, invalidRequest("Unknown status "+request.Status, map[string]string{"field": "status"})
+	}
+
+	votes, err := h.storage.ListAllVotes(ctx, request.Status)
+	if err != nil {
+		return nil, h.handleStorageError(err, "votes")
+	}
+	votes = visibleVotes(ctx, votes)
+
+	protoVotes := make([]*proto.VoteDefinition, 0, len(votes))
+	for _, vote := range votes {
+		protoVotes = append(protoVotes, voteToProto(vote))
+	}
+	return &proto.ListAllVotesResponse{Response: protoVotes}, nil
+}
+
+func (h *AdminHandler) SetVoteStatus(ctx context.Context, request *proto.SetVoteStatusRequest) (*proto.SetVoteStatusResponse, error) {
+	h.logger.Debug("Received SetVoteStatus request", slog.Any("request", request))
+
+	if !storage.IsKnownStatus(request.Status) {
+		return nil, invalidRequest("Unknown status "+request.Status, map[string]string{"field": "status"})
+	}
+
+	vote, err := h.storage.SetVoteStatus(ctx, int(request.VoteId), request.Status)
+	if err != nil {
+		return nil, h.handleStorageError(err, "changing vote status")
+	}
+
+	h.logger.Info("Vote status changed", slog.Int("vote_id", vote.ID), slog.String("status", vote.Status))
+	return &proto.SetVoteStatusResponse{Response: voteToProto(vote)}, nil
+}
+
+func (h *AdminHandler) ListComments(ctx context.Context, request *proto.ListCommentsRequest) (*proto.ListCommentsResponse, error) {
+	h.logger.Debug("Received ListComments request", slog.Any("request", request))
+
+	commentStatus := request.Status
+	if commentStatus == "" {
+		commentStatus = storage.CommentPending
+	}
+	if !storage.IsKnownCommentStatus(commentStatus) {
+		return nil, invalidRequest("Unknown comment status "+commentStatus, map[string]string{"field": "status"})
+	}
+
+	comments, err := h.storage.ListComments(ctx, commentStatus, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "comments")
+	}
+	return &proto.ListCommentsResponse{Response: commentsToProto(comments)}, nil
+}
+
+func (h *AdminHandler) ReviewComment(ctx context.Context, request *proto.ReviewCommentRequest) (*proto.ReviewCommentResponse, error) {
+	h.logger.Debug("Received ReviewComment request", slog.Any("request", request))
+
+	if err := storage.ValidateReview(request.Status, request.Reason); err != nil {
+		return nil, invalidRequest("Invalid review: "+err.Error(), map[string]string{"field": "status"})
+	}
+
+	comment, err := h.storage.ReviewComment(ctx, int(request.CommentId), request.Status, request.Reason)
+	if err != nil {
+		return nil, h.handleStorageError(err, "reviewing comment")
+	}
+
+	h.logger.Info("Comment reviewed", slog.Int("comment_id", comment.ID), slog.String("status", comment.Status))
+	return &proto.ReviewCommentResponse{Response: commentToProto(comment)}, nil
+}
+
+func (h *AdminHandler) ReviewPetition(ctx context.Context, request *proto.ReviewPetitionRequest) (*proto.ReviewPetitionResponse, error) {
+	h.logger.Debug("Received ReviewPetition request", slog.Any("request", request))
+
+	if err := storage.ValidatePetitionReview(request.Status, request.Reason, int(request.SignatureGoal)); err != nil {
+		return nil, invalidRequest("Invalid review: "+err.Error(), nil)
+	}
+
+	vote, err := h.storage.ReviewPetition(ctx, int(request.VoteId), request.Status, request.Reason, int(request.SignatureGoal))
+	if err != nil {
+		return nil, h.handleStorageError(err, "reviewing petition")
+	}
+
+	h.logger.Info("Petition reviewed", slog.Int("vote_id", vote.ID), slog.String("status", vote.Status))
+	return &proto.ReviewPetitionResponse{Response: voteToProto(vote)}, nil
+}
+
+func (h *AdminHandler) PublishPetitionResponse(ctx context.Context, request *proto.PublishPetitionResponseRequest) (*proto.PublishPetitionResponseResponse, error) {
+	h.logger.Debug("Received PublishPetitionResponse request", slog.Any("request", request))
+
+	response := &storage.PetitionResponse{
+		VoteID:       int(request.VoteId),
+		Decision:     request.Decision,
+		Text:         request.Text,
+		Organization: request.Organization,
+		Attachments:  attachmentsFromProto(request.Attachments),
+	}
+	if err := storage.ValidateResponse(response); err != nil {
+		return nil, invalidRequest("Invalid response: "+err.Error(), nil)
+	}
+
+	published, err := h.storage.PublishResponse(ctx, response)
+	if err != nil {
+		return nil, h.handleStorageError(err, "publishing petition response")
+	}
+
+	h.logger.Info("Petition response published", slog.Int("vote_id", published.VoteID), slog.String("decision", published.Decision))
+	return &proto.PublishPetitionResponseResponse{Response: petitionResponseToProto(published)}, nil
+}
+
+func (h *AdminHandler) ListOverduePetitions(ctx context.Context, request *proto.ListOverduePetitionsRequest) (*proto.ListOverduePetitionsResponse, error) {
+	h.logger.Debug("Received ListOverduePetitions request", slog.Any("request", request))
+
+	votes, err := h.storage.ListOverduePetitions(ctx)
+	if err != nil {
+		return nil, h.handleStorageError(err, "overdue petitions")
+	}
+	votes = visibleVotes(ctx, votes)
+
+	protoVotes := make([]*proto.VoteDefinition, 0, len(votes))
+	for _, vote := range votes {
+		protoVotes = append(protoVotes, voteToProto(vote))
+	}
+	return &proto.ListOverduePetitionsResponse{Response: protoVotes}, nil
+}
+
+func (h *AdminHandler) handleStorageError(err error, context string) error {
+	return storageStatus(h.logger, err, context)
+}
+
+func voteFromProto(vote *proto.VoteDefinition) *storage.Vote {
+	v := &storage.Vote{
+		ID:             int(vote.GetId()),
+		ExternalKey:    vote.GetExternalKey(),
+		Category:       vote.GetCategory(),
+		Name:           vote.GetName(),
+		Description:    vote.GetDescription(),
+		Organization:   vote.GetOrganization(),
+		OrganizationID: int(vote.GetOrganizationId()),
+		Topics:         vote.GetTopics(),
+		Photo:          vote.GetPhoto(),
+		Status:         vote.GetStatus(),
+		Options:        optionsFromProto(vote.GetOptionDetails(), vote.GetOptions()),
+		Scale: storage.RateScale{
+			Min:  int(vote.GetRateScale().GetMin()),
+			Max:  int(vote.GetRateScale().GetMax()),
+			Step: int(vote.GetRateScale().GetStep()),
+		},
+		Selection: storage.SelectionRange{
+			Min: int(vote.GetMinSelections()),
+			Max: int(vote.GetMaxSelections()),
+		},
+		Budget:        int(vote.GetBudget()),
+		SignatureGoal: int(vote.GetSignatureGoal()),
+		Questions:     questionsFromProto(vote.GetQuestions()),
+		FollowUps:     followUpsFromProto(vote.GetFollowUps()),
+	}
+	if vote.GetStart() != nil {
+		v.StartTime = vote.GetStart().AsTime()
+	}
+	if vote.GetEnd() != nil {
+		v.EndTime = vote.GetEnd().AsTime()
+	}
+	return v
+}
+
+func voteToProto(vote *storage.Vote) *proto.VoteDefinition {
+	v := &proto.VoteDefinition{
+		Id:              int32(vote.ID),
+		Category:        vote.Category,
+		Name:            vote.Name,
+		Description:     vote.Description,
+		Organization:    vote.Organization,
+		OrganizationId:  int32(vote.OrganizationID),
+		Topics:          vote.Topics,
+		End:             timestamppb.New(vote.EndTime),
+		Photo:           vote.Photo,
+		Options:         storage.OptionTexts(vote.Options),
+		ExternalKey:     vote.ExternalKey,
+		OptionDetails:   optionsToProto(vote.Options),
+		Status:          vote.Status,
+		MinSelections:   int32(vote.Selection.Min),
+		MaxSelections:   int32(vote.Selection.Max),
+		Budget:          int32(vote.Budget),
+		Questions:       questionsToProto(vote.Questions),
+		FollowUps:       followUpsToProto(vote.FollowUps),
+		SignatureGoal:   int32(vote.SignatureGoal),
+		ReviewReason:    vote.ReviewReason,
+		ResponseDue:     optionalTimestamp(vote.ResponseDue),
+		ResponseOverdue: vote.ResponseOverdue,
+		RateScale: &proto.RateScale{
+			Min:  int32(vote.Scale.Min),
+			Max:  int32(vote.Scale.Max),
+			Step: int32(vote.Scale.Step),
+		},
+	}
+	if !vote.StartTime.IsZero() {
+		v.Start = timestamppb.New(vote.StartTime)
+	}
+	return v
+}
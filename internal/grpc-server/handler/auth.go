@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"log/slog"
+	"strings"
+)
+
+// tokenRequest is implemented by the requests that carry a user token.
+type tokenRequest interface {
+	GetToken() string
+}
+
+// AuthInterceptor authenticates the user token of every request and passes
+// the user ID on in the context, see auth.UserID. The token is read from the
+// "authorization" metadata as "Bearer <token>", falling back to the token
+// field of the request. A request without a token is passed on anonymously
+// and methods that need a user reject it themselves; an invalid token is
+// rejected with Unauthenticated.
+func AuthInterceptor(authenticator auth.Authenticator, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token := bearerToken(ctx)
+		if request, ok := req.(tokenRequest); ok && token == "" {
+			token = request.GetToken()
+		}
+		if token == "" {
+			return handler(ctx, req)
+		}
+
+		userId, err := authenticator.Authenticate(ctx, token)
+		if err != nil {
+			if !errors.Is(err, auth.ErrInvalidToken) {
+				logger.Error("Authentication failed", slog.String("method", info.FullMethod), slog.String("error", err.Error()))
+				return nil, errorStatus(codes.Internal, ReasonInternal, "Failed to authenticate", nil)
+			}
+			logger.Warn("Token rejected", slog.String("method", info.FullMethod), slog.String("error", err.Error()))
+			return nil, errorStatus(codes.Unauthenticated, ReasonUnauthenticated, "Invalid token", nil)
+		}
+		return handler(auth.WithUser(ctx, userId), req)
+	}
+}
+
+// bearerToken returns the token of the "authorization" metadata, or "".
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+		if found && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// requireVoter returns the pseudonym the ballots of the authenticated user
+// are stored under, or Unauthenticated when the request carried no token.
+// User IDs never reach storage or the logs of ballot requests.
+func (h *GRPCHandler) requireVoter(ctx context.Context) (string, error) {
+	userId := auth.UserID(ctx)
+	if userId == "" {
+		return "", errorStatus(codes.Unauthenticated, ReasonUnauthenticated, "Token is required", nil)
+	}
+	return h.voters.VoterID(userId), nil
+}
+
+// voter returns the pseudonym of the authenticated user, or "" for an
+// anonymous request.
+func (h *GRPCHandler) voter(ctx context.Context) string {
+	userId := auth.UserID(ctx)
+	if userId == "" {
+		return ""
+	}
+	return h.voters.VoterID(userId)
+}
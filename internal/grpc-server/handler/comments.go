@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"strings"
+)
+
+// ballotComment prepares the comment sent with a ballot. Empty text keeps
+// the stored comment; text flagged by the filter is stored as rejected and
+// skips the moderation queue.
+func (h *GRPCHandler) ballotComment(text string) *storage.Comment {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	comment := &storage.Comment{Text: text, Status: storage.CommentPending}
+	if verdict := h.filter.Check(text); verdict.Flagged {
+		h.logger.Info("Comment rejected by filter", slog.String("reason", verdict.Reason), slog.Int("matches", len(verdict.Matches)))
+		comment.Status = storage.CommentRejected
+		comment.Reason = verdict.Reason
+	}
+	return comment
+}
+
+func commentToProto(comment *storage.Comment) *proto.Comment {
+	if comment == nil {
+		return nil
+	}
+	c := &proto.Comment{
+		Id:      int32(comment.ID),
+		VoteId:  int32(comment.VoteID),
+		Text:    comment.Text,
+		Status:  comment.Status,
+		Reason:  comment.Reason,
+		Created: timestamppb.New(comment.CreatedAt),
+	}
+	if !comment.ReviewedAt.IsZero() {
+		c.Reviewed = timestamppb.New(comment.ReviewedAt)
+	}
+	return c
+}
+
+func commentsToProto(comments []storage.Comment) []*proto.Comment {
+	protoComments := make([]*proto.Comment, 0, len(comments))
+	for i := range comments {
+		protoComments = append(protoComments, commentToProto(&comments[i]))
+	}
+	return protoComments
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// errorDomain is the ErrorInfo domain clients use to recognise errors
+// produced by this service.
+const errorDomain = "votes.kdt2024"
+
+// Reasons reported in google.rpc.ErrorInfo. Clients switch on these values,
+// so they must never change once released.
+const (
+	ReasonVoteNotFound         = "VOTE_NOT_FOUND"
+	ReasonCommentNotFound      = "COMMENT_NOT_FOUND"
+	ReasonOrganizationNotFound = "ORGANIZATION_NOT_FOUND"
+	ReasonTopicNotFound        = "TOPIC_NOT_FOUND"
+	ReasonRoleNotFound         = "ROLE_NOT_FOUND"
+	ReasonBallotNotFound       = "BALLOT_NOT_FOUND"
+	ReasonWrongVoteType        = "WRONG_VOTE_TYPE"
+	ReasonVoteClosed           = "VOTE_CLOSED"
+	ReasonVoteNotStarted       = "VOTE_NOT_STARTED"
+	ReasonInvalidOption        = "INVALID_OPTION"
+	ReasonInvalidQuestion      = "INVALID_QUESTION"
+	ReasonInvalidBallot        = "INVALID_BALLOT"
+	ReasonConflict             = "CONFLICT"
+	ReasonInvalidTransition    = "INVALID_TRANSITION"
+	ReasonRulesLocked          = "RULES_LOCKED"
+	ReasonVoteHasBallots       = "VOTE_HAS_BALLOTS"
+	ReasonInvalidRequest       = "INVALID_REQUEST"
+	ReasonUnauthenticated      = "UNAUTHENTICATED"
+	ReasonPermissionDenied     = "PERMISSION_DENIED"
+	ReasonInternal             = "INTERNAL"
+)
+
+var storageErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+	text   string
+}{
+	{storage.ErrCommentNotFound, codes.NotFound, ReasonCommentNotFound, "comment not found"},
+	{storage.ErrOrganizationNotFound, codes.NotFound, ReasonOrganizationNotFound, "organization not found"},
+	{storage.ErrTopicNotFound, codes.NotFound, ReasonTopicNotFound, "topic not found"},
+	{storage.ErrRoleNotFound, codes.NotFound, ReasonRoleNotFound, "user does not have this role"},
+	{storage.ErrBallotNotFound, codes.NotFound, ReasonBallotNotFound, "no ballot to withdraw"},
+	{storage.ErrNotFound, codes.NotFound, ReasonVoteNotFound, "vote not found"},
+	{storage.ErrWrongVoteType, codes.InvalidArgument, ReasonWrongVoteType, "vote has a different type"},
+	{storage.ErrVoteClosed, codes.FailedPrecondition, ReasonVoteClosed, "vote is closed"},
+	{storage.ErrVoteNotStarted, codes.FailedPrecondition, ReasonVoteNotStarted, "vote has not started yet"},
+	{storage.ErrInvalidOption, codes.InvalidArgument, ReasonInvalidOption, "option is not valid for this vote"},
+	{storage.ErrInvalidQuestion, codes.InvalidArgument, ReasonInvalidQuestion, "question is not valid for this survey"},
+	{storage.ErrInvalidBallot, codes.InvalidArgument, ReasonInvalidBallot, "ballot does not match the vote rules"},
+	{storage.ErrConflict, codes.AlreadyExists, ReasonConflict, "conflicts with existing data"},
+	{storage.ErrInvalidTransition, codes.FailedPrecondition, ReasonInvalidTransition, "vote cannot move to this status"},
+	{storage.ErrRulesLocked, codes.FailedPrecondition, ReasonRulesLocked, "ballot rules cannot change once the vote has opened or has ballots"},
+	{storage.ErrVoteHasBallots, codes.FailedPrecondition, ReasonVoteHasBallots, "a vote with ballots cannot be deleted, archive it instead"},
+}
+
+// storageStatus converts a storage error into a gRPC status. Known errors
+// keep their meaning; anything else becomes Internal without exposing the
+// underlying driver message.
+func storageStatus(logger *slog.Logger, err error, subject string) error {
+	for _, known := range storageErrors {
+		if errors.Is(err, known.err) {
+			logger.Warn("Storage operation rejected", slog.String("context", subject), slog.String("reason", known.reason), slog.String("error", err.Error()))
+			return errorStatus(known.code, known.reason, "Failed to process "+subject+": "+known.text, map[string]string{"context": subject})
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "Request was cancelled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "Request deadline exceeded")
+	}
+
+	logger.Error("Storage operation failed", slog.String("context", subject), slog.String("error", err.Error()))
+	return errorStatus(codes.Internal, ReasonInternal, "Failed to process "+subject, map[string]string{"context": subject})
+}
+
+func invalidRequest(message string, metadata map[string]string) error {
+	return errorStatus(codes.InvalidArgument, ReasonInvalidRequest, message, metadata)
+}
+
+func errorStatus(code codes.Code, reason, message string, metadata map[string]string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
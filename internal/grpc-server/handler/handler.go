@@ -2,25 +2,35 @@ package handler
 
 import (
 	"context"
-	"github.com/GP-Hacks/kdt2024-commons/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
 	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"github.com/GP-Hacks/kdt2024-votes/internal/moderation"
+	"github.com/GP-Hacks/kdt2024-votes/internal/pseudonym"
 	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"github.com/GP-Hacks/kdt2024-votes/internal/tally"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
+	"math"
+	"sort"
+	"strings"
 )
 
 type GRPCHandler struct {
 	cfg *config.Config
 	proto.UnimplementedVotesServiceServer
-	storage *storage.PostgresStorage
+	storage storage.Repository
+	filter  moderation.Filter
+	voters  *pseudonym.Keys
+	clock   clock.Clock
 	logger  *slog.Logger
 }
 
-func NewGRPCHandler(cfg *config.Config, server *grpc.Server, storage *storage.PostgresStorage, logger *slog.Logger) *GRPCHandler {
-	handler := &GRPCHandler{cfg: cfg, storage: storage, logger: logger}
+func NewGRPCHandler(cfg *config.Config, server *grpc.Server, storage storage.Repository, filter moderation.Filter, voters *pseudonym.Keys, clock clock.Clock, logger *slog.Logger) *GRPCHandler {
+	handler := &GRPCHandler{cfg: cfg, storage: storage, filter: filter, voters: voters, clock: clock, logger: logger}
 	proto.RegisterVotesServiceServer(server, handler)
 	logger.Info("GRPCHandler initialized", slog.String("address", cfg.Address))
 	return handler
@@ -36,36 +46,57 @@ func (h *GRPCHandler) GetVotes(ctx context.Context, request *proto.GetVotesReque
 	default:
 	}
 
-	var votes []*storage.Vote
-	var err error
-
-	category := request.GetCategory()
-	if category == "all" {
-		votes, err = h.storage.GetVotes(ctx)
-	} else {
-		votes, err = h.storage.GetVotesByCategory(ctx, category)
+	filter := storage.VoteFilter{
+		Category:       request.GetCategory(),
+		OrganizationID: int(request.GetOrganizationId()),
+		Topic:          request.GetTopic(),
+	}
+	if filter.Category == "all" {
+		filter.Category = ""
 	}
+	votes, err := h.storage.GetVotes(ctx, filter)
 	if err != nil {
 		return nil, h.handleStorageError(err, "votes")
 	}
 
 	var protoVotes []*proto.Vote
 	for _, vote := range votes {
-		protoVotes = append(protoVotes, &proto.Vote{
-			Id:           int32(vote.ID),
-			Category:     vote.Category,
-			Name:         vote.Name,
-			Description:  vote.Description,
-			Organization: vote.Organization,
-			End:          timestamppb.New(vote.EndTime),
-			Photo:        vote.Photo,
-			Options:      vote.Options,
-		})
+		protoVotes = append(protoVotes, voteSummaryToProto(vote))
 	}
 
 	return &proto.GetVotesResponse{Response: protoVotes}, nil
 }
 
+func (h *GRPCHandler) SearchVotes(ctx context.Context, request *proto.SearchVotesRequest) (*proto.SearchVotesResponse, error) {
+	h.logger.Debug("Received SearchVotes request", slog.Any("request", request))
+
+	query := storage.SearchQuery{
+		Text:     request.Query,
+		Category: request.Category,
+		Limit:    int(request.Limit),
+		Offset:   int(request.Offset),
+	}
+	if err := storage.ValidateSearch(&query); err != nil {
+		return nil, invalidRequest("Invalid search: "+err.Error(), nil)
+	}
+
+	found, err := h.storage.SearchVotes(ctx, query)
+	if err != nil {
+		return nil, h.handleStorageError(err, "searching votes")
+	}
+
+	results := make([]*proto.SearchResult, 0, len(found.Results))
+	for _, result := range found.Results {
+		results = append(results, &proto.SearchResult{
+			Vote:     voteSummaryToProto(result.Vote),
+			Rank:     float32(result.Rank),
+			Headline: result.Headline,
+			Snippet:  result.Snippet,
+		})
+	}
+	return &proto.SearchVotesResponse{Response: results, Fuzzy: found.Fuzzy}, nil
+}
+
 func (h *GRPCHandler) GetCategories(ctx context.Context, request *proto.GetCategoriesRequest) (*proto.GetCategoriesResponse, error) {
 	h.logger.Debug("Received GetCategories request", slog.Any("request", request))
 
@@ -94,7 +125,7 @@ func (h *GRPCHandler) GetRateInfo(ctx context.Context, request *proto.GetVoteInf
 	default:
 	}
 
-	rates, err := h.storage.GetUserRates(ctx, request.Token)
+	rates, err := h.storage.GetUserRates(ctx, h.voter(ctx))
 	if err != nil {
 		return nil, h.handleStorageError(err, "rates")
 	}
@@ -116,18 +147,37 @@ func (h *GRPCHandler) GetRateInfo(ctx context.Context, request *proto.GetVoteInf
 		return nil, h.handleStorageError(err, "fetching rate info")
 	}
 
+	pending, err := h.storage.GetPendingFollowUps(ctx, h.voter(ctx), int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "follow-ups")
+	}
+
+	comment, err := h.storage.GetUserComment(ctx, h.voter(ctx), int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "comment")
+	}
+
+	lastChange, err := h.lastBallotChange(ctx, request.VoteId)
+	if err != nil {
+		return nil, err
+	}
+
 	return &proto.GetRateInfoResponse{
 		Response: &proto.VoteInfo{
-			Id:           int32(rateInfo.ID),
-			Category:     rateInfo.Category,
-			Name:         rateInfo.Name,
-			Description:  rateInfo.Description,
-			Organization: rateInfo.Organization,
-			End:          timestamppb.New(rateInfo.EndTime),
-			Options:      rateInfo.Options,
-			Photo:        rateInfo.Photo,
-			Mid:          float32(rateInfo.Mid),
-			Rate:         xxx,
+			Id:               int32(rateInfo.ID),
+			Category:         rateInfo.Category,
+			Name:             rateInfo.Name,
+			Description:      rateInfo.Description,
+			Organization:     rateInfo.Organization,
+			End:              timestamppb.New(rateInfo.EndTime),
+			Options:          storage.OptionTexts(rateInfo.Options),
+			Photo:            rateInfo.Photo,
+			Mid:              float32(rateInfo.Mid),
+			Rate:             xxx,
+			PendingFollowUps: followUpsToProto(pending),
+			Comment:          commentToProto(comment),
+			Comments:         commentsToProto(rateInfo.Comments),
+			LastChange:       lastChange,
 		},
 	}, nil
 }
@@ -135,7 +185,7 @@ func (h *GRPCHandler) GetRateInfo(ctx context.Context, request *proto.GetVoteInf
 func (h *GRPCHandler) GetPetitionInfo(ctx context.Context, request *proto.GetVoteInfoRequest) (*proto.GetPetitionInfoResponse, error) {
 	h.logger.Debug("Received GetPetitionInfo request", slog.Any("request", request))
 
-	petitions, err := h.storage.GetUserPetitions(ctx, request.Token)
+	petitions, err := h.storage.GetUserPetitions(ctx, h.voter(ctx))
 	if err != nil {
 		return nil, h.handleStorageError(err, "petitions")
 	}
@@ -158,18 +208,30 @@ func (h *GRPCHandler) GetPetitionInfo(ctx context.Context, request *proto.GetVot
 		return nil, h.handleStorageError(err, "fetching petition info")
 	}
 
+	lastChange, err := h.lastBallotChange(ctx, request.VoteId)
+	if err != nil {
+		return nil, err
+	}
+
 	return &proto.GetPetitionInfoResponse{
 		Response: &proto.PetitionInfo{
-			Id:           int32(petitionInfo.ID),
-			Category:     petitionInfo.Category,
-			Name:         petitionInfo.Name,
-			Description:  petitionInfo.Description,
-			Organization: petitionInfo.Organization,
-			End:          timestamppb.New(petitionInfo.EndTime),
-			Options:      petitionInfo.Options,
-			Photo:        petitionInfo.Photo,
-			Stats:        petitionInfo.Stats,
-			Support:      xxx,
+			Id:               int32(petitionInfo.ID),
+			Category:         petitionInfo.Category,
+			Name:             petitionInfo.Name,
+			Description:      petitionInfo.Description,
+			Organization:     petitionInfo.Organization,
+			End:              timestamppb.New(petitionInfo.EndTime),
+			Options:          storage.OptionTexts(petitionInfo.Options),
+			Photo:            petitionInfo.Photo,
+			Stats:            petitionInfo.Stats,
+			Support:          xxx,
+			Status:           petitionInfo.Status,
+			SignatureGoal:    int32(petitionInfo.SignatureGoal),
+			Signatures:       int32(petitionInfo.Signatures),
+			OfficialResponse: petitionResponseToProto(petitionInfo.Response),
+			ResponseDue:      optionalTimestamp(petitionInfo.ResponseDue),
+			ResponseOverdue:  petitionInfo.ResponseOverdue,
+			LastChange:       lastChange,
 		},
 	}, nil
 }
@@ -177,7 +239,7 @@ func (h *GRPCHandler) GetPetitionInfo(ctx context.Context, request *proto.GetVot
 func (h *GRPCHandler) GetChoiceInfo(ctx context.Context, request *proto.GetVoteInfoRequest) (*proto.GetChoiceInfoResponse, error) {
 	h.logger.Debug("Received GetChoiceInfo request", slog.Any("request", request))
 
-	choices, err := h.storage.GetUserChoices(ctx, request.Token)
+	choices, err := h.storage.GetUserChoices(ctx, h.voter(ctx))
 
 	if err != nil {
 		return nil, h.handleStorageError(err, "choices")
@@ -192,8 +254,16 @@ func (h *GRPCHandler) GetChoiceInfo(ctx context.Context, request *proto.GetVoteI
 	}
 
 	var xxx string
-	if uchoice != nil {
-		xxx = uchoice.Choice
+	var optionId int32
+	var optionIds []int32
+	var selected []string
+	if uchoice != nil && len(uchoice.OptionIDs) > 0 {
+		xxx = uchoice.Choices[0]
+		optionId = int32(uchoice.OptionIDs[0])
+		for _, id := range uchoice.OptionIDs {
+			optionIds = append(optionIds, int32(id))
+		}
+		selected = uchoice.Choices
 	}
 
 	choiceInfo, err := h.storage.GetChoiceInfo(ctx, int(request.VoteId))
@@ -201,58 +271,455 @@ func (h *GRPCHandler) GetChoiceInfo(ctx context.Context, request *proto.GetVoteI
 		return nil, h.handleStorageError(err, "fetching choice info")
 	}
 
+	pending, err := h.storage.GetPendingFollowUps(ctx, h.voter(ctx), int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "follow-ups")
+	}
+
+	comment, err := h.storage.GetUserComment(ctx, h.voter(ctx), int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "comment")
+	}
+
+	lastChange, err := h.lastBallotChange(ctx, request.VoteId)
+	if err != nil {
+		return nil, err
+	}
+
 	return &proto.GetChoiceInfoResponse{
 		Response: &proto.ChoiceInfo{
-			Id:           int32(choiceInfo.ID),
-			Category:     choiceInfo.Category,
-			Name:         choiceInfo.Name,
-			Description:  choiceInfo.Description,
-			Organization: choiceInfo.Organization,
-			End:          timestamppb.New(choiceInfo.EndTime),
-			Options:      choiceInfo.Options,
-			Photo:        choiceInfo.Photo,
-			Stats:        choiceInfo.Stats,
-			Choice:       xxx,
+			Id:               int32(choiceInfo.ID),
+			Category:         choiceInfo.Category,
+			Name:             choiceInfo.Name,
+			Description:      choiceInfo.Description,
+			Organization:     choiceInfo.Organization,
+			End:              timestamppb.New(choiceInfo.EndTime),
+			Options:          storage.OptionTexts(choiceInfo.Options),
+			Photo:            choiceInfo.Photo,
+			Stats:            choiceInfo.Stats,
+			Choice:           xxx,
+			OptionDetails:    optionsToProto(choiceInfo.Options),
+			OptionId:         optionId,
+			OptionIds:        optionIds,
+			Choices:          selected,
+			MinSelections:    int32(choiceInfo.Selection.Min),
+			MaxSelections:    int32(choiceInfo.Selection.Max),
+			Ballots:          int32(choiceInfo.Ballots),
+			PendingFollowUps: followUpsToProto(pending),
+			Comment:          commentToProto(comment),
+			Comments:         commentsToProto(choiceInfo.Comments),
+			LastChange:       lastChange,
 		},
 	}, nil
 }
 
+func (h *GRPCHandler) GetRankedInfo(ctx context.Context, request *proto.GetVoteInfoRequest) (*proto.GetRankedInfoResponse, error) {
+	h.logger.Debug("Received GetRankedInfo request", slog.Any("request", request))
+
+	rankings, err := h.storage.GetUserRankings(ctx, h.voter(ctx))
+	if err != nil {
+		return nil, h.handleStorageError(err, "rankings")
+	}
+	var ranking []int32
+	for _, r := range rankings {
+		if int32(r.ID) == request.VoteId {
+			for _, id := range r.OptionIDs {
+				ranking = append(ranking, int32(id))
+			}
+		}
+	}
+
+	rankedInfo, err := h.storage.GetRankedInfo(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "fetching ranked info")
+	}
+
+	return &proto.GetRankedInfoResponse{
+		Response: &proto.RankedInfo{
+			Id:           int32(rankedInfo.ID),
+			Category:     rankedInfo.Category,
+			Name:         rankedInfo.Name,
+			Description:  rankedInfo.Description,
+			Organization: rankedInfo.Organization,
+			End:          timestamppb.New(rankedInfo.EndTime),
+			Photo:        rankedInfo.Photo,
+			Options:      optionsToProto(rankedInfo.Options),
+			Ballots:      int32(rankedInfo.Ballots),
+			Rounds:       roundsToProto(rankedInfo.Options, rankedInfo.Result.Rounds),
+			WinnerId:     int32(rankedInfo.Result.Winner),
+			Ranking:      ranking,
+		},
+	}, nil
+}
+
+func (h *GRPCHandler) GetAllocationInfo(ctx context.Context, request *proto.GetVoteInfoRequest) (*proto.GetAllocationInfoResponse, error) {
+	h.logger.Debug("Received GetAllocationInfo request", slog.Any("request", request))
+
+	allocations, err := h.storage.GetUserAllocations(ctx, h.voter(ctx))
+	if err != nil {
+		return nil, h.handleStorageError(err, "allocations")
+	}
+	var uallocation []storage.Allocation
+	for _, a := range allocations {
+		if int32(a.ID) == request.VoteId {
+			uallocation = a.Allocations
+		}
+	}
+
+	allocationInfo, err := h.storage.GetAllocationInfo(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "fetching allocation info")
+	}
+
+	return &proto.GetAllocationInfoResponse{
+		Response: &proto.AllocationInfo{
+			Id:           int32(allocationInfo.ID),
+			Category:     allocationInfo.Category,
+			Name:         allocationInfo.Name,
+			Description:  allocationInfo.Description,
+			Organization: allocationInfo.Organization,
+			End:          timestamppb.New(allocationInfo.EndTime),
+			Photo:        allocationInfo.Photo,
+			Options:      optionsToProto(allocationInfo.Options),
+			Budget:       int32(allocationInfo.Budget),
+			Ballots:      int32(allocationInfo.Ballots),
+			Results:      allocationResultsToProto(allocationInfo.Results),
+			Allocations:  allocationsToProto(uallocation),
+			Spent:        int32(storage.AllocationCost(allocationInfo.Category, uallocation)),
+		},
+	}, nil
+}
+
+func (h *GRPCHandler) GetSurveyInfo(ctx context.Context, request *proto.GetVoteInfoRequest) (*proto.GetSurveyInfoResponse, error) {
+	h.logger.Debug("Received GetSurveyInfo request", slog.Any("request", request))
+
+	response, err := h.storage.GetSurveyResponse(ctx, h.voter(ctx), int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "survey response")
+	}
+
+	surveyInfo, err := h.storage.GetSurveyInfo(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "fetching survey info")
+	}
+
+	info := &proto.SurveyInfo{
+		Id:           int32(surveyInfo.ID),
+		Category:     surveyInfo.Category,
+		Name:         surveyInfo.Name,
+		Description:  surveyInfo.Description,
+		Organization: surveyInfo.Organization,
+		End:          timestamppb.New(surveyInfo.EndTime),
+		Photo:        surveyInfo.Photo,
+		Questions:    questionsToProto(surveyInfo.Questions),
+		Responses:    int32(surveyInfo.Responses),
+		Results:      questionResultsToProto(surveyInfo.Questions, surveyInfo.Results),
+	}
+	if response != nil {
+		info.Answers = answersToProto(response.Answers)
+		info.Submitted = response.Submitted
+		info.Updated = timestamppb.New(response.UpdatedAt)
+	}
+	return &proto.GetSurveyInfoResponse{Response: info}, nil
+}
+
 func (h *GRPCHandler) VoteRate(ctx context.Context, request *proto.VoteRateRequest) (*proto.VoteResponse, error) {
 	h.logger.Debug("Received VoteRate request", slog.Any("request", request))
 
-	err := h.storage.VoteRate(ctx, request.Token, int(request.VoteId), int(request.Rating))
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Rating != float32(math.Trunc(float64(request.Rating))) {
+		return nil, invalidRequest("Rating must be a whole number", map[string]string{"field": "rating"})
+	}
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting rate")
+	}
+	if err := storage.ValidateRating(vote, int(request.Rating)); err != nil {
+		return nil, h.handleStorageError(err, "voting rate")
+	}
+	comment := h.ballotComment(request.Comment)
+	if err := storage.ValidateComment(vote, comment); err != nil {
+		return nil, h.handleStorageError(err, "voting rate")
+	}
+
+	err = h.storage.VoteRate(ctx, voterId, int(request.VoteId), int(request.Rating), comment)
 	if err != nil {
 		return nil, h.handleStorageError(err, "voting rate")
 	}
 
-	h.logger.Info("Successfully recorded rate vote", slog.String("token", request.Token), slog.Int("vote_id", int(request.VoteId)))
+	h.logger.Info("Successfully recorded rate vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
 	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
 }
 
 func (h *GRPCHandler) VotePetition(ctx context.Context, request *proto.VotePetitionRequest) (*proto.VoteResponse, error) {
 	h.logger.Debug("Received VotePetition request", slog.Any("request", request))
 
-	err := h.storage.VotePetition(ctx, request.Token, int(request.VoteId), request.Support)
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.ValidateSupport(request.Support); err != nil {
+		return nil, h.handleStorageError(err, "voting petition")
+	}
+
+	err = h.storage.VotePetition(ctx, voterId, int(request.VoteId), request.Support)
 	if err != nil {
 		return nil, h.handleStorageError(err, "voting petition")
 	}
 
-	h.logger.Info("Successfully recorded petition vote", slog.String("token", request.Token), slog.Int("vote_id", int(request.VoteId)))
+	h.logger.Info("Successfully recorded petition vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
 	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
 }
 
+// CreatePetition stores a petition submitted by a citizen for moderation.
+// Text flagged by the filter is stored as rejected right away so that the
+// author can see why.
+func (h *GRPCHandler) CreatePetition(ctx context.Context, request *proto.CreatePetitionRequest) (*proto.CreatePetitionResponse, error) {
+	h.logger.Debug("Received CreatePetition request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	petition := &storage.Vote{
+		Category:       "petition",
+		Name:           strings.TrimSpace(request.Name),
+		Description:    strings.TrimSpace(request.Description),
+		Organization:   strings.TrimSpace(request.Organization),
+		OrganizationID: int(request.OrganizationId),
+		Topics:         request.Topics,
+		Photo:          request.Photo,
+		Author:         voterId,
+		SignatureGoal:  h.cfg.PetitionSignatureGoal,
+	}
+	if request.End != nil {
+		petition.EndTime = request.End.AsTime()
+	}
+	if err := storage.ValidatePetition(petition, h.clock.Now()); err != nil {
+		return nil, invalidRequest("Invalid petition: "+err.Error(), nil)
+	}
+
+	petition.Status = storage.StatusModeration
+	if verdict := h.filter.Check(petition.Name + "\n" + petition.Description); verdict.Flagged {
+		h.logger.Info("Petition rejected by filter", slog.String("reason", verdict.Reason), slog.Int("matches", len(verdict.Matches)))
+		petition.Status = storage.StatusRejected
+		petition.ReviewReason = verdict.Reason
+	}
+
+	created, err := h.storage.CreatePetition(ctx, petition)
+	if err != nil {
+		return nil, h.handleStorageError(err, "submitting petition")
+	}
+
+	h.logger.Info("Petition submitted", slog.Int("vote_id", created.ID), slog.String("status", created.Status))
+	return &proto.CreatePetitionResponse{
+		VoteId:        int32(created.ID),
+		Status:        created.Status,
+		Reason:        created.ReviewReason,
+		SignatureGoal: int32(created.SignatureGoal),
+	}, nil
+}
+
 func (h *GRPCHandler) VoteChoice(ctx context.Context, request *proto.VoteChoiceRequest) (*proto.VoteResponse, error) {
 	h.logger.Debug("Received VoteChoice request", slog.Any("request", request))
 
-	err := h.storage.VoteChoice(ctx, request.Token, int(request.VoteId), request.Choice)
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting choice")
+	}
+	optionIds := make([]int, 0, len(request.OptionIds))
+	for _, id := range request.OptionIds {
+		optionIds = append(optionIds, int(id))
+	}
+	if len(optionIds) == 0 {
+		optionId, err := storage.FindOption(vote, int(request.OptionId), request.Choice)
+		if err != nil {
+			return nil, h.handleStorageError(err, "voting choice")
+		}
+		optionIds = append(optionIds, optionId)
+	}
+	if err := storage.ValidateChoice(vote, optionIds); err != nil {
+		return nil, h.handleStorageError(err, "voting choice")
+	}
+	comment := h.ballotComment(request.Comment)
+	if err := storage.ValidateComment(vote, comment); err != nil {
+		return nil, h.handleStorageError(err, "voting choice")
+	}
+
+	err = h.storage.VoteChoice(ctx, voterId, int(request.VoteId), optionIds, comment)
 	if err != nil {
 		return nil, h.handleStorageError(err, "voting choice")
 	}
 
-	h.logger.Info("Successfully recorded choice vote", slog.String("token", request.Token), slog.Int("vote_id", int(request.VoteId)))
+	h.logger.Info("Successfully recorded choice vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
 	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
 }
 
+func (h *GRPCHandler) VoteRanked(ctx context.Context, request *proto.VoteRankedRequest) (*proto.VoteResponse, error) {
+	h.logger.Debug("Received VoteRanked request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	optionIds := make([]int, 0, len(request.OptionIds))
+	for _, id := range request.OptionIds {
+		optionIds = append(optionIds, int(id))
+	}
+
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting ranked")
+	}
+	if err := storage.ValidateRanking(vote, optionIds); err != nil {
+		return nil, h.handleStorageError(err, "voting ranked")
+	}
+
+	err = h.storage.VoteRanked(ctx, voterId, int(request.VoteId), optionIds)
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting ranked")
+	}
+
+	h.logger.Info("Successfully recorded ranked vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
+}
+
+func (h *GRPCHandler) VoteAllocation(ctx context.Context, request *proto.VoteAllocationRequest) (*proto.VoteResponse, error) {
+	h.logger.Debug("Received VoteAllocation request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make([]storage.Allocation, 0, len(request.Allocations))
+	for _, a := range request.Allocations {
+		allocations = append(allocations, storage.Allocation{OptionID: int(a.GetOptionId()), Points: int(a.GetPoints())})
+	}
+
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting allocation")
+	}
+	if err := storage.ValidateAllocation(vote, allocations); err != nil {
+		return nil, h.handleStorageError(err, "voting allocation")
+	}
+
+	err = h.storage.VoteAllocation(ctx, voterId, int(request.VoteId), allocations)
+	if err != nil {
+		return nil, h.handleStorageError(err, "voting allocation")
+	}
+
+	h.logger.Info("Successfully recorded allocation vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
+}
+
+func (h *GRPCHandler) SubmitSurvey(ctx context.Context, request *proto.SubmitSurveyRequest) (*proto.VoteResponse, error) {
+	h.logger.Debug("Received SubmitSurvey request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := answersFromProto(request.Answers)
+
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "submitting survey")
+	}
+	if err := storage.ValidateSurvey(vote, answers, request.Draft); err != nil {
+		return nil, h.handleStorageError(err, "submitting survey")
+	}
+
+	err = h.storage.SubmitSurvey(ctx, voterId, int(request.VoteId), answers, request.Draft)
+	if err != nil {
+		return nil, h.handleStorageError(err, "submitting survey")
+	}
+
+	if request.Draft {
+		h.logger.Info("Saved survey draft", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+		return &proto.VoteResponse{Response: "Draft saved successfully"}, nil
+	}
+	h.logger.Info("Successfully recorded survey response", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+	return &proto.VoteResponse{Response: "Vote recorded successfully"}, nil
+}
+
+func (h *GRPCHandler) AnswerFollowUps(ctx context.Context, request *proto.AnswerFollowUpsRequest) (*proto.VoteResponse, error) {
+	h.logger.Debug("Received AnswerFollowUps request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := answersFromProto(request.Answers)
+
+	vote, err := h.storage.GetVote(ctx, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "answering follow-ups")
+	}
+	if err := storage.ValidateFollowUpAnswers(vote, answers); err != nil {
+		return nil, h.handleStorageError(err, "answering follow-ups")
+	}
+
+	err = h.storage.AnswerFollowUps(ctx, voterId, int(request.VoteId), answers)
+	if err != nil {
+		return nil, h.handleStorageError(err, "answering follow-ups")
+	}
+
+	h.logger.Info("Successfully recorded follow-up answers", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+	return &proto.VoteResponse{Response: "Answers recorded successfully"}, nil
+}
+
+// WithdrawVote takes back the caller's ballot while the vote accepts
+// ballots, so that it no longer counts in the results.
+func (h *GRPCHandler) WithdrawVote(ctx context.Context, request *proto.WithdrawVoteRequest) (*proto.VoteResponse, error) {
+	h.logger.Debug("Received WithdrawVote request", slog.Any("request", request))
+
+	voterId, err := h.requireVoter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.storage.WithdrawVote(ctx, voterId, int(request.VoteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "withdrawing vote")
+	}
+
+	h.logger.Info("Successfully withdrew vote", slog.String("voter_id", voterId), slog.Int("vote_id", int(request.VoteId)))
+	return &proto.VoteResponse{Response: "Vote withdrawn successfully"}, nil
+}
+
+// lastBallotChange returns the latest change of the caller's ballot in a
+// vote, or nil for an anonymous caller or one who never voted.
+func (h *GRPCHandler) lastBallotChange(ctx context.Context, voteId int32) (*proto.BallotChange, error) {
+	voterId := h.voter(ctx)
+	if voterId == "" {
+		return nil, nil
+	}
+	history, err := h.storage.GetBallotHistory(ctx, voterId, int(voteId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "ballot history")
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	return &proto.BallotChange{Action: history[0].Action, Changed: timestamppb.New(history[0].ChangedAt)}, nil
+}
+
 func (h *GRPCHandler) HealthCheck(ctx context.Context, request *proto.HealthCheckRequest) (*proto.HealthCheckResponse, error) {
 	h.logger.Debug("Received HealthCheck request")
 
@@ -261,6 +728,80 @@ func (h *GRPCHandler) HealthCheck(ctx context.Context, request *proto.HealthChec
 }
 
 func (h *GRPCHandler) handleStorageError(err error, context string) error {
-	h.logger.Error("Storage operation failed", slog.String("context", context), slog.String("error", err.Error()))
-	return status.Errorf(codes.Internal, "Failed to process %s: %v", context, err)
+	return storageStatus(h.logger, err, context)
+}
+
+func voteSummaryToProto(vote *storage.Vote) *proto.Vote {
+	return &proto.Vote{
+		Id:             int32(vote.ID),
+		Category:       vote.Category,
+		Name:           vote.Name,
+		Description:    vote.Description,
+		Organization:   vote.Organization,
+		OrganizationId: int32(vote.OrganizationID),
+		Topics:         vote.Topics,
+		End:            timestamppb.New(vote.EndTime),
+		Photo:          vote.Photo,
+		Options:        storage.OptionTexts(vote.Options),
+		OptionDetails:  optionsToProto(vote.Options),
+		Status:         vote.Status,
+		MinSelections:  int32(vote.Selection.Min),
+		MaxSelections:  int32(vote.Selection.Max),
+		Budget:         int32(vote.Budget),
+	}
+}
+
+// roundsToProto lists the tallies of each round in display order.
+func roundsToProto(options []storage.VoteOption, rounds []tally.Round) []*proto.RankedRound {
+	protoRounds := make([]*proto.RankedRound, 0, len(rounds))
+	for _, round := range rounds {
+		protoRound := &proto.RankedRound{
+			Number:    int32(round.Number),
+			Exhausted: int32(round.Exhausted),
+			WinnerId:  int32(round.Winner),
+		}
+		for _, option := range options {
+			if votes, ok := round.Votes[option.ID]; ok {
+				protoRound.Tallies = append(protoRound.Tallies, &proto.OptionTally{OptionId: int32(option.ID), Votes: int32(votes)})
+			}
+		}
+		for _, id := range round.Eliminated {
+			protoRound.Eliminated = append(protoRound.Eliminated, int32(id))
+		}
+		protoRounds = append(protoRounds, protoRound)
+	}
+	return protoRounds
+}
+
+func allocationsToProto(allocations []storage.Allocation) []*proto.Allocation {
+	protoAllocations := make([]*proto.Allocation, 0, len(allocations))
+	for _, a := range allocations {
+		protoAllocations = append(protoAllocations, &proto.Allocation{OptionId: int32(a.OptionID), Points: int32(a.Points)})
+	}
+	return protoAllocations
+}
+
+func allocationResultsToProto(results []storage.OptionAllocation) []*proto.OptionAllocation {
+	protoResults := make([]*proto.OptionAllocation, 0, len(results))
+	for _, result := range results {
+		protoResult := &proto.OptionAllocation{
+			OptionId:   int32(result.OptionID),
+			Total:      int32(result.Total),
+			Credits:    int32(result.Credits),
+			Supporters: int32(result.Supporters),
+		}
+		amounts := make([]int, 0, len(result.Distribution))
+		for points := range result.Distribution {
+			amounts = append(amounts, points)
+		}
+		sort.Ints(amounts)
+		for _, points := range amounts {
+			protoResult.Distribution = append(protoResult.Distribution, &proto.AllocationCount{
+				Points:  int32(points),
+				Ballots: int32(result.Distribution[points]),
+			})
+		}
+		protoResults = append(protoResults, protoResult)
+	}
+	return protoResults
 }
@@ -0,0 +1,491 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/config"
+	"github.com/GP-Hacks/kdt2024-votes/internal/access"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"github.com/GP-Hacks/kdt2024-votes/internal/moderation"
+	"github.com/GP-Hacks/kdt2024-votes/internal/pseudonym"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type testVotes struct {
+	rate, choice, petition, closed, scheduled int
+	options                                   []int
+}
+
+func newTestHandler(t *testing.T) (*GRPCHandler, *storage.MemoryStorage, testVotes) {
+	t.Helper()
+
+	s := storage.NewMemoryStorage(storage.WithClock(clock.NewFake(testNow)))
+	voters, err := pseudonym.New("test pepper of sixteen bytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &GRPCHandler{
+		cfg:     &config.Config{},
+		storage: s,
+		filter:  moderation.Russian(),
+		voters:  voters,
+		clock:   clock.NewFake(testNow),
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	ctx := context.Background()
+	end := testNow.Add(24 * time.Hour)
+	create := func(vote storage.Vote) *storage.Vote {
+		t.Helper()
+		vote.EndTime = end
+		if err := storage.ValidateVote(&vote, testNow); err != nil {
+			t.Fatalf("vote %q: %v", vote.Name, err)
+		}
+		created, err := s.CreateVote(ctx, &vote)
+		if err != nil {
+			t.Fatalf("vote %q: %v", vote.Name, err)
+		}
+		return created
+	}
+
+	var votes testVotes
+	votes.rate = create(storage.Vote{Name: "Park", Category: "rate", Status: storage.StatusOpen}).ID
+	choice := create(storage.Vote{Name: "Bridge", Category: "choice", Status: storage.StatusOpen,
+		Options: []storage.VoteOption{{Text: "Stone"}, {Text: "Steel"}}})
+	votes.choice = choice.ID
+	for _, option := range choice.Options {
+		votes.options = append(votes.options, option.ID)
+	}
+	votes.petition = create(storage.Vote{Name: "Library hours", Category: "petition", Status: storage.StatusOpen, SignatureGoal: 100}).ID
+	votes.closed = create(storage.Vote{Name: "Square", Category: "rate", Status: storage.StatusOpen}).ID
+	if _, err := s.SetVoteStatus(ctx, votes.closed, storage.StatusClosed); err != nil {
+		t.Fatal(err)
+	}
+	votes.scheduled = create(storage.Vote{Name: "Pool", Category: "rate", Status: storage.StatusScheduled,
+		StartTime: testNow.Add(time.Hour)}).ID
+	return h, s, votes
+}
+
+func asUser(userId string) context.Context {
+	return auth.WithUser(context.Background(), userId)
+}
+
+// errorReason returns the gRPC code of err and the reason of its ErrorInfo.
+func errorReason(t *testing.T, err error) (codes.Code, string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("%v is not a gRPC status", err)
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st.Code(), info.Reason
+		}
+	}
+	return st.Code(), ""
+}
+
+func TestGetVotes(t *testing.T) {
+	h, _, _ := newTestHandler(t)
+
+	tests := []struct {
+		category string
+		want     int
+	}{
+		{"", 4},
+		{"all", 4},
+		{"rate", 2},
+		{"choice", 1},
+		{"petition", 1},
+		{"survey", 0},
+	}
+	for _, tt := range tests {
+		t.Run("category="+tt.category, func(t *testing.T) {
+			resp, err := h.GetVotes(context.Background(), &proto.GetVotesRequest{Category: tt.category})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(resp.Response); got != tt.want {
+				t.Errorf("got %d votes, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVoteInfoCounts(t *testing.T) {
+	h, _, votes := newTestHandler(t)
+
+	for user, rating := range map[string]float32{"alice": 5, "bob": 2, "carol": 2} {
+		if _, err := h.VoteRate(asUser(user), &proto.VoteRateRequest{VoteId: int32(votes.rate), Rating: rating}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for user, option := range map[string]int{"alice": 0, "bob": 1, "carol": 1} {
+		request := &proto.VoteChoiceRequest{VoteId: int32(votes.choice), OptionIds: []int32{int32(votes.options[option])}}
+		if _, err := h.VoteChoice(asUser(user), request); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for user, support := range map[string]string{"alice": "for", "bob": "for", "carol": "against"} {
+		if _, err := h.VotePetition(asUser(user), &proto.VotePetitionRequest{VoteId: int32(votes.petition), Support: support}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("rate", func(t *testing.T) {
+		resp, err := h.GetRateInfo(asUser("alice"), &proto.GetVoteInfoRequest{VoteId: int32(votes.rate)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Response.Mid != 3 || resp.Response.Rate != 5 {
+			t.Errorf("got mid %v and rate %v, want 3 and 5", resp.Response.Mid, resp.Response.Rate)
+		}
+	})
+	t.Run("choice", func(t *testing.T) {
+		resp, err := h.GetChoiceInfo(asUser("bob"), &proto.GetVoteInfoRequest{VoteId: int32(votes.choice)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := resp.Response
+		if info.Ballots != 3 || info.Stats["Stone"] != 1 || info.Stats["Steel"] != 2 || info.Choice != "Steel" {
+			t.Errorf("got %d ballots, stats %v and choice %q", info.Ballots, info.Stats, info.Choice)
+		}
+	})
+	t.Run("petition", func(t *testing.T) {
+		resp, err := h.GetPetitionInfo(asUser("carol"), &proto.GetVoteInfoRequest{VoteId: int32(votes.petition)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := resp.Response
+		if info.Signatures != 2 || info.Stats["against"] != 1 || info.Support != "against" {
+			t.Errorf("got %d signatures, stats %v and support %q", info.Signatures, info.Stats, info.Support)
+		}
+	})
+	t.Run("anonymous", func(t *testing.T) {
+		resp, err := h.GetRateInfo(context.Background(), &proto.GetVoteInfoRequest{VoteId: int32(votes.rate)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Response.Rate != 0 || resp.Response.LastChange != nil {
+			t.Errorf("anonymous caller sees rate %v and change %v", resp.Response.Rate, resp.Response.LastChange)
+		}
+	})
+}
+
+// TestDoubleVote checks that a second ballot of the same user replaces the
+// first one instead of being counted again.
+func TestDoubleVote(t *testing.T) {
+	tests := []struct {
+		name  string
+		vote  func(h *GRPCHandler, votes testVotes, second bool) error
+		check func(h *GRPCHandler, votes testVotes) error
+	}{
+		{
+			name: "rate",
+			vote: func(h *GRPCHandler, votes testVotes, second bool) error {
+				rating := float32(1)
+				if second {
+					rating = 4
+				}
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.rate), Rating: rating})
+				return err
+			},
+			check: func(h *GRPCHandler, votes testVotes) error {
+				resp, err := h.GetRateInfo(asUser("alice"), &proto.GetVoteInfoRequest{VoteId: int32(votes.rate)})
+				if err != nil {
+					return err
+				}
+				if resp.Response.Mid != 4 || resp.Response.LastChange.GetAction() != storage.BallotChanged {
+					return fmt.Errorf("got mid %v and last change %q", resp.Response.Mid, resp.Response.LastChange.GetAction())
+				}
+				return nil
+			},
+		},
+		{
+			name: "choice",
+			vote: func(h *GRPCHandler, votes testVotes, second bool) error {
+				option := votes.options[0]
+				if second {
+					option = votes.options[1]
+				}
+				request := &proto.VoteChoiceRequest{VoteId: int32(votes.choice), OptionIds: []int32{int32(option)}}
+				_, err := h.VoteChoice(asUser("alice"), request)
+				return err
+			},
+			check: func(h *GRPCHandler, votes testVotes) error {
+				resp, err := h.GetChoiceInfo(asUser("alice"), &proto.GetVoteInfoRequest{VoteId: int32(votes.choice)})
+				if err != nil {
+					return err
+				}
+				if info := resp.Response; info.Ballots != 1 || info.Stats["Stone"] != 0 || info.Stats["Steel"] != 1 {
+					return fmt.Errorf("got %d ballots and stats %v", info.Ballots, info.Stats)
+				}
+				return nil
+			},
+		},
+		{
+			name: "petition",
+			vote: func(h *GRPCHandler, votes testVotes, second bool) error {
+				_, err := h.VotePetition(asUser("alice"), &proto.VotePetitionRequest{VoteId: int32(votes.petition), Support: "for"})
+				return err
+			},
+			check: func(h *GRPCHandler, votes testVotes) error {
+				resp, err := h.GetPetitionInfo(asUser("alice"), &proto.GetVoteInfoRequest{VoteId: int32(votes.petition)})
+				if err != nil {
+					return err
+				}
+				if info := resp.Response; info.Signatures != 1 || info.LastChange.GetAction() != storage.BallotCast {
+					return fmt.Errorf("got %d signatures and last change %q", info.Signatures, info.LastChange.GetAction())
+				}
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, votes := newTestHandler(t)
+			if err := tt.vote(h, votes, false); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.vote(h, votes, true); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.check(h, votes); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestBallotErrors(t *testing.T) {
+	h, _, votes := newTestHandler(t)
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{
+			name: "anonymous",
+			call: func() error {
+				_, err := h.VoteRate(context.Background(), &proto.VoteRateRequest{VoteId: int32(votes.rate), Rating: 3})
+				return err
+			},
+			code: codes.Unauthenticated, reason: ReasonUnauthenticated,
+		},
+		{
+			name: "unknown vote",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: 999, Rating: 3})
+				return err
+			},
+			code: codes.NotFound, reason: ReasonVoteNotFound,
+		},
+		{
+			name: "wrong type",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.choice), Rating: 3})
+				return err
+			},
+			code: codes.InvalidArgument, reason: ReasonWrongVoteType,
+		},
+		{
+			name: "off the scale",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.rate), Rating: 6})
+				return err
+			},
+			code: codes.InvalidArgument, reason: ReasonInvalidBallot,
+		},
+		{
+			name: "fractional rating",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.rate), Rating: 2.5})
+				return err
+			},
+			code: codes.InvalidArgument, reason: ReasonInvalidRequest,
+		},
+		{
+			name: "closed",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.closed), Rating: 3})
+				return err
+			},
+			code: codes.FailedPrecondition, reason: ReasonVoteClosed,
+		},
+		{
+			name: "not started",
+			call: func() error {
+				_, err := h.VoteRate(asUser("alice"), &proto.VoteRateRequest{VoteId: int32(votes.scheduled), Rating: 3})
+				return err
+			},
+			code: codes.FailedPrecondition, reason: ReasonVoteNotStarted,
+		},
+		{
+			name: "unknown option",
+			call: func() error {
+				_, err := h.VoteChoice(asUser("alice"), &proto.VoteChoiceRequest{VoteId: int32(votes.choice), OptionIds: []int32{999}})
+				return err
+			},
+			code: codes.InvalidArgument, reason: ReasonInvalidOption,
+		},
+		{
+			name: "unknown support",
+			call: func() error {
+				_, err := h.VotePetition(asUser("alice"), &proto.VotePetitionRequest{VoteId: int32(votes.petition), Support: "maybe"})
+				return err
+			},
+			code: codes.InvalidArgument, reason: ReasonInvalidBallot,
+		},
+		{
+			name: "nothing to withdraw",
+			call: func() error {
+				_, err := h.WithdrawVote(asUser("alice"), &proto.WithdrawVoteRequest{VoteId: int32(votes.rate)})
+				return err
+			},
+			code: codes.NotFound, reason: ReasonBallotNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, reason := errorReason(t, tt.call())
+			if code != tt.code || reason != tt.reason {
+				t.Errorf("got %s %s, want %s %s", code, reason, tt.code, tt.reason)
+			}
+		})
+	}
+}
+
+func TestStorageStatus(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{fmt.Errorf("op: %w", storage.ErrNotFound), codes.NotFound, ReasonVoteNotFound},
+		{fmt.Errorf("op: %w", storage.ErrCommentNotFound), codes.NotFound, ReasonCommentNotFound},
+		{fmt.Errorf("op: %w", storage.ErrOrganizationNotFound), codes.NotFound, ReasonOrganizationNotFound},
+		{fmt.Errorf("op: %w", storage.ErrTopicNotFound), codes.NotFound, ReasonTopicNotFound},
+		{fmt.Errorf("op: %w", storage.ErrRoleNotFound), codes.NotFound, ReasonRoleNotFound},
+		{fmt.Errorf("op: %w", storage.ErrBallotNotFound), codes.NotFound, ReasonBallotNotFound},
+		{fmt.Errorf("op: %w", storage.ErrWrongVoteType), codes.InvalidArgument, ReasonWrongVoteType},
+		{fmt.Errorf("op: %w", storage.ErrVoteClosed), codes.FailedPrecondition, ReasonVoteClosed},
+		{fmt.Errorf("op: %w", storage.ErrVoteNotStarted), codes.FailedPrecondition, ReasonVoteNotStarted},
+		{fmt.Errorf("op: %w", storage.ErrInvalidOption), codes.InvalidArgument, ReasonInvalidOption},
+		{fmt.Errorf("op: %w", storage.ErrInvalidQuestion), codes.InvalidArgument, ReasonInvalidQuestion},
+		{fmt.Errorf("op: %w", storage.ErrInvalidBallot), codes.InvalidArgument, ReasonInvalidBallot},
+		{fmt.Errorf("op: %w", storage.ErrConflict), codes.AlreadyExists, ReasonConflict},
+		{fmt.Errorf("op: %w", storage.ErrInvalidTransition), codes.FailedPrecondition, ReasonInvalidTransition},
+		{fmt.Errorf("op: %w", storage.ErrRulesLocked), codes.FailedPrecondition, ReasonRulesLocked},
+		{fmt.Errorf("op: %w", storage.ErrVoteHasBallots), codes.FailedPrecondition, ReasonVoteHasBallots},
+		{fmt.Errorf("op: %w", context.Canceled), codes.Canceled, ""},
+		{fmt.Errorf("op: %w", context.DeadlineExceeded), codes.DeadlineExceeded, ""},
+		{errors.New("connection refused"), codes.Internal, ReasonInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			err := storageStatus(logger, tt.err, "test")
+			code, reason := errorReason(t, err)
+			if code != tt.code || reason != tt.reason {
+				t.Errorf("got %s %s, want %s %s", code, reason, tt.code, tt.reason)
+			}
+			if tt.code == codes.Internal && status.Convert(err).Message() != "Failed to process test" {
+				t.Errorf("internal error leaks its cause: %q", status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestAccessStatusHidesUser(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	denied := &access.DeniedError{Method: "/votes.VotesAdminService/UpdateVote", UserID: "user-1",
+		Roles: []string{storage.RoleAdmin, storage.RoleEditor}, OrganizationID: 3}
+
+	err := accessStatus(logger, fmt.Errorf("authorize: %w", denied))
+	if code, reason := errorReason(t, err); code != codes.PermissionDenied || reason != ReasonPermissionDenied {
+		t.Fatalf("got %s %s, want PermissionDenied %s", code, reason, ReasonPermissionDenied)
+	}
+	st := status.Convert(err)
+	if strings.Contains(st.Message(), "user-1") {
+		t.Errorf("message leaks the user ID: %q", st.Message())
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		for key, value := range info.GetMetadata() {
+			if strings.Contains(value, "user-1") {
+				t.Errorf("metadata %s leaks the user ID: %q", key, value)
+			}
+		}
+		if info.GetMetadata()["organization_id"] != "3" {
+			t.Errorf("got metadata %v, want organization_id 3", info.GetMetadata())
+		}
+	}
+
+	if code, _ := errorReason(t, accessStatus(logger, access.ErrUnauthenticated)); code != codes.Unauthenticated {
+		t.Errorf("got %s for an anonymous caller, want Unauthenticated", code)
+	}
+}
+
+func TestPolicyOrganizationVerification(t *testing.T) {
+	_, s, _ := newTestHandler(t)
+	ctx := context.Background()
+	organization, err := s.CreateOrganization(ctx, &storage.Organization{Name: "City parks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.GrantRole(ctx, &storage.RoleAssignment{UserID: "editor", Role: storage.RoleEditor, OrganizationID: organization.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := NewPolicy(s, []string{"admin"})
+
+	tests := []struct {
+		name     string
+		user     string
+		verified bool
+		allowed  bool
+	}{
+		{"editor renames", "editor", false, true},
+		{"editor verifies", "editor", true, false},
+		{"admin verifies", "admin", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &proto.UpdateOrganizationRequest{Organization: &proto.Organization{
+				Id: int32(organization.ID), Name: "City parks department", Verified: tt.verified}}
+			_, err := policy.Authorize(asUser(tt.user), proto.VotesAdminService_UpdateOrganization_FullMethodName, request)
+			if tt.allowed {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var denied *access.DeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("got error %v, want a *access.DeniedError", err)
+			}
+			if len(denied.Roles) != 1 || denied.Roles[0] != storage.RoleAdmin {
+				t.Errorf("got roles %q, want admin only", denied.Roles)
+			}
+		})
+	}
+}
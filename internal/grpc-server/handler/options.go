@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+)
+
+func optionsToProto(options []storage.VoteOption) []*proto.Option {
+	protoOptions := make([]*proto.Option, 0, len(options))
+	for _, option := range options {
+		protoOptions = append(protoOptions, &proto.Option{
+			Id:          int32(option.ID),
+			Text:        option.Text,
+			Description: option.Description,
+			Image:       option.Image,
+			Position:    int32(option.Position),
+		})
+	}
+	return protoOptions
+}
+
+// optionsFromProto prefers the detailed options; plain texts are accepted
+// from clients that only know the original string list. Display order is
+// the order of the list, positions sent by the client are ignored.
+func optionsFromProto(details []*proto.Option, texts []string) []storage.VoteOption {
+	var options []storage.VoteOption
+	if len(details) != 0 {
+		for i, option := range details {
+			options = append(options, storage.VoteOption{
+				ID:          int(option.GetId()),
+				Text:        option.GetText(),
+				Description: option.GetDescription(),
+				Image:       option.GetImage(),
+				Position:    i,
+			})
+		}
+		return options
+	}
+	for i, text := range texts {
+		options = append(options, storage.VoteOption{Text: text, Position: i})
+	}
+	return options
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"log/slog"
+)
+
+func (h *GRPCHandler) ListOrganizations(ctx context.Context, request *proto.ListOrganizationsRequest) (*proto.ListOrganizationsResponse, error) {
+	h.logger.Debug("Received ListOrganizations request", slog.Any("request", request))
+
+	organizations, err := h.storage.ListOrganizations(ctx)
+	if err != nil {
+		return nil, h.handleStorageError(err, "organizations")
+	}
+
+	protoOrganizations := make([]*proto.Organization, 0, len(organizations))
+	for i := range organizations {
+		protoOrganizations = append(protoOrganizations, organizationToProto(&organizations[i]))
+	}
+	return &proto.ListOrganizationsResponse{Response: protoOrganizations}, nil
+}
+
+func (h *GRPCHandler) GetOrganization(ctx context.Context, request *proto.GetOrganizationRequest) (*proto.GetOrganizationResponse, error) {
+	h.logger.Debug("Received GetOrganization request", slog.Any("request", request))
+
+	organization, err := h.storage.GetOrganization(ctx, int(request.OrganizationId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "organization")
+	}
+	return &proto.GetOrganizationResponse{Response: organizationToProto(organization)}, nil
+}
+
+func (h *AdminHandler) CreateOrganization(ctx context.Context, request *proto.CreateOrganizationRequest) (*proto.CreateOrganizationResponse, error) {
+	h.logger.Debug("Received CreateOrganization request", slog.Any("request", request))
+
+	organization := organizationFromProto(request.GetOrganization())
+	if err := storage.ValidateOrganization(organization); err != nil {
+		return nil, invalidRequest("Invalid organization: "+err.Error(), nil)
+	}
+
+	created, err := h.storage.CreateOrganization(ctx, organization)
+	if err != nil {
+		return nil, h.handleStorageError(err, "creating organization")
+	}
+
+	h.logger.Info("Organization created", slog.Int("organization_id", created.ID), slog.String("name", created.Name))
+	return &proto.CreateOrganizationResponse{Response: organizationToProto(created)}, nil
+}
+
+func (h *AdminHandler) UpdateOrganization(ctx context.Context, request *proto.UpdateOrganizationRequest) (*proto.UpdateOrganizationResponse, error) {
+	h.logger.Debug("Received UpdateOrganization request", slog.Any("request", request))
+
+	organization := organizationFromProto(request.GetOrganization())
+	if organization.ID <= 0 {
+		return nil, invalidRequest("Invalid organization: id is required", map[string]string{"field": "id"})
+	}
+	if err := storage.ValidateOrganization(organization); err != nil {
+		return nil, invalidRequest("Invalid organization: "+err.Error(), nil)
+	}
+
+	updated, err := h.storage.UpdateOrganization(ctx, organization)
+	if err != nil {
+		return nil, h.handleStorageError(err, "updating organization")
+	}
+
+	h.logger.Info("Organization updated", slog.Int("organization_id", updated.ID))
+	return &proto.UpdateOrganizationResponse{Response: organizationToProto(updated)}, nil
+}
+
+func organizationFromProto(organization *proto.Organization) *storage.Organization {
+	return &storage.Organization{
+		ID:          int(organization.GetId()),
+		Name:        organization.GetName(),
+		Logo:        organization.GetLogo(),
+		Description: organization.GetDescription(),
+		Contact:     organization.GetContact(),
+		Verified:    organization.GetVerified(),
+	}
+}
+
+func organizationToProto(organization *storage.Organization) *proto.Organization {
+	return &proto.Organization{
+		Id:          int32(organization.ID),
+		Name:        organization.Name,
+		Logo:        organization.Logo,
+		Description: organization.Description,
+		Contact:     organization.Contact,
+		Verified:    organization.Verified,
+		Votes:       int32(organization.Votes),
+	}
+}
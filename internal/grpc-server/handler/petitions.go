@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func petitionResponseToProto(response *storage.PetitionResponse) *proto.PetitionResponse {
+	if response == nil {
+		return nil
+	}
+	attachments := make([]*proto.Attachment, 0, len(response.Attachments))
+	for _, attachment := range response.Attachments {
+		attachments = append(attachments, &proto.Attachment{Name: attachment.Name, Url: attachment.URL})
+	}
+	return &proto.PetitionResponse{
+		Decision:     response.Decision,
+		Text:         response.Text,
+		Organization: response.Organization,
+		Attachments:  attachments,
+		Responded:    timestamppb.New(response.RespondedAt),
+	}
+}
+
+func attachmentsFromProto(attachments []*proto.Attachment) []storage.Attachment {
+	result := make([]storage.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, storage.Attachment{Name: attachment.GetName(), URL: attachment.GetUrl()})
+	}
+	return result
+}
+
+// optionalTimestamp leaves unset times out of a message.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/access"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// NewPolicy returns the access policy of both services. Every citizen RPC
+// is public; ballots still require a token, see requireVoter. Admin RPCs
+// without roles in their rule are for admins only. The users in admins are
+// admins without a role assignment.
+func NewPolicy(repository storage.Repository, admins []string) *access.Policy {
+	rules := make(map[string]access.Rule)
+	for _, method := range proto.VotesService_ServiceDesc.Methods {
+		rules["/"+proto.VotesService_ServiceDesc.ServiceName+"/"+method.MethodName] = access.Rule{Public: true}
+	}
+
+	voteScope := func(voteId func(req interface{}) int32) access.Scope {
+		return func(ctx context.Context, req interface{}) ([]int, error) {
+			vote, err := repository.GetVote(ctx, int(voteId(req)))
+			if err != nil {
+				return nil, err
+			}
+			return []int{vote.OrganizationID}, nil
+		}
+	}
+	staff := []string{storage.RoleModerator, storage.RoleAnalyst}
+
+	rules[proto.VotesAdminService_CreateVote_FullMethodName] = access.Rule{
+		Scope: func(ctx context.Context, req interface{}) ([]int, error) {
+			return []int{int(req.(*proto.CreateVoteRequest).GetVote().GetOrganizationId())}, nil
+		},
+	}
+	rules[proto.VotesAdminService_UpdateVote_FullMethodName] = access.Rule{
+		Scope: func(ctx context.Context, req interface{}) ([]int, error) {
+			vote := req.(*proto.UpdateVoteRequest).GetVote()
+			existing, err := repository.GetVote(ctx, int(vote.GetId()))
+			if err != nil {
+				return nil, err
+			}
+			return []int{existing.OrganizationID, int(vote.GetOrganizationId())}, nil
+		},
+	}
+	rules[proto.VotesAdminService_DeleteVote_FullMethodName] = access.Rule{
+		Scope: voteScope(func(req interface{}) int32 { return req.(*proto.DeleteVoteRequest).VoteId }),
+	}
+	rules[proto.VotesAdminService_SetVoteStatus_FullMethodName] = access.Rule{
+		Scope: voteScope(func(req interface{}) int32 { return req.(*proto.SetVoteStatusRequest).VoteId }),
+	}
+	rules[proto.VotesAdminService_PublishPetitionResponse_FullMethodName] = access.Rule{
+		Scope: voteScope(func(req interface{}) int32 { return req.(*proto.PublishPetitionResponseRequest).VoteId }),
+	}
+	// Editors update their organizations, but only admins verify them.
+	rules[proto.VotesAdminService_UpdateOrganization_FullMethodName] = access.Rule{
+		Scope: func(ctx context.Context, req interface{}) ([]int, error) {
+			return []int{int(req.(*proto.UpdateOrganizationRequest).GetOrganization().GetId())}, nil
+		},
+		AdminOnly: func(ctx context.Context, req interface{}) (bool, error) {
+			organization := req.(*proto.UpdateOrganizationRequest).GetOrganization()
+			existing, err := repository.GetOrganization(ctx, int(organization.GetId()))
+			if errors.Is(err, storage.ErrOrganizationNotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			return existing.Verified != organization.GetVerified(), nil
+		},
+	}
+
+	// Editors list only the votes of their organizations, see
+	// visibleVotes.
+	rules[proto.VotesAdminService_ListAllVotes_FullMethodName] = access.Rule{Roles: staff, Scope: anyOrganization}
+	rules[proto.VotesAdminService_ListOverduePetitions_FullMethodName] = access.Rule{Roles: staff, Scope: anyOrganization}
+
+	rules[proto.VotesAdminService_ListComments_FullMethodName] = access.Rule{Roles: []string{storage.RoleModerator}}
+	rules[proto.VotesAdminService_ReviewComment_FullMethodName] = access.Rule{Roles: []string{storage.RoleModerator}}
+	rules[proto.VotesAdminService_ReviewPetition_FullMethodName] = access.Rule{Roles: []string{storage.RoleModerator}}
+
+	return access.NewPolicy(rules, repository, admins)
+}
+
+func anyOrganization(ctx context.Context, req interface{}) ([]int, error) {
+	return nil, nil
+}
+
+// PolicyInterceptor consults policy before every RPC and passes the caller
+// on in the context, see access.FromContext, and as the actor of the audit
+// log. It has to run after AuthInterceptor. Refusals are logged and
+// reported as PermissionDenied with the method, the admitted roles and the
+// organization in the error details; the user ID is only logged.
+func PolicyInterceptor(policy *access.Policy, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		subject, err := policy.Authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, accessStatus(logger, err)
+		}
+		if subject != nil {
+			ctx = audit.WithActor(ctx, subject.UserID)
+		}
+		return handler(access.WithSubject(ctx, subject), req)
+	}
+}
+
+func accessStatus(logger *slog.Logger, err error) error {
+	if errors.Is(err, access.ErrUnauthenticated) {
+		return errorStatus(codes.Unauthenticated, ReasonUnauthenticated, "Token is required", nil)
+	}
+
+	var denied *access.DeniedError
+	if !errors.As(err, &denied) {
+		return storageStatus(logger, err, "authorization")
+	}
+	metadata := map[string]string{
+		"method":         denied.Method,
+		"required_roles": strings.Join(denied.Roles, ","),
+	}
+	if denied.OrganizationID != 0 {
+		metadata["organization_id"] = strconv.Itoa(denied.OrganizationID)
+	}
+	logger.Warn("Permission denied", slog.String("method", denied.Method), slog.String("user_id", denied.UserID),
+		slog.String("required_roles", metadata["required_roles"]), slog.Int("organization_id", denied.OrganizationID))
+	return errorStatus(codes.PermissionDenied, ReasonPermissionDenied, "Permission denied: "+denied.Error(), metadata)
+}
+
+// visibleVotes drops the votes the caller may not see: editors without a
+// global staff role see the votes of their organizations only.
+func visibleVotes(ctx context.Context, votes []*storage.Vote) []*storage.Vote {
+	subject := access.FromContext(ctx)
+	if !subject.Restricted() {
+		return votes
+	}
+	visible := make([]*storage.Vote, 0, len(votes))
+	for _, vote := range votes {
+		if subject.Edits(vote.OrganizationID) {
+			visible = append(visible, vote)
+		}
+	}
+	return visible
+}
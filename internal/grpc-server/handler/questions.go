@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"sort"
+)
+
+func questionsToProto(questions []storage.Question) []*proto.Question {
+	protoQuestions := make([]*proto.Question, 0, len(questions))
+	for _, question := range questions {
+		protoQuestions = append(protoQuestions, &proto.Question{
+			Id:       int32(question.ID),
+			Position: int32(question.Position),
+			Kind:     question.Kind,
+			Text:     question.Text,
+			Required: question.Required,
+			Options:  optionsToProto(question.Options),
+			RateScale: &proto.RateScale{
+				Min:  int32(question.Scale.Min),
+				Max:  int32(question.Scale.Max),
+				Step: int32(question.Scale.Step),
+			},
+		})
+	}
+	return protoQuestions
+}
+
+// questionsFromProto keeps the order of the list as display order, like
+// optionsFromProto.
+func questionsFromProto(questions []*proto.Question) []storage.Question {
+	var stored []storage.Question
+	for i, question := range questions {
+		stored = append(stored, storage.Question{
+			ID:       int(question.GetId()),
+			Position: i,
+			Kind:     question.GetKind(),
+			Text:     question.GetText(),
+			Required: question.GetRequired(),
+			Options:  optionsFromProto(question.GetOptions(), nil),
+			Scale: storage.RateScale{
+				Min:  int(question.GetRateScale().GetMin()),
+				Max:  int(question.GetRateScale().GetMax()),
+				Step: int(question.GetRateScale().GetStep()),
+			},
+		})
+	}
+	return stored
+}
+
+func followUpsToProto(followUps []storage.FollowUp) []*proto.FollowUp {
+	protoFollowUps := make([]*proto.FollowUp, 0, len(followUps))
+	for _, followUp := range followUps {
+		trigger := &proto.FollowUpTrigger{
+			MinRating: int32(followUp.Trigger.MinRating),
+			MaxRating: int32(followUp.Trigger.MaxRating),
+		}
+		for _, id := range followUp.Trigger.OptionIDs {
+			trigger.OptionIds = append(trigger.OptionIds, int32(id))
+		}
+		protoFollowUps = append(protoFollowUps, &proto.FollowUp{
+			Id:       int32(followUp.ID),
+			Position: int32(followUp.Position),
+			Kind:     followUp.Kind,
+			Text:     followUp.Text,
+			Options:  optionsToProto(followUp.Options),
+			RateScale: &proto.RateScale{
+				Min:  int32(followUp.Scale.Min),
+				Max:  int32(followUp.Scale.Max),
+				Step: int32(followUp.Scale.Step),
+			},
+			Trigger: trigger,
+		})
+	}
+	return protoFollowUps
+}
+
+func followUpsFromProto(followUps []*proto.FollowUp) []storage.FollowUp {
+	var stored []storage.FollowUp
+	for i, followUp := range followUps {
+		trigger := storage.FollowUpTrigger{
+			MinRating: int(followUp.GetTrigger().GetMinRating()),
+			MaxRating: int(followUp.GetTrigger().GetMaxRating()),
+			Options:   followUp.GetTrigger().GetOptions(),
+		}
+		for _, id := range followUp.GetTrigger().GetOptionIds() {
+			trigger.OptionIDs = append(trigger.OptionIDs, int(id))
+		}
+		stored = append(stored, storage.FollowUp{
+			ID:       int(followUp.GetId()),
+			Position: i,
+			Kind:     followUp.GetKind(),
+			Text:     followUp.GetText(),
+			Options:  optionsFromProto(followUp.GetOptions(), nil),
+			Scale: storage.RateScale{
+				Min:  int(followUp.GetRateScale().GetMin()),
+				Max:  int(followUp.GetRateScale().GetMax()),
+				Step: int(followUp.GetRateScale().GetStep()),
+			},
+			Trigger: trigger,
+		})
+	}
+	return stored
+}
+
+func answersToProto(answers []storage.SurveyAnswer) []*proto.SurveyAnswer {
+	protoAnswers := make([]*proto.SurveyAnswer, 0, len(answers))
+	for _, answer := range answers {
+		protoAnswer := &proto.SurveyAnswer{QuestionId: int32(answer.QuestionID), Text: answer.Text}
+		if answer.Rating != nil {
+			rating := int32(*answer.Rating)
+			protoAnswer.Rating = &rating
+		}
+		for _, id := range answer.OptionIDs {
+			protoAnswer.OptionIds = append(protoAnswer.OptionIds, int32(id))
+		}
+		protoAnswers = append(protoAnswers, protoAnswer)
+	}
+	return protoAnswers
+}
+
+func answersFromProto(answers []*proto.SurveyAnswer) []storage.SurveyAnswer {
+	stored := make([]storage.SurveyAnswer, 0, len(answers))
+	for _, answer := range answers {
+		survey := storage.SurveyAnswer{QuestionID: int(answer.GetQuestionId()), Text: answer.GetText()}
+		if answer.Rating != nil {
+			rating := int(answer.GetRating())
+			survey.Rating = &rating
+		}
+		for _, id := range answer.GetOptionIds() {
+			survey.OptionIDs = append(survey.OptionIDs, int(id))
+		}
+		stored = append(stored, survey)
+	}
+	return stored
+}
+
+// questionResultsToProto orders ratings by value and lists every option of
+// a choice question in display order, including options nobody selected.
+func questionResultsToProto(questions []storage.Question, results []storage.QuestionResult) []*proto.QuestionResult {
+	protoResults := make([]*proto.QuestionResult, 0, len(results))
+	for i, result := range results {
+		protoResult := &proto.QuestionResult{
+			QuestionId: int32(result.QuestionID),
+			Answers:    int32(result.Answers),
+			Average:    float32(result.Average),
+		}
+		for _, rating := range sortedKeys(result.Ratings) {
+			protoResult.Ratings = append(protoResult.Ratings, &proto.RatingCount{
+				Rating:  int32(rating),
+				Answers: int32(result.Ratings[rating]),
+			})
+		}
+		for _, option := range questions[i].Options {
+			protoResult.Options = append(protoResult.Options, &proto.OptionTally{
+				OptionId: int32(option.ID),
+				Votes:    int32(result.Options[option.ID]),
+			})
+		}
+		protoResults = append(protoResults, protoResult)
+	}
+	return protoResults
+}
+
+func sortedKeys(counts map[int]int) []int {
+	keys := make([]int, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+)
+
+// requestIDHeader is the metadata key of the request ID, both in requests
+// and in response headers.
+const requestIDHeader = "x-request-id"
+
+// maxRequestID bounds the length of a request ID supplied by the caller.
+const maxRequestID = 128
+
+// RequestIDInterceptor gives every request an ID, which the audit log
+// records with the changes the request causes. An ID sent by the caller in
+// the "x-request-id" metadata is kept so that a request can be traced
+// through the services it passes; otherwise one is generated. The ID is
+// returned in the "x-request-id" response header.
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestId := incomingRequestID(ctx)
+		if requestId == "" {
+			requestId = newRequestID()
+		}
+		// Setting the header fails only outside of a gRPC call.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestId))
+		return handler(audit.WithRequestID(ctx, requestId), req)
+	}
+}
+
+// incomingRequestID returns the request ID sent by the caller, or "" if
+// there is none or it is not printable ASCII of at most maxRequestID
+// characters.
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(requestIDHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	requestId := strings.TrimSpace(values[0])
+	if len(requestId) > maxRequestID {
+		return ""
+	}
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] < ' ' || requestId[i] > '~' {
+			return ""
+		}
+	}
+	return requestId
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
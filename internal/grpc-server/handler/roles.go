@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/auth"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"log/slog"
+	"strings"
+)
+
+func (h *AdminHandler) ListRoleAssignments(ctx context.Context, request *proto.ListRoleAssignmentsRequest) (*proto.ListRoleAssignmentsResponse, error) {
+	h.logger.Debug("Received ListRoleAssignments request", slog.Any("request", request))
+
+	assignments, err := h.storage.ListRoleAssignments(ctx, strings.TrimSpace(request.UserId))
+	if err != nil {
+		return nil, h.handleStorageError(err, "role assignments")
+	}
+
+	protoAssignments := make([]*proto.RoleAssignment, 0, len(assignments))
+	for i := range assignments {
+		protoAssignments = append(protoAssignments, roleAssignmentToProto(&assignments[i]))
+	}
+	return &proto.ListRoleAssignmentsResponse{Response: protoAssignments}, nil
+}
+
+func (h *AdminHandler) GrantRole(ctx context.Context, request *proto.GrantRoleRequest) (*proto.GrantRoleResponse, error) {
+	h.logger.Debug("Received GrantRole request", slog.Any("request", request))
+
+	assignment := roleAssignmentFromProto(request.GetAssignment())
+	if err := storage.ValidateRoleAssignment(assignment); err != nil {
+		return nil, invalidRequest("Invalid role assignment: "+err.Error(), nil)
+	}
+
+	granted, err := h.storage.GrantRole(ctx, assignment)
+	if err != nil {
+		return nil, h.handleStorageError(err, "granting role")
+	}
+
+	h.logger.Info("Role granted", slog.String("user_id", granted.UserID), slog.String("role", granted.Role),
+		slog.Int("organization_id", granted.OrganizationID), slog.String("granted_by", auth.UserID(ctx)))
+	return &proto.GrantRoleResponse{Response: roleAssignmentToProto(granted)}, nil
+}
+
+func (h *AdminHandler) RevokeRole(ctx context.Context, request *proto.RevokeRoleRequest) (*proto.RevokeRoleResponse, error) {
+	h.logger.Debug("Received RevokeRole request", slog.Any("request", request))
+
+	assignment := roleAssignmentFromProto(request.GetAssignment())
+	if err := storage.ValidateRoleAssignment(assignment); err != nil {
+		return nil, invalidRequest("Invalid role assignment: "+err.Error(), nil)
+	}
+
+	if err := h.storage.RevokeRole(ctx, assignment); err != nil {
+		return nil, h.handleStorageError(err, "revoking role")
+	}
+
+	h.logger.Info("Role revoked", slog.String("user_id", assignment.UserID), slog.String("role", assignment.Role),
+		slog.Int("organization_id", assignment.OrganizationID), slog.String("revoked_by", auth.UserID(ctx)))
+	return &proto.RevokeRoleResponse{Response: "Role revoked successfully"}, nil
+}
+
+func roleAssignmentFromProto(assignment *proto.RoleAssignment) *storage.RoleAssignment {
+	return &storage.RoleAssignment{
+		UserID:         assignment.GetUserId(),
+		Role:           assignment.GetRole(),
+		OrganizationID: int(assignment.GetOrganizationId()),
+	}
+}
+
+func roleAssignmentToProto(assignment *storage.RoleAssignment) *proto.RoleAssignment {
+	return &proto.RoleAssignment{
+		UserId:         assignment.UserID,
+		Role:           assignment.Role,
+		OrganizationId: int32(assignment.OrganizationID),
+		Granted:        optionalTimestamp(assignment.GrantedAt),
+	}
+}
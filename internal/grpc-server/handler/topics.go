@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/api/proto"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"log/slog"
+)
+
+func (h *GRPCHandler) ListTopics(ctx context.Context, request *proto.ListTopicsRequest) (*proto.ListTopicsResponse, error) {
+	h.logger.Debug("Received ListTopics request", slog.Any("request", request))
+
+	topics, err := h.storage.ListTopics(ctx)
+	if err != nil {
+		return nil, h.handleStorageError(err, "topics")
+	}
+
+	protoTopics := make([]*proto.Topic, 0, len(topics))
+	for i := range topics {
+		protoTopics = append(protoTopics, topicToProto(&topics[i]))
+	}
+	return &proto.ListTopicsResponse{Response: protoTopics}, nil
+}
+
+func (h *AdminHandler) CreateTopic(ctx context.Context, request *proto.CreateTopicRequest) (*proto.CreateTopicResponse, error) {
+	h.logger.Debug("Received CreateTopic request", slog.Any("request", request))
+
+	topic := topicFromProto(request.GetTopic())
+	if err := storage.ValidateTopic(topic); err != nil {
+		return nil, invalidRequest("Invalid topic: "+err.Error(), nil)
+	}
+
+	created, err := h.storage.CreateTopic(ctx, topic)
+	if err != nil {
+		return nil, h.handleStorageError(err, "creating topic")
+	}
+
+	h.logger.Info("Topic created", slog.String("slug", created.Slug))
+	return &proto.CreateTopicResponse{Response: topicToProto(created)}, nil
+}
+
+func (h *AdminHandler) UpdateTopic(ctx context.Context, request *proto.UpdateTopicRequest) (*proto.UpdateTopicResponse, error) {
+	h.logger.Debug("Received UpdateTopic request", slog.Any("request", request))
+
+	topic := topicFromProto(request.GetTopic())
+	if err := storage.ValidateTopic(topic); err != nil {
+		return nil, invalidRequest("Invalid topic: "+err.Error(), nil)
+	}
+
+	updated, err := h.storage.UpdateTopic(ctx, topic)
+	if err != nil {
+		return nil, h.handleStorageError(err, "updating topic")
+	}
+
+	h.logger.Info("Topic updated", slog.String("slug", updated.Slug))
+	return &proto.UpdateTopicResponse{Response: topicToProto(updated)}, nil
+}
+
+func topicFromProto(topic *proto.Topic) *storage.Topic {
+	return &storage.Topic{Slug: topic.GetSlug(), Name: topic.GetName()}
+}
+
+func topicToProto(topic *storage.Topic) *proto.Topic {
+	return &proto.Topic{Slug: topic.Slug, Name: topic.Name, Votes: int32(topic.Votes)}
+}
@@ -0,0 +1,54 @@
+package lifecycle
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/internal/storage"
+	"log/slog"
+	"time"
+)
+
+// Job periodically opens scheduled votes whose start time has come, closes
+// votes whose end time has passed, catches up on petitions that reached
+// their signature goal and flags petitions whose response is overdue.
+type Job struct {
+	storage  storage.Repository
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewJob(storage storage.Repository, interval time.Duration, logger *slog.Logger) *Job {
+	return &Job{storage: storage, interval: interval, logger: logger}
+}
+
+// Run advances the vote statuses once immediately and then on every tick
+// until ctx is cancelled.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.logger.Info("Vote lifecycle job started", slog.Duration("interval", j.interval))
+	for {
+		j.tick(ctx)
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Vote lifecycle job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Job) tick(ctx context.Context) {
+	changes, err := j.storage.AdvanceVoteStatuses(ctx)
+	if err != nil {
+		j.logger.Error("Failed to advance vote statuses", slog.String("error", err.Error()))
+		return
+	}
+	if changes.Opened > 0 || changes.Closed > 0 || changes.AwaitingResponse > 0 {
+		j.logger.Info("Vote statuses advanced", slog.Int("opened", changes.Opened), slog.Int("closed", changes.Closed),
+			slog.Int("awaiting_response", changes.AwaitingResponse))
+	}
+	if changes.Overdue > 0 {
+		j.logger.Warn("Petition responses overdue", slog.Int("petitions", changes.Overdue))
+	}
+}
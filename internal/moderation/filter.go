@@ -0,0 +1,170 @@
+package moderation
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Filter screens user-written text before it is stored. Implementations
+// must be safe for concurrent use.
+type Filter interface {
+	Check(text string) Verdict
+}
+
+// Verdict is the outcome of a Filter check. A flagged text is stored as
+// rejected with Reason and never reaches the moderation queue.
+type Verdict struct {
+	Flagged bool
+	Reason  string
+	Matches []string
+}
+
+// ReasonProfanity is the rejection reason reported by WordList.
+const ReasonProfanity = "profanity"
+
+//go:embed wordlist_ru.txt
+var russianWords string
+
+// Russian returns a WordList built from the embedded Russian profanity list.
+func Russian() *WordList {
+	list, err := ParseWordList(strings.NewReader(russianWords))
+	if err != nil {
+		panic(fmt.Sprintf("moderation: embedded word list: %v", err))
+	}
+	return list
+}
+
+// WordList flags texts containing a word that matches one of its rules. The
+// rule syntax is described at the top of wordlist_ru.txt.
+type WordList struct {
+	contains   []string
+	prefixes   []string
+	words      map[string]bool
+	exceptions []string
+}
+
+// LoadWordList reads a word list from the file at path.
+func LoadWordList(path string) (*WordList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("moderation: %w", err)
+	}
+	defer f.Close()
+
+	list, err := ParseWordList(f)
+	if err != nil {
+		return nil, fmt.Errorf("moderation: %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// ParseWordList reads a word list with one rule per line.
+func ParseWordList(r io.Reader) (*WordList, error) {
+	list := &WordList{words: make(map[string]bool)}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		rule := strings.TrimSpace(scanner.Text())
+		if rule == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+
+		kind, stem := rule[0], rule[1:]
+		if !strings.ContainsRune("^=!", rune(kind)) {
+			kind, stem = 0, rule
+		}
+		stem = normalize(stem)
+		if stem == "" || strings.IndexFunc(stem, isSeparator) >= 0 {
+			return nil, fmt.Errorf("line %d: invalid rule %q", line, rule)
+		}
+
+		switch kind {
+		case '^':
+			list.prefixes = append(list.prefixes, stem)
+		case '=':
+			list.words[stem] = true
+		case '!':
+			list.exceptions = append(list.exceptions, stem)
+		default:
+			list.contains = append(list.contains, stem)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (l *WordList) Check(text string) Verdict {
+	var verdict Verdict
+	for _, word := range strings.FieldsFunc(text, isSeparator) {
+		if l.matches(normalize(word)) {
+			verdict.Flagged = true
+			verdict.Reason = ReasonProfanity
+			verdict.Matches = append(verdict.Matches, word)
+		}
+	}
+	return verdict
+}
+
+func (l *WordList) matches(word string) bool {
+	for _, exception := range l.exceptions {
+		if strings.Contains(word, exception) {
+			return false
+		}
+	}
+	if l.words[word] {
+		return true
+	}
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	for _, stem := range l.contains {
+		if strings.Contains(word, stem) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookalikes maps Latin letters and digits used to disguise Cyrillic words
+// to the letters they imitate.
+var lookalikes = map[rune]rune{
+	'a': 'а', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к', 'm': 'м', 'n': 'п', 'o': 'о',
+	'p': 'р', 't': 'т', 'u': 'и', 'x': 'х', 'y': 'у', '0': 'о', '3': 'з', '4': 'ч',
+	'6': 'б', '@': 'а',
+}
+
+// normalize lower-cases word, folds ё into е and collapses repeated
+// letters. Look-alikes are only replaced in words that contain Cyrillic,
+// so ordinary Latin words are left alone.
+func normalize(word string) string {
+	word = strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	cyrillic := strings.IndexFunc(word, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) >= 0
+
+	var b strings.Builder
+	var last rune
+	for _, r := range word {
+		if replacement, ok := lookalikes[r]; ok && cyrillic {
+			r = replacement
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '@'
+}
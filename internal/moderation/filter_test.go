@@ -0,0 +1,120 @@
+package moderation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRussianCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		matches []string
+	}{
+		{"clean", "Предлагаю построить новый парк у реки", nil},
+		{"contains", "Это пиздец, а не дорога", []string{"пиздец"}},
+		{"prefix", "Ебанутый проект", []string{"Ебанутый"}},
+		{"exact word", "Какая сука это придумала?", []string{"сука"}},
+		{"several", "Мудак и долбоеб", []string{"Мудак", "долбоеб"}},
+		{"punctuation", "...сука!!!", []string{"сука"}},
+
+		{"yo folding", "Ёбаный стыд", []string{"Ёбаный"}},
+		{"upper case", "ХУЙНЯ какая-то", []string{"ХУЙНЯ"}},
+		{"repeated letters", "суууука", []string{"суууука"}},
+		{"latin look-alikes", "xуйня и пиzдa", []string{"xуйня"}},
+		{"latin look-alike stem", "сykа", []string{"сykа"}},
+		{"digit look-alikes", "пи3дец и 6лядь", []string{"пи3дец", "6лядь"}},
+		{"at sign", "муд@к", []string{"муд@к"}},
+		{"latin words stay latin", "cyka hotel exam", nil},
+
+		{"exact word only", "Сукно и сукровица", nil},
+		{"exact word prefix", "Херсон", nil},
+		{"city", "Ебург и Екатеринбург", nil},
+		{"bread", "Хлеб, хлебал и хлебница", nil},
+		{"rebalance", "Перебалансировка бюджета", nil},
+		{"rowing", "Гребля и грести", nil},
+		{"hesitation", "Колебание цен", nil},
+		{"debate", "Дебаты в думе", nil},
+		{"teasing", "Стебать и стебаться", nil},
+		{"consumption", "Потребление воды", nil},
+		{"badge", "Бляшка и бляха", nil},
+	}
+	list := Russian()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := list.Check(tt.text)
+			if !reflect.DeepEqual(verdict.Matches, tt.matches) {
+				t.Errorf("got matches %q, want %q", verdict.Matches, tt.matches)
+			}
+			if verdict.Flagged != (len(tt.matches) > 0) {
+				t.Errorf("got flagged %v for matches %q", verdict.Flagged, tt.matches)
+			}
+			if verdict.Flagged && verdict.Reason != ReasonProfanity {
+				t.Errorf("got reason %q, want %q", verdict.Reason, ReasonProfanity)
+			}
+		})
+	}
+}
+
+func TestParseWordList(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		ok    bool
+	}{
+		{"comments and blank lines", "# comment\n\n  \nслово\n", true},
+		{"all kinds", "корень\n^начало\n=слово\n!исключение\n", true},
+		{"empty stem", "^\n", false},
+		{"two words", "два слова\n", false},
+		{"punctuation", "=слово!\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWordList(strings.NewReader(tt.rules))
+			if (err == nil) != tt.ok {
+				t.Errorf("got error %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestWordListRules(t *testing.T) {
+	list, err := ParseWordList(strings.NewReader("кот\n^пес\n=мыш\n!котел\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]bool{
+		"кот":     true,
+		"скотина": true,
+		"котел":   false,
+		"пескарь": true,
+		"опес":    false,
+		"мыш":     true,
+		"мышка":   false,
+		"собака":  false,
+	}
+	for word, want := range tests {
+		if got := list.Check(word).Flagged; got != want {
+			t.Errorf("Check(%q) flagged %v, want %v", word, got, want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Ёлка":   "елка",
+		"ПАРККК": "парк",
+		"xуй":    "хуй",
+		"пи3да":  "пизда",
+		"муд@к":  "мудак",
+		"hello":  "helo",
+		"4eрт":   "черт",
+		"c0ffee": "c0fe",
+	}
+	for word, want := range tests {
+		if got := normalize(word); got != want {
+			t.Errorf("normalize(%q) is %q, want %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package pseudonym derives the voter IDs stored with ballots from user IDs.
+//
+// A voter ID is a chain of HMAC-SHA256 layers over the user ID, one per
+// pepper, oldest first. Rotating the pepper adds a layer: existing rows are
+// re-keyed by applying the new pepper to what they store, so the user IDs
+// themselves are never needed. Each layer is the lowercase hex encoding of
+// the HMAC, which is what pgcrypto's encode(hmac(value, pepper, 'sha256'),
+// 'hex') computes when rows are re-keyed in the database.
+package pseudonym
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// MinPepperLength is the shortest pepper accepted, in bytes.
+const MinPepperLength = 16
+
+// fingerprintLabel is hashed with a pepper to identify it without
+// revealing it.
+const fingerprintLabel = "votes ballot pepper fingerprint"
+
+// Keys derives voter IDs with a chain of peppers.
+type Keys struct {
+	peppers []string
+}
+
+// New returns Keys for the peppers, oldest first. The last one is the
+// current pepper.
+func New(peppers ...string) (*Keys, error) {
+	if len(peppers) == 0 {
+		return nil, errors.New("pseudonym: at least one pepper is required")
+	}
+	seen := make(map[string]bool, len(peppers))
+	for i, pepper := range peppers {
+		if err := ValidatePepper(pepper); err != nil {
+			return nil, fmt.Errorf("pseudonym: pepper %d: %w", i+1, err)
+		}
+		if seen[pepper] {
+			return nil, fmt.Errorf("pseudonym: pepper %d is used twice", i+1)
+		}
+		seen[pepper] = true
+	}
+	return &Keys{peppers: append([]string{}, peppers...)}, nil
+}
+
+// ValidatePepper checks that a pepper is long enough.
+func ValidatePepper(pepper string) error {
+	if len(pepper) < MinPepperLength {
+		return fmt.Errorf("must be at least %d bytes long", MinPepperLength)
+	}
+	return nil
+}
+
+// VoterID returns the pseudonym stored with the ballots of a user.
+func (k *Keys) VoterID(userId string) string {
+	id := userId
+	for _, pepper := range k.peppers {
+		id = Layer(pepper, id)
+	}
+	return id
+}
+
+// Fingerprints identifies the peppers, oldest first.
+func (k *Keys) Fingerprints() []string {
+	fingerprints := make([]string, 0, len(k.peppers))
+	for _, pepper := range k.peppers {
+		fingerprints = append(fingerprints, Fingerprint(pepper))
+	}
+	return fingerprints
+}
+
+// Layer applies one pepper to value.
+func Layer(pepper, value string) string {
+	mac := hmac.New(sha256.New, []byte(pepper))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Fingerprint identifies a pepper so that the database can record which
+// peppers its rows are keyed with.
+func Fingerprint(pepper string) string {
+	return Layer(pepper, fingerprintLabel)[:16]
+}
@@ -0,0 +1,117 @@
+package pseudonym
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	oldPepper     = "old pepper of sixteen bytes"
+	currentPepper = "current pepper of sixteen bytes"
+)
+
+func TestLayer(t *testing.T) {
+	// RFC 4231, test case 2.
+	got := Layer("Jefe", "what do ya want for nothing?")
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestVoterID(t *testing.T) {
+	single, err := New(oldPepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layered, err := New(oldPepper, currentPepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := single.VoterID("user-1"), Layer(oldPepper, "user-1"); got != want {
+		t.Errorf("single pepper: got %s, want %s", got, want)
+	}
+	// Adding a pepper re-keys the stored ID without the user ID.
+	if got, want := layered.VoterID("user-1"), Layer(currentPepper, single.VoterID("user-1")); got != want {
+		t.Errorf("layered: got %s, want %s", got, want)
+	}
+	if single.VoterID("user-1") == single.VoterID("user-2") {
+		t.Error("two users share a voter ID")
+	}
+	reversed, err := New(currentPepper, oldPepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reversed.VoterID("user-1") == layered.VoterID("user-1") {
+		t.Error("the order of the peppers does not matter")
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	keys, err := New(oldPepper, currentPepper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprints := keys.Fingerprints()
+	want := []string{Fingerprint(oldPepper), Fingerprint(currentPepper)}
+	if len(fingerprints) != len(want) {
+		t.Fatalf("got %d fingerprints, want %d", len(fingerprints), len(want))
+	}
+	for i, fingerprint := range fingerprints {
+		if fingerprint != want[i] {
+			t.Errorf("fingerprint %d is %s, want %s", i+1, fingerprint, want[i])
+		}
+		if len(fingerprint) != 16 {
+			t.Errorf("fingerprint %d is %d characters long, want 16", i+1, len(fingerprint))
+		}
+	}
+	if fingerprints[0] == fingerprints[1] {
+		t.Error("two peppers share a fingerprint")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		peppers []string
+		err     string
+	}{
+		{"one pepper", []string{oldPepper}, ""},
+		{"two peppers", []string{oldPepper, currentPepper}, ""},
+		{"no pepper", nil, "at least one pepper"},
+		{"short pepper", []string{oldPepper, "fifteen bytes!!"}, "pepper 2: must be at least 16 bytes"},
+		{"minimum length", []string{"sixteen bytes!!!"}, ""},
+		{"duplicate", []string{oldPepper, currentPepper, oldPepper}, "pepper 3 is used twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := New(tt.peppers...)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if keys == nil {
+					t.Fatal("got no keys")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewCopiesPeppers(t *testing.T) {
+	peppers := []string{oldPepper}
+	keys, err := New(peppers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := keys.VoterID("user-1")
+	peppers[0] = currentPepper
+	if got := keys.VoterID("user-1"); got != want {
+		t.Error("changing the caller's slice changes the voter IDs")
+	}
+}
@@ -0,0 +1,442 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/jackc/pgx/v5"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *PostgresStorage) CreateVote(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.postgresql.CreateVote"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	created, err := insertVote(ctx, tx, vote, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.auditChange(ctx, tx, "vote.create", auditVote, strconv.Itoa(created.ID), nil, created); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+// insertVote stores a new vote with its options, questions and follow-ups.
+// A vote without a status is created as a draft. createOrganization is
+// passed on to resolveOrganization.
+func insertVote(ctx context.Context, tx pgx.Tx, vote *Vote, createOrganization bool) (*Vote, error) {
+	created := *vote
+	if created.Status == "" {
+		created.Status = StatusDraft
+	}
+	if err := resolveOrganization(ctx, tx, &created, createOrganization); err != nil {
+		return nil, err
+	}
+	err := tx.QueryRow(ctx, `
+		INSERT INTO votes (category, name, description, organization, photo, start_time, end_time, external_key,
+			rate_min, rate_max, rate_step, min_selections, max_selections, budget, status,
+			signature_goal, author_token, review_reason, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, $14, $15, $16, NULLIF($17, ''), $18, $19)
+		RETURNING id`,
+		vote.Category, vote.Name, vote.Description, created.Organization, vote.Photo, nullTime(vote.StartTime), vote.EndTime, vote.ExternalKey,
+		vote.Scale.Min, vote.Scale.Max, vote.Scale.Step, vote.Selection.Min, vote.Selection.Max, vote.Budget, created.Status,
+		vote.SignatureGoal, vote.Author, vote.ReviewReason, nullID(created.OrganizationID)).Scan(&created.ID)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	if created.Options, err = replaceOptions(ctx, tx, created.ID, vote.Options); err != nil {
+		return nil, err
+	}
+	if created.Questions, err = replaceQuestions(ctx, tx, created.ID, vote.Questions); err != nil {
+		return nil, err
+	}
+	if created.FollowUps, err = replaceFollowUps(ctx, tx, created.ID, resolveTriggers(vote.FollowUps, created.Options)); err != nil {
+		return nil, err
+	}
+	if err := replaceTopics(ctx, tx, created.ID, vote.Topics); err != nil {
+		return nil, err
+	}
+	created.Topics = append([]string{}, vote.Topics...)
+	return &created, nil
+}
+
+func (s *PostgresStorage) UpdateVote(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.postgresql.UpdateVote"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The status is left alone; it only changes through SetVoteStatus and
+	// AdvanceVoteStatuses. The author, review and response deadline of a
+	// petition are kept as well.
+	previous, err := s.lockVote(ctx, tx, vote.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := checkRulesChange(ctx, tx, previous, vote); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated := *vote
+	var responseDue *time.Time
+	if err := resolveOrganization(ctx, tx, &updated, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	err = tx.QueryRow(ctx, `
+		UPDATE votes
+		SET category = $2, name = $3, description = $4, organization = $5, photo = $6, start_time = $7,
+			end_time = $8, external_key = NULLIF($9, ''), rate_min = $10, rate_max = $11, rate_step = $12,
+			min_selections = $13, max_selections = $14, budget = $15, signature_goal = $16, organization_id = $17
+		WHERE id = $1
+		RETURNING status, COALESCE(author_token, ''), review_reason, response_due, response_overdue`,
+		vote.ID, vote.Category, vote.Name, vote.Description, updated.Organization, vote.Photo, nullTime(vote.StartTime), vote.EndTime, vote.ExternalKey,
+		vote.Scale.Min, vote.Scale.Max, vote.Scale.Step, vote.Selection.Min, vote.Selection.Max, vote.Budget, vote.SignatureGoal,
+		nullID(updated.OrganizationID)).
+		Scan(&updated.Status, &updated.Author, &updated.ReviewReason, &responseDue, &updated.ResponseOverdue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if responseDue != nil {
+		updated.ResponseDue = *responseDue
+	}
+
+	if updated.Options, err = replaceOptions(ctx, tx, vote.ID, vote.Options); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if updated.Questions, err = replaceQuestions(ctx, tx, vote.ID, vote.Questions); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if updated.FollowUps, err = replaceFollowUps(ctx, tx, vote.ID, resolveTriggers(vote.FollowUps, updated.Options)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := replaceTopics(ctx, tx, vote.ID, vote.Topics); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated.Topics = append([]string{}, vote.Topics...)
+	if err := s.auditChange(ctx, tx, "vote.update", auditVote, strconv.Itoa(vote.ID), previous, &updated); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &updated, nil
+}
+
+// DeleteVote deletes a vote that has no ballots. Deleting the ballots with
+// the vote would leave no audit entry of their removal, so a vote with
+// ballots is refused with ErrVoteHasBallots and archived instead.
+func (s *PostgresStorage) DeleteVote(ctx context.Context, voteId int) error {
+	const op = "storage.postgresql.DeleteVote"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := s.lockVote(ctx, tx, voteId)
+	if err != nil {
+		return fmt.Errorf("%s: vote %d: %w", op, voteId, err)
+	}
+	ballots, err := hasBallots(ctx, tx, voteId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ballots {
+		return fmt.Errorf("%s: %w: vote %d", op, ErrVoteHasBallots, voteId)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM votes WHERE id = $1`, voteId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.auditChange(ctx, tx, "vote.delete", auditVote, strconv.Itoa(voteId), previous, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// lockVote locks a vote for the rest of tx and returns it as last committed,
+// so that the audit log records the state the change starts from.
+func (s *PostgresStorage) lockVote(ctx context.Context, tx pgx.Tx, voteId int) (*Vote, error) {
+	var id int
+	if err := tx.QueryRow(ctx, `SELECT id FROM votes WHERE id = $1 FOR UPDATE`, voteId).Scan(&id); err != nil {
+		return nil, classifyError(err)
+	}
+	return s.GetVote(ctx, voteId)
+}
+
+// checkRulesChange refuses an edit of the ballot rules of previous once the
+// vote has opened or has ballots. The vote row must be locked, so that no
+// ballot is cast while the edit is in progress.
+func checkRulesChange(ctx context.Context, tx pgx.Tx, previous, vote *Vote) error {
+	if !rulesChanged(previous, vote) {
+		return nil
+	}
+	ballots, err := hasBallots(ctx, tx, previous.ID)
+	if err != nil {
+		return err
+	}
+	return checkRulesLocked(previous, ballots)
+}
+
+// hasBallots reports whether anyone voted in a vote. Survey drafts count as
+// ballots.
+func hasBallots(ctx context.Context, tx pgx.Tx, voteId int) (bool, error) {
+	tables := make([]string, 0, len(ballotTables))
+	for _, table := range ballotTables {
+		tables = append(tables, `EXISTS (SELECT 1 FROM `+table+` WHERE vote_id = $1)`)
+	}
+	sort.Strings(tables)
+
+	var found bool
+	err := tx.QueryRow(ctx, `SELECT `+strings.Join(tables, " OR "), voteId).Scan(&found)
+	return found, err
+}
+
+// SetVoteStatus moves a vote to another lifecycle state if the transition is
+// allowed.
+func (s *PostgresStorage) SetVoteStatus(ctx context.Context, voteId int, status string) (*Vote, error) {
+	const op = "storage.postgresql.SetVoteStatus"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var current string
+	var startTime *time.Time
+	var endTime time.Time
+	err = tx.QueryRow(ctx, `SELECT status, start_time, end_time FROM votes WHERE id = $1 FOR UPDATE`, voteId).
+		Scan(&current, &startTime, &endTime)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	var start time.Time
+	if startTime != nil {
+		start = *startTime
+	}
+	if err := checkTransition(voteId, current, status, start, endTime, s.opts.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE votes SET status = $2 WHERE id = $1`, voteId, status); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	err = s.auditChange(ctx, tx, "vote.status", auditVote, strconv.Itoa(voteId),
+		map[string]string{"Status": current}, map[string]string{"Status": status})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.GetVote(ctx, voteId)
+}
+
+// AdvanceVoteStatuses opens scheduled votes whose start time has come,
+// closes votes whose end time has passed, moves open petitions that have
+// reached their signature goal to awaiting_response and flags petitions
+// whose response is overdue. Every change is audited with the actor
+// "system:lifecycle".
+func (s *PostgresStorage) AdvanceVoteStatuses(ctx context.Context) (StatusChanges, error) {
+	const op = "storage.postgresql.AdvanceVoteStatuses"
+
+	var changes StatusChanges
+	now := s.opts.clock.Now()
+	ctx = audit.WithActor(ctx, actorLifecycle)
+
+	// Petitions are promoted first so that one which reached its goal just
+	// before its end time is not closed instead.
+	promoted, err := s.promotePetitions(ctx, 0)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	changes.AwaitingResponse = promoted
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	closed, err := advanceStatuses(ctx, tx, `status IN ('scheduled', 'open') AND end_time <= $1`, StatusClosed, now)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	opened, err := advanceStatuses(ctx, tx, `status = 'scheduled' AND (start_time IS NULL OR start_time <= $1)`, StatusOpen, now)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	overdue, err := flagOverdueResponses(ctx, tx, now)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The changes are audited last, as appending holds the audit stream of
+	// each vote until the commit.
+	for _, move := range append(closed, opened...) {
+		err := s.auditChange(ctx, tx, "vote.status", auditVote, strconv.Itoa(move.voteId),
+			map[string]string{"Status": move.from}, map[string]string{"Status": move.to})
+		if err != nil {
+			return changes, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	for _, voteId := range overdue {
+		err := s.auditChange(ctx, tx, "petition.overdue", auditVote, strconv.Itoa(voteId),
+			map[string]bool{"ResponseOverdue": false}, map[string]bool{"ResponseOverdue": true})
+		if err != nil {
+			return changes, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	changes.Closed, changes.Opened, changes.Overdue = len(closed), len(opened), len(overdue)
+	return changes, nil
+}
+
+// statusMove is a status change made by AdvanceVoteStatuses.
+type statusMove struct {
+	voteId   int
+	from, to string
+}
+
+// advanceStatuses moves the votes matching where, a condition on the time
+// $1, to status and returns the moves in order of vote ID.
+func advanceStatuses(ctx context.Context, tx pgx.Tx, where, status string, now time.Time) ([]statusMove, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE votes v SET status = $2
+		FROM (SELECT id, status FROM votes WHERE `+where+` ORDER BY id FOR UPDATE) previous
+		WHERE v.id = previous.id
+		RETURNING v.id, previous.status`, now, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var moves []statusMove
+	for rows.Next() {
+		move := statusMove{to: status}
+		if err := rows.Scan(&move.voteId, &move.from); err != nil {
+			return nil, err
+		}
+		moves = append(moves, move)
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i].voteId < moves[j].voteId })
+	return moves, rows.Err()
+}
+
+// replaceOptions makes the option list of a vote equal to options, in that
+// order. Options are matched by ID, or by text when no ID is given, so kept
+// options retain their ballots; ballots for removed options are deleted
+// together with the option. The stored options are returned with their IDs.
+func replaceOptions(ctx context.Context, tx pgx.Tx, voteId int, options []VoteOption) ([]VoteOption, error) {
+	keepIDs := []int{}
+	keepTexts := []string{}
+	for _, option := range options {
+		if option.ID != 0 {
+			keepIDs = append(keepIDs, option.ID)
+		} else {
+			keepTexts = append(keepTexts, option.Text)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM options WHERE vote_id = $1 AND id <> ALL($2) AND option <> ALL($3)`, voteId, keepIDs, keepTexts)
+	if err != nil {
+		return nil, err
+	}
+	if err := moveRenamed(ctx, tx, "options", "vote_id", "option", voteId, renamedTexts(options)); err != nil {
+		return nil, err
+	}
+
+	stored := make([]VoteOption, 0, len(options))
+	for position, option := range options {
+		option.Position = position
+		if option.ID != 0 {
+			tag, err := tx.Exec(ctx, `
+				UPDATE options SET option = $3, description = $4, image = $5, position = $6
+				WHERE id = $1 AND vote_id = $2`,
+				option.ID, voteId, option.Text, option.Description, option.Image, option.Position)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+			if tag.RowsAffected() == 0 {
+				return nil, fmt.Errorf("%w: option %d does not belong to vote %d", ErrInvalidOption, option.ID, voteId)
+			}
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO options (vote_id, option, description, image, position)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (vote_id, option)
+				DO UPDATE SET description = EXCLUDED.description, image = EXCLUDED.image, position = EXCLUDED.position
+				RETURNING id`,
+				voteId, option.Text, option.Description, option.Image, option.Position).Scan(&option.ID)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+		}
+		stored = append(stored, option)
+	}
+	return stored, nil
+}
+
+// renamedTexts maps the IDs of the options that are matched by ID to their
+// new texts.
+func renamedTexts(options []VoteOption) map[int]string {
+	texts := make(map[int]string, len(options))
+	for _, option := range options {
+		if option.ID != 0 {
+			texts[option.ID] = option.Text
+		}
+	}
+	return texts
+}
+
+// moveRenamed gives the rows of table under parentId whose text column is
+// about to change a unique temporary text. The unique constraint on
+// (parent, column) is checked row by row, so without this two texts could
+// not be swapped, and a new entry given the old text of a renamed one would
+// be matched to it by ON CONFLICT.
+func moveRenamed(ctx context.Context, tx pgx.Tx, table, parent, column string, parentId int, texts map[int]string) error {
+	ids := make([]int, 0, len(texts))
+	newTexts := make([]string, 0, len(texts))
+	for id, text := range texts {
+		ids = append(ids, id)
+		newTexts = append(newTexts, text)
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE `+table+` t SET `+column+` = 'renaming '||gen_random_uuid()::text
+		FROM unnest($2::int[], $3::text[]) AS r(id, text)
+		WHERE t.`+parent+` = $1 AND t.id = r.id AND t.`+column+` <> r.text`,
+		parentId, ids, newTexts)
+	return err
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
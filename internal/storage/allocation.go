@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Allocation is the amount a score or quadratic ballot puts on one option:
+// points in a score vote, votes in a quadratic vote.
+type Allocation struct {
+	OptionID int
+	Points   int
+}
+
+type UserAllocation struct {
+	ID          int
+	Allocations []Allocation
+}
+
+type AllocationInfo struct {
+	ID           int
+	Category     string
+	Name         string
+	Description  string
+	Organization string
+	EndTime      time.Time
+	Photo        string
+	Options      []VoteOption
+	Budget       int
+	Ballots      int
+	Results      []OptionAllocation
+}
+
+// OptionAllocation is the tally of one option. Total sums the allocated
+// amounts and Credits their cost; both are equal in score votes.
+// Distribution maps each allocated amount to the number of ballots that
+// allocated it.
+type OptionAllocation struct {
+	OptionID     int
+	Total        int
+	Credits      int
+	Supporters   int
+	Distribution map[int]int
+}
+
+// AllocationCost is the part of the budget a ballot spends. Quadratic votes
+// cost the square of the votes put on each option.
+func AllocationCost(category string, allocations []Allocation) int {
+	cost := 0
+	for _, allocation := range allocations {
+		cost += allocationCost(category, allocation.Points)
+	}
+	return cost
+}
+
+func allocationCost(category string, points int) int {
+	if category == "quadratic" {
+		return points * points
+	}
+	return points
+}
+
+// allocationResults builds the per-option tallies, in display order, from
+// the number of ballots allocating each amount to each option.
+func allocationResults(category string, options []VoteOption, counts map[int]map[int]int) []OptionAllocation {
+	results := make([]OptionAllocation, 0, len(options))
+	for _, option := range options {
+		result := OptionAllocation{OptionID: option.ID, Distribution: make(map[int]int)}
+		for points, ballots := range counts[option.ID] {
+			result.Total += points * ballots
+			result.Credits += allocationCost(category, points) * ballots
+			result.Supporters += ballots
+			result.Distribution[points] = ballots
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (s *PostgresStorage) GetUserAllocations(ctx context.Context, voterId string) ([]*UserAllocation, error) {
+	const op = "storage.postgresql.GetUserAllocations"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT a.vote_id, a.option_id, a.points
+		FROM allocation_results a
+		JOIN options o ON o.id = a.option_id
+		WHERE a.user_token = $1
+		ORDER BY a.vote_id, o.position, o.id`, voterId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var allocations []*UserAllocation
+	for rows.Next() {
+		var voteId int
+		var allocation Allocation
+		if err := rows.Scan(&voteId, &allocation.OptionID, &allocation.Points); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(allocations) == 0 || allocations[len(allocations)-1].ID != voteId {
+			allocations = append(allocations, &UserAllocation{ID: voteId})
+		}
+		last := allocations[len(allocations)-1]
+		last.Allocations = append(last.Allocations, allocation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return allocations, nil
+}
+
+func (s *PostgresStorage) GetAllocationInfo(ctx context.Context, voteId int) (*AllocationInfo, error) {
+	const op = "storage.postgresql.GetAllocationInfo"
+
+	query := `
+		SELECT id, category, name, description, organization, photo, end_time, budget
+		FROM votes
+		WHERE id = $1 AND status = ANY($2)
+	`
+	var info AllocationInfo
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
+		&info.ID, &info.Category, &info.Name, &info.Description,
+		&info.Organization, &info.Photo, &info.EndTime, &info.Budget,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, info.Category, budgetCategories...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options, err := s.getOptions(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	info.Options = options
+
+	rows, err := s.db.Query(ctx, `
+		SELECT option_id, points, COUNT(*)
+		FROM allocation_results
+		WHERE vote_id = $1
+		GROUP BY option_id, points`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]map[int]int)
+	for rows.Next() {
+		var optionId, points, ballots int
+		if err := rows.Scan(&optionId, &points, &ballots); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if counts[optionId] == nil {
+			counts[optionId] = make(map[int]int)
+		}
+		counts[optionId][points] = ballots
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	info.Results = allocationResults(info.Category, options, counts)
+
+	err = s.db.QueryRow(ctx, `SELECT COUNT(DISTINCT user_token) FROM allocation_results WHERE vote_id = $1`, voteId).
+		Scan(&info.Ballots)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &info, nil
+}
+
+// VoteAllocation replaces the score or quadratic ballot of voterId.
+func (s *PostgresStorage) VoteAllocation(ctx context.Context, voterId string, voteId int, allocations []Allocation) error {
+	const op = "storage.postgresql.VoteAllocation"
+
+	optionIds := make([]int, 0, len(allocations))
+	points := make([]int, 0, len(allocations))
+	for _, allocation := range allocations {
+		optionIds = append(optionIds, allocation.OptionID)
+		points = append(points, allocation.Points)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, budgetCategories...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotAllocation, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM allocation_results WHERE vote_id = $1 AND user_token = $2`, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO allocation_results (vote_id, user_token, option_id, points)
+		SELECT $1, $2, a.option_id, a.points
+		FROM unnest($3::int[], $4::int[]) AS a(option_id, points)`,
+		voteId, voterId, optionIds, points)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.recordBallot(ctx, tx, ballotAllocation, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
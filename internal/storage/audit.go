@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"time"
+)
+
+// Entities whose changes are audited.
+const (
+	auditVote         = "vote"
+	auditComment      = "comment"
+	auditOrganization = "organization"
+	auditTopic        = "topic"
+	auditRole         = "role"
+	auditVoterKey     = "voter_key"
+)
+
+// Actors of the changes the service makes on its own.
+const (
+	actorLifecycle = "system:lifecycle"
+	actorImport    = "system:import"
+	actorRekey     = "system:rekey"
+)
+
+// Ballot kinds, each with the snapshot query of ballotSnapshots.
+const (
+	ballotRate       = "rate"
+	ballotPetition   = "petition"
+	ballotChoice     = "choice"
+	ballotRanked     = "ranked"
+	ballotAllocation = "allocation"
+	ballotSurvey     = "survey"
+	ballotFollowUps  = "follow_ups"
+)
+
+// ballotSnapshots select the ballot of user $2 in vote $1 as a JSON
+// document, or no row when there is none. Audit entries keep the text as
+// returned, so that it hashes the same when read back.
+var ballotSnapshots = map[string]string{
+	ballotRate: `
+		SELECT jsonb_build_object('rating', rate)::text
+		FROM rate_results WHERE vote_id = $1 AND user_token = $2`,
+	ballotPetition: `
+		SELECT jsonb_build_object('support', support)::text
+		FROM petition_results WHERE vote_id = $1 AND user_token = $2`,
+	ballotChoice: `
+		SELECT jsonb_build_object('option_ids', jsonb_agg(option_id ORDER BY option_id))::text
+		FROM choices_results WHERE vote_id = $1 AND user_token = $2
+		HAVING COUNT(*) > 0`,
+	ballotRanked: `
+		SELECT jsonb_build_object('option_ids', jsonb_agg(option_id ORDER BY rank))::text
+		FROM ranked_results WHERE vote_id = $1 AND user_token = $2
+		HAVING COUNT(*) > 0`,
+	ballotAllocation: `
+		SELECT jsonb_build_object('allocations',
+			jsonb_agg(jsonb_build_object('option_id', option_id, 'points', points) ORDER BY option_id))::text
+		FROM allocation_results WHERE vote_id = $1 AND user_token = $2
+		HAVING COUNT(*) > 0`,
+	ballotSurvey: `
+		SELECT jsonb_build_object('submitted', r.submitted, 'answers', COALESCE((
+			SELECT jsonb_agg(jsonb_build_object('question_id', a.question_id, 'rating', a.rating, 'text', a.text,
+				'option_ids', COALESCE((
+					SELECT jsonb_agg(o.option_id ORDER BY o.option_id) FROM survey_answer_options o
+					WHERE o.vote_id = a.vote_id AND o.user_token = a.user_token AND o.question_id = a.question_id
+				), '[]')) ORDER BY a.question_id)
+			FROM survey_answers a WHERE a.vote_id = r.vote_id AND a.user_token = r.user_token
+		), '[]'))::text
+		FROM survey_responses r WHERE r.vote_id = $1 AND r.user_token = $2`,
+	ballotFollowUps: `
+		SELECT jsonb_build_object('answers', jsonb_agg(jsonb_build_object('follow_up_id', a.follow_up_id,
+			'rating', a.rating, 'text', a.text, 'option_ids', COALESCE((
+				SELECT jsonb_agg(o.option_id ORDER BY o.option_id) FROM follow_up_answer_options o
+				WHERE o.vote_id = a.vote_id AND o.user_token = a.user_token AND o.follow_up_id = a.follow_up_id
+			), '[]')) ORDER BY a.follow_up_id))::text
+		FROM follow_up_answers a WHERE a.vote_id = $1 AND a.user_token = $2
+		HAVING COUNT(*) > 0`,
+}
+
+// AuditLog returns up to limit entries of the audit log following the
+// entry afterSeq of afterStream, ordered by stream and Seq. An empty
+// afterStream starts at the beginning.
+func (s *PostgresStorage) AuditLog(ctx context.Context, afterStream string, afterSeq int64, limit int) ([]audit.Entry, error) {
+	const op = "storage.postgresql.AuditLog"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT stream, seq, occurred_at, actor, request_id, action, entity, entity_id, old_value, new_value, prev_hash, hash
+		FROM audit_log
+		WHERE (stream, seq) > ($1, $2)
+		ORDER BY stream, seq
+		LIMIT $3`, afterStream, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := []audit.Entry{}
+	for rows.Next() {
+		var e audit.Entry
+		err := rows.Scan(&e.Stream, &e.Seq, &e.Time, &e.Actor, &e.RequestID, &e.Action, &e.Entity, &e.EntityID,
+			&e.OldValue, &e.NewValue, &e.PrevHash, &e.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return entries, nil
+}
+
+// appendAudit seals entry onto the end of its stream and stores it in tx.
+//
+// Appends to a stream are serialized by an advisory lock that is held
+// until tx ends, so appends come last in their transactions. This bounds
+// the throughput of a stream: ballots of one vote commit one at a time,
+// at most one per commit latency, while ballots of different votes do not
+// wait for each other.
+func appendAudit(ctx context.Context, tx pgx.Tx, entry *audit.Entry) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('audit:' || $1))`, entry.Stream); err != nil {
+		return err
+	}
+	var seq int64
+	prevHash := audit.GenesisHash
+	err := tx.QueryRow(ctx, `SELECT seq, hash FROM audit_log WHERE stream = $1 ORDER BY seq DESC LIMIT 1`, entry.Stream).
+		Scan(&seq, &prevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	entry.Seal(seq+1, prevHash)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO audit_log (stream, seq, occurred_at, actor, request_id, action, entity, entity_id,
+			old_value, new_value, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		entry.Stream, entry.Seq, entry.Time, entry.Actor, entry.RequestID, entry.Action, entry.Entity, entry.EntityID,
+		entry.OldValue, entry.NewValue, entry.PrevHash, entry.Hash)
+	return err
+}
+
+// auditStream names the chain the changes of an entity are appended to.
+// Every vote has its own, which also takes its ballots, petition steps and
+// lifecycle; the other entities change rarely and share one per kind.
+func auditStream(entity, entityId string) string {
+	if entity == auditVote {
+		return auditVote + ":" + entityId
+	}
+	return entity
+}
+
+// auditChange appends the change of an entity by the actor of ctx. Actions
+// are named after the entity and the change, such as "vote.update".
+func (s *PostgresStorage) auditChange(ctx context.Context, tx pgx.Tx, action, entity, entityId string, before, after interface{}) error {
+	entry, err := newAuditEntry(ctx, s.opts.clock.Now(), audit.Actor(ctx), action, entity, entityId, before, after)
+	if err != nil {
+		return err
+	}
+	return appendAudit(ctx, tx, entry)
+}
+
+// auditBallot appends the change of the ballot of voterId in a vote between
+// the snapshots before and after. The action is "ballot." followed by the
+// kind.
+func (s *PostgresStorage) auditBallot(ctx context.Context, tx pgx.Tx, kind string, voteId int, voterId, before, after string) error {
+	entry, err := newAuditEntry(ctx, s.opts.clock.Now(), voterId, "ballot."+kind, auditVote, strconv.Itoa(voteId), before, after)
+	if err != nil {
+		return err
+	}
+	return appendAudit(ctx, tx, entry)
+}
+
+// ballotSnapshot returns the ballot of voterId in a vote as JSON, or "".
+func ballotSnapshot(ctx context.Context, tx pgx.Tx, kind string, voteId int, voterId string) (string, error) {
+	var snapshot string
+	err := tx.QueryRow(ctx, ballotSnapshots[kind], voteId, voterId).Scan(&snapshot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return snapshot, err
+}
+
+// newAuditEntry returns an unsealed entry. The values before and after the
+// change are encoded as JSON; a string is taken to be JSON already and nil,
+// including a nil pointer, stands for no value.
+func newAuditEntry(ctx context.Context, now time.Time, actor, action, entity, entityId string, before, after interface{}) (*audit.Entry, error) {
+	entry := &audit.Entry{
+		Stream:    auditStream(entity, entityId),
+		Time:      now.UTC().Truncate(time.Microsecond),
+		Actor:     actor,
+		RequestID: audit.RequestID(ctx),
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityId,
+	}
+	var err error
+	if entry.OldValue, err = auditValue(before); err != nil {
+		return nil, err
+	}
+	if entry.NewValue, err = auditValue(after); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func auditValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("encoding audit value: %w", err)
+	}
+	if string(encoded) == "null" {
+		return "", nil
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Moderation states of a ballot comment. New comments wait in the queue
+// as pending until a moderator approves or rejects them; only approved
+// comments are shown to other citizens.
+const (
+	CommentPending  = "pending"
+	CommentApproved = "approved"
+	CommentRejected = "rejected"
+)
+
+var CommentStatuses = []string{CommentPending, CommentApproved, CommentRejected}
+
+// commentCategories are the vote types whose ballots may carry a comment.
+var commentCategories = []string{"rate", "choice"}
+
+// maxPublicComments bounds the approved comments returned with vote info.
+const maxPublicComments = 50
+
+// Comment is the free-text answer a citizen sends with a rate or choice
+// ballot. ReviewedAt is zero until a moderator has looked at it.
+type Comment struct {
+	ID         int
+	VoteID     int
+	Text       string
+	Status     string
+	Reason     string
+	CreatedAt  time.Time
+	ReviewedAt time.Time
+}
+
+func IsKnownCommentStatus(status string) bool {
+	return contains(CommentStatuses, status)
+}
+
+// ValidateComment checks a comment before it is stored with a ballot. The
+// text must already be trimmed; the status is pending, or rejected when
+// the profanity filter flagged the text.
+func ValidateComment(vote *Vote, comment *Comment) error {
+	if comment == nil {
+		return nil
+	}
+	if !contains(commentCategories, vote.Category) {
+		return fmt.Errorf("%w: %s votes do not accept comments", ErrInvalidBallot, vote.Category)
+	}
+	if comment.Text == "" || comment.Text != strings.TrimSpace(comment.Text) {
+		return fmt.Errorf("%w: comment must be non-empty trimmed text", ErrInvalidBallot)
+	}
+	if n := utf8.RuneCountInString(comment.Text); n > maxAnswerText {
+		return fmt.Errorf("%w: comment is longer than %d characters", ErrInvalidBallot, maxAnswerText)
+	}
+	if comment.Status != CommentPending && comment.Status != CommentRejected {
+		return fmt.Errorf("%w: new comment cannot be %q", ErrInvalidBallot, comment.Status)
+	}
+	return nil
+}
+
+// ValidateReview checks a moderator decision: a comment is either approved
+// or rejected, and a rejection needs a reason.
+func ValidateReview(status, reason string) error {
+	switch status {
+	case CommentApproved:
+		return nil
+	case CommentRejected:
+		if strings.TrimSpace(reason) == "" {
+			return errors.New("rejecting a comment requires a reason")
+		}
+		return nil
+	}
+	return fmt.Errorf("comment status must be %q or %q, got %q", CommentApproved, CommentRejected, status)
+}
+
+// commentColumns is the column list read by scanComment.
+const commentColumns = `id, vote_id, text, status, reason, created_at, reviewed_at`
+
+func scanComment(row pgx.Row, comment *Comment) error {
+	var reviewedAt *time.Time
+	err := row.Scan(&comment.ID, &comment.VoteID, &comment.Text, &comment.Status, &comment.Reason,
+		&comment.CreatedAt, &reviewedAt)
+	if err != nil {
+		return err
+	}
+	if reviewedAt != nil {
+		comment.ReviewedAt = *reviewedAt
+	}
+	return nil
+}
+
+func (s *PostgresStorage) fetchComments(ctx context.Context, query string, args ...interface{}) ([]Comment, error) {
+	const op = "storage.postgresql.fetchComments"
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	for rows.Next() {
+		var comment Comment
+		if err := scanComment(rows, &comment); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return comments, nil
+}
+
+// GetUserComment returns the comment voterId sent with its ballot, or nil if
+// there is none.
+func (s *PostgresStorage) GetUserComment(ctx context.Context, voterId string, voteId int) (*Comment, error) {
+	const op = "storage.postgresql.GetUserComment"
+
+	var comment Comment
+	err := scanComment(s.db.QueryRow(ctx, `
+		SELECT `+commentColumns+`
+		FROM ballot_comments
+		WHERE vote_id = $1 AND user_token = $2`, voteId, voterId), &comment)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &comment, nil
+}
+
+// getApprovedComments returns the newest approved comments of a vote.
+func (s *PostgresStorage) getApprovedComments(ctx context.Context, voteId int) ([]Comment, error) {
+	return s.fetchComments(ctx, `
+		SELECT `+commentColumns+`
+		FROM ballot_comments
+		WHERE vote_id = $1 AND status = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`, voteId, CommentApproved, maxPublicComments)
+}
+
+// ListComments returns the comments in a moderation state, oldest first.
+// voteId 0 lists comments of every vote.
+func (s *PostgresStorage) ListComments(ctx context.Context, status string, voteId int) ([]Comment, error) {
+	const op = "storage.postgresql.ListComments"
+
+	comments, err := s.fetchComments(ctx, `
+		SELECT `+commentColumns+`
+		FROM ballot_comments
+		WHERE status = $1 AND ($2 = 0 OR vote_id = $2)
+		ORDER BY created_at, id`, status, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return comments, nil
+}
+
+// ReviewComment records a moderator decision on a comment. The decision is
+// expected to have passed ValidateReview.
+func (s *PostgresStorage) ReviewComment(ctx context.Context, commentId int, status, reason string) (*Comment, error) {
+	const op = "storage.postgresql.ReviewComment"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var previous Comment
+	err = scanComment(tx.QueryRow(ctx, `SELECT `+commentColumns+` FROM ballot_comments WHERE id = $1 FOR UPDATE`, commentId), &previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w: %d", op, ErrCommentNotFound, commentId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var comment Comment
+	err = scanComment(tx.QueryRow(ctx, `
+		UPDATE ballot_comments
+		SET status = $2, reason = $3, reviewed_at = $4
+		WHERE id = $1
+		RETURNING `+commentColumns, commentId, status, strings.TrimSpace(reason), s.opts.clock.Now()), &comment)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.auditChange(ctx, tx, "comment.review", auditComment, strconv.Itoa(commentId), &previous, &comment); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &comment, nil
+}
+
+// saveComment stores the comment sent with a ballot. Resending the same
+// text keeps its moderation state; new text goes back to the queue. A nil
+// comment leaves the stored one untouched.
+func saveComment(ctx context.Context, tx pgx.Tx, voteId int, voterId string, comment *Comment, now time.Time) error {
+	if comment == nil {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO ballot_comments (vote_id, user_token, text, status, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (vote_id, user_token) DO UPDATE
+		SET text = EXCLUDED.text, status = EXCLUDED.status, reason = EXCLUDED.reason,
+			created_at = EXCLUDED.created_at, reviewed_at = NULL
+		WHERE ballot_comments.text <> EXCLUDED.text`,
+		voteId, voterId, comment.Text, comment.Status, comment.Reason, now)
+	return classifyError(err)
+}
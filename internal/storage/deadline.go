@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// checkVoteOpen reports whether a vote in the given state accepts ballots at
+// now. The window is checked as well, so ballots are refused on time even
+// before the background job has caught up with the state.
+func checkVoteOpen(voteId int, status string, start, end, now time.Time) error {
+	switch status {
+	case StatusDraft, StatusScheduled, StatusModeration:
+		return fmt.Errorf("%w: vote %d is %s", ErrVoteNotStarted, voteId, status)
+	case StatusClosed, StatusArchived, StatusRejected, StatusAwaitingResponse:
+		return fmt.Errorf("%w: vote %d is %s", ErrVoteClosed, voteId, status)
+	}
+	return checkVoteWindow(voteId, start, end, now)
+}
+
+// checkVoteWindow reports whether a vote accepts ballots at now. A zero start
+// time means the vote is open from creation; the end time is exclusive.
+func checkVoteWindow(voteId int, start, end, now time.Time) error {
+	if !start.IsZero() && now.Before(start) {
+		return fmt.Errorf("%w: vote %d opens at %s", ErrVoteNotStarted, voteId, start.Format(time.RFC3339))
+	}
+	if !end.IsZero() && !now.Before(end) {
+		return fmt.Errorf("%w: vote %d ended at %s", ErrVoteClosed, voteId, end.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped) by every Repository implementation.
+// Callers should match them with errors.Is.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrWrongVoteType     = errors.New("wrong vote type")
+	ErrVoteClosed        = errors.New("vote is closed")
+	ErrVoteNotStarted    = errors.New("vote has not started")
+	ErrInvalidOption     = errors.New("invalid option")
+	ErrInvalidQuestion   = errors.New("invalid question")
+	ErrInvalidBallot     = errors.New("invalid ballot")
+	ErrConflict          = errors.New("conflict")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrRulesLocked       = errors.New("ballot rules are locked")
+	ErrVoteHasBallots    = errors.New("vote has ballots")
+)
+
+// ErrCommentNotFound is returned when a moderator reviews a comment that
+// does not exist. It matches ErrNotFound as well.
+var ErrCommentNotFound = fmt.Errorf("comment %w", ErrNotFound)
+
+// ErrOrganizationNotFound is returned for an unknown organization, whether
+// it is read directly or referenced by a vote. It matches ErrNotFound as
+// well.
+var ErrOrganizationNotFound = fmt.Errorf("organization %w", ErrNotFound)
+
+// ErrTopicNotFound is returned for an unknown topic slug. It matches
+// ErrNotFound as well.
+var ErrTopicNotFound = fmt.Errorf("topic %w", ErrNotFound)
+
+// ErrBallotNotFound is returned when withdrawing a ballot that was never
+// cast or is already withdrawn. It matches ErrNotFound as well.
+var ErrBallotNotFound = fmt.Errorf("ballot %w", ErrNotFound)
+
+// ErrRoleNotFound is returned when revoking a role the user does not have.
+// It matches ErrNotFound as well.
+var ErrRoleNotFound = fmt.Errorf("role assignment %w", ErrNotFound)
+
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+)
+
+// optionConstraints tie ballot rows to an existing option of the vote.
+var optionConstraints = []string{"choices_results_option_fkey", "ranked_results_option_fkey", "allocation_results_option_fkey",
+	"survey_answer_options_option_fkey", "follow_up_answer_options_option_fkey", "follow_up_triggers_option_fkey"}
+
+// questionConstraints tie answers to an existing survey question or
+// follow-up of the vote.
+var questionConstraints = []string{"survey_answers_question_fkey", "follow_up_answers_follow_up_fkey"}
+
+// classifyError wraps driver errors with the matching sentinel error while
+// keeping the original error in the chain for logging.
+func classifyError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgForeignKeyViolation:
+			if contains(optionConstraints, pgErr.ConstraintName) {
+				return fmt.Errorf("%w: %w", ErrInvalidOption, err)
+			}
+			if contains(questionConstraints, pgErr.ConstraintName) {
+				return fmt.Errorf("%w: %w", ErrInvalidQuestion, err)
+			}
+			return fmt.Errorf("%w: %w", ErrNotFound, err)
+		case pgCheckViolation:
+			return fmt.Errorf("%w: %w", ErrInvalidBallot, err)
+		case pgUniqueViolation:
+			return fmt.Errorf("%w: %w", ErrConflict, err)
+		}
+	}
+	return err
+}
+
+func checkCategory(voteId int, actual string, expected ...string) error {
+	if !contains(expected, actual) {
+		return fmt.Errorf("%w: vote %d is %q, not %q", ErrWrongVoteType, voteId, actual, strings.Join(expected, " or "))
+	}
+	return nil
+}
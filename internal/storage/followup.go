@@ -0,0 +1,433 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+// followUpCategories are the vote types that may ask follow-up questions.
+var followUpCategories = []string{"rate", "choice"}
+
+// FollowUp is a question asked after a rate or choice ballot when its
+// trigger fires. It takes the same answers as a survey question of its kind.
+type FollowUp struct {
+	ID       int
+	Position int
+	Kind     string
+	Text     string
+	Options  []VoteOption
+	Scale    RateScale
+	Trigger  FollowUpTrigger
+}
+
+// FollowUpTrigger decides which ballots a follow-up applies to. A rate vote
+// triggers on a rating between MinRating and MaxRating, a choice vote on
+// selecting any of OptionIDs. When a vote is stored, options named in
+// Options are resolved to their IDs.
+type FollowUpTrigger struct {
+	MinRating int
+	MaxRating int
+	OptionIDs []int
+	Options   []string
+}
+
+func (f FollowUp) question() Question {
+	return Question{ID: f.ID, Position: f.Position, Kind: f.Kind, Text: f.Text, Options: f.Options, Scale: f.Scale}
+}
+
+// triggeredBy reports whether a ballot fires the follow-up. rating is nil
+// without a rate ballot, optionIds empty without a choice ballot.
+func (f FollowUp) triggeredBy(rating *int, optionIds []int) bool {
+	if rating != nil && len(f.Trigger.OptionIDs) == 0 {
+		return f.Trigger.MinRating <= *rating && *rating <= f.Trigger.MaxRating
+	}
+	for _, id := range optionIds {
+		if containsInt(f.Trigger.OptionIDs, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func followUpQuestions(followUps []FollowUp) []Question {
+	questions := make([]Question, 0, len(followUps))
+	for _, followUp := range followUps {
+		questions = append(questions, followUp.question())
+	}
+	return questions
+}
+
+func copyFollowUps(followUps []FollowUp) []FollowUp {
+	copied := make([]FollowUp, 0, len(followUps))
+	for _, followUp := range followUps {
+		followUp.Options = append([]VoteOption{}, followUp.Options...)
+		followUp.Trigger.OptionIDs = append([]int{}, followUp.Trigger.OptionIDs...)
+		followUp.Trigger.Options = nil
+		copied = append(copied, followUp)
+	}
+	return copied
+}
+
+// resolveTriggers turns the trigger options named by text into option IDs
+// of the stored vote options.
+func resolveTriggers(followUps []FollowUp, options []VoteOption) []FollowUp {
+	resolved := make([]FollowUp, 0, len(followUps))
+	for _, followUp := range followUps {
+		ids := append([]int{}, followUp.Trigger.OptionIDs...)
+		for _, text := range followUp.Trigger.Options {
+			if id := optionIDByText(options, text); id != 0 && !containsInt(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		followUp.Trigger.OptionIDs = ids
+		followUp.Trigger.Options = nil
+		resolved = append(resolved, followUp)
+	}
+	return resolved
+}
+
+// followUpsDropped reports whether an edit changes one of followUps the way
+// questionsDropped does for survey questions or changes its trigger, which
+// would delete answers to it. The triggers of edited must be resolved.
+func followUpsDropped(followUps, edited []FollowUp) bool {
+	if questionsDropped(followUpQuestions(followUps), followUpQuestions(edited)) {
+		return true
+	}
+	byID := make(map[int]FollowUpTrigger, len(edited))
+	byText := make(map[string]FollowUpTrigger, len(edited))
+	for _, followUp := range edited {
+		if followUp.ID != 0 {
+			byID[followUp.ID] = followUp.Trigger
+		} else {
+			byText[followUp.Text] = followUp.Trigger
+		}
+	}
+	for _, followUp := range followUps {
+		trigger, ok := byID[followUp.ID]
+		if !ok {
+			trigger = byText[followUp.Text]
+		}
+		if !sameTrigger(followUp.Trigger, trigger) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameTrigger reports whether two resolved triggers fire on the same
+// ballots. Rating bounds only matter without trigger options.
+func sameTrigger(a, b FollowUpTrigger) bool {
+	if len(a.OptionIDs) == 0 && len(b.OptionIDs) == 0 {
+		return a.MinRating == b.MinRating && a.MaxRating == b.MaxRating
+	}
+	if len(a.OptionIDs) != len(b.OptionIDs) {
+		return false
+	}
+	for _, id := range a.OptionIDs {
+		if !containsInt(b.OptionIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// followUpTriggered is the SQL condition under which follow-up q applies to
+// the current ballot of voter voterId in vote voteId; both are SQL expressions.
+func followUpTriggered(voteId, voterId string) string {
+	return `(EXISTS (
+			SELECT 1 FROM rate_results r
+			WHERE r.vote_id = ` + voteId + ` AND r.user_token = ` + voterId + `
+				AND r.rate BETWEEN q.trigger_min AND q.trigger_max
+		) OR EXISTS (
+			SELECT 1 FROM choices_results c
+			JOIN follow_up_triggers t ON t.option_id = c.option_id AND t.follow_up_id = q.id
+			WHERE c.vote_id = ` + voteId + ` AND c.user_token = ` + voterId + `
+		))`
+}
+
+func (s *PostgresStorage) getFollowUps(ctx context.Context, voteId int) ([]FollowUp, error) {
+	const op = "storage.postgresql.getFollowUps"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT id, position, kind, text, rate_min, rate_max, rate_step,
+			COALESCE(trigger_min, 0), COALESCE(trigger_max, 0),
+			ARRAY(SELECT option_id FROM follow_up_triggers t WHERE t.follow_up_id = q.id ORDER BY option_id)
+		FROM follow_ups q
+		WHERE vote_id = $1
+		ORDER BY position, id`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	followUps := []FollowUp{}
+	index := make(map[int]int)
+	for rows.Next() {
+		followUp := FollowUp{Options: []VoteOption{}}
+		err := rows.Scan(&followUp.ID, &followUp.Position, &followUp.Kind, &followUp.Text,
+			&followUp.Scale.Min, &followUp.Scale.Max, &followUp.Scale.Step,
+			&followUp.Trigger.MinRating, &followUp.Trigger.MaxRating, &followUp.Trigger.OptionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[followUp.ID] = len(followUps)
+		followUps = append(followUps, followUp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = s.db.Query(ctx, `
+		SELECT o.follow_up_id, o.id, o.option, o.description, o.image, o.position
+		FROM follow_up_options o
+		JOIN follow_ups q ON q.id = o.follow_up_id
+		WHERE q.vote_id = $1
+		ORDER BY o.position, o.id`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var followUpId int
+		var option VoteOption
+		if err := rows.Scan(&followUpId, &option.ID, &option.Text, &option.Description, &option.Image, &option.Position); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if i, ok := index[followUpId]; ok {
+			followUps[i].Options = append(followUps[i].Options, option)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return followUps, nil
+}
+
+// GetPendingFollowUps returns the follow-ups triggered by the ballot of
+// voterId that it has not answered yet.
+func (s *PostgresStorage) GetPendingFollowUps(ctx context.Context, voterId string, voteId int) ([]FollowUp, error) {
+	const op = "storage.postgresql.GetPendingFollowUps"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT q.id
+		FROM follow_ups q
+		WHERE q.vote_id = $1 AND `+followUpTriggered("$1", "$2")+`
+			AND NOT EXISTS (
+				SELECT 1 FROM follow_up_answers a
+				WHERE a.vote_id = $1 AND a.user_token = $2 AND a.follow_up_id = q.id
+			)`, voteId, voterId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	pending, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(pending) == 0 {
+		return []FollowUp{}, nil
+	}
+
+	followUps, err := s.getFollowUps(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	filtered := make([]FollowUp, 0, len(pending))
+	for _, followUp := range followUps {
+		if containsInt(pending, followUp.ID) {
+			filtered = append(filtered, followUp)
+		}
+	}
+	return filtered, nil
+}
+
+// AnswerFollowUps stores answers to follow-ups triggered by the current
+// ballot of voterId, replacing earlier answers to the same follow-ups.
+func (s *PostgresStorage) AnswerFollowUps(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer) error {
+	const op = "storage.postgresql.AnswerFollowUps"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, followUpCategories...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotFollowUps, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT q.id FROM follow_ups q
+		WHERE q.vote_id = $1 AND `+followUpTriggered("$1", "$2"), voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	triggered, err := scanIDs(rows)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := s.opts.clock.Now()
+	for _, answer := range answers {
+		if !containsInt(triggered, answer.QuestionID) {
+			return fmt.Errorf("%s: %w: follow-up %d is not triggered by the ballot", op, ErrInvalidBallot, answer.QuestionID)
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM follow_up_answers WHERE vote_id = $1 AND user_token = $2 AND follow_up_id = $3`,
+			voteId, voterId, answer.QuestionID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		var text *string
+		if answer.Text != "" {
+			text = &answer.Text
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO follow_up_answers (vote_id, user_token, follow_up_id, rating, text, answered_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			voteId, voterId, answer.QuestionID, answer.Rating, text, now)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+		if len(answer.OptionIDs) == 0 {
+			continue
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO follow_up_answer_options (vote_id, user_token, follow_up_id, option_id)
+			SELECT $1, $2, $3, unnest($4::int[])`,
+			voteId, voterId, answer.QuestionID, answer.OptionIDs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+	}
+	after, err := ballotSnapshot(ctx, tx, ballotFollowUps, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.auditBallot(ctx, tx, ballotFollowUps, voteId, voterId, before, after); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// pruneFollowUpAnswers deletes answers to follow-ups that the ballot no
+// longer triggers or that no longer fit the follow-up after an edit. An
+// empty voterId prunes the answers of every user.
+func pruneFollowUpAnswers(ctx context.Context, tx pgx.Tx, voteId int, voterId string) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM follow_up_answers a
+		USING follow_ups q
+		WHERE q.id = a.follow_up_id AND a.vote_id = $1 AND ($2 = '' OR a.user_token = $2)
+			AND (NOT `+followUpTriggered("a.vote_id", "a.user_token")+`
+				OR `+answerMismatch("follow_up_answer_options", "follow_up_id")+`)`, voteId, voterId)
+	return err
+}
+
+// replaceFollowUps makes the follow-ups of a vote equal to followUps, the
+// way replaceQuestions does for survey questions.
+func replaceFollowUps(ctx context.Context, tx pgx.Tx, voteId int, followUps []FollowUp) ([]FollowUp, error) {
+	keepIDs := []int{}
+	keepTexts := []string{}
+	for _, followUp := range followUps {
+		if followUp.ID != 0 {
+			keepIDs = append(keepIDs, followUp.ID)
+		} else {
+			keepTexts = append(keepTexts, followUp.Text)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM follow_ups WHERE vote_id = $1 AND id <> ALL($2) AND text <> ALL($3)`, voteId, keepIDs, keepTexts)
+	if err != nil {
+		return nil, err
+	}
+	renamed := make(map[int]string, len(keepIDs))
+	for _, followUp := range followUps {
+		if followUp.ID != 0 {
+			renamed[followUp.ID] = followUp.Text
+		}
+	}
+	if err := moveRenamed(ctx, tx, "follow_ups", "vote_id", "text", voteId, renamed); err != nil {
+		return nil, err
+	}
+
+	stored := make([]FollowUp, 0, len(followUps))
+	for position, followUp := range followUps {
+		followUp.Position = position
+		var triggerMin, triggerMax *int
+		if len(followUp.Trigger.OptionIDs) == 0 {
+			triggerMin, triggerMax = &followUp.Trigger.MinRating, &followUp.Trigger.MaxRating
+		}
+		if followUp.ID != 0 {
+			tag, err := tx.Exec(ctx, `
+				UPDATE follow_ups
+				SET kind = $3, text = $4, position = $5, rate_min = $6, rate_max = $7, rate_step = $8,
+					trigger_min = $9, trigger_max = $10
+				WHERE id = $1 AND vote_id = $2`,
+				followUp.ID, voteId, followUp.Kind, followUp.Text, followUp.Position,
+				followUp.Scale.Min, followUp.Scale.Max, followUp.Scale.Step, triggerMin, triggerMax)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+			if tag.RowsAffected() == 0 {
+				return nil, fmt.Errorf("%w: follow-up %d does not belong to vote %d", ErrInvalidQuestion, followUp.ID, voteId)
+			}
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO follow_ups (vote_id, kind, text, position, rate_min, rate_max, rate_step, trigger_min, trigger_max)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				ON CONFLICT (vote_id, text)
+				DO UPDATE SET kind = EXCLUDED.kind, position = EXCLUDED.position, rate_min = EXCLUDED.rate_min,
+					rate_max = EXCLUDED.rate_max, rate_step = EXCLUDED.rate_step,
+					trigger_min = EXCLUDED.trigger_min, trigger_max = EXCLUDED.trigger_max
+				RETURNING id`,
+				voteId, followUp.Kind, followUp.Text, followUp.Position,
+				followUp.Scale.Min, followUp.Scale.Max, followUp.Scale.Step, triggerMin, triggerMax).Scan(&followUp.ID)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+		}
+
+		if followUp.Options, err = replaceQuestionOptions(ctx, tx, "follow_up_options", "follow_up_id", followUp.ID, followUp.Options); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM follow_up_triggers WHERE follow_up_id = $1`, followUp.ID); err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO follow_up_triggers (follow_up_id, vote_id, option_id)
+			SELECT $1, $2, unnest($3::int[])`, followUp.ID, voteId, followUp.Trigger.OptionIDs)
+		if err != nil {
+			return nil, classifyError(err)
+		}
+		stored = append(stored, followUp)
+	}
+
+	if err := pruneFollowUpAnswers(ctx, tx, voteId, ""); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// scanIDs reads single-column rows of IDs and closes rows.
+func scanIDs(rows pgx.Rows) ([]int, error) {
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
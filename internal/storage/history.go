@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// Actions in the ballot history of a user.
+const (
+	BallotCast      = "cast"
+	BallotChanged   = "change"
+	BallotWithdrawn = "withdraw"
+)
+
+// BallotChange is an entry of the ballot history of a user in one vote. The
+// history keeps when a ballot changed; the audit log keeps the values.
+type BallotChange struct {
+	Action    string
+	ChangedAt time.Time
+}
+
+// ballotKinds map vote categories to the kind of their ballots.
+var ballotKinds = map[string]string{
+	"rate":      ballotRate,
+	"petition":  ballotPetition,
+	"choice":    ballotChoice,
+	"ranked":    ballotRanked,
+	"score":     ballotAllocation,
+	"quadratic": ballotAllocation,
+	"survey":    ballotSurvey,
+}
+
+// ballotTables hold the ballots of each kind. Survey answers follow their
+// response through ON DELETE CASCADE.
+var ballotTables = map[string]string{
+	ballotRate:       "rate_results",
+	ballotPetition:   "petition_results",
+	ballotChoice:     "choices_results",
+	ballotRanked:     "ranked_results",
+	ballotAllocation: "allocation_results",
+	ballotSurvey:     "survey_responses",
+}
+
+// ballotAction returns the history action for a ballot that changed from
+// the snapshot before to after, or "" if it did not change.
+func ballotAction(before, after string) string {
+	switch {
+	case before == after:
+		return ""
+	case after == "":
+		return BallotWithdrawn
+	case before == "":
+		return BallotCast
+	}
+	return BallotChanged
+}
+
+// GetBallotHistory returns the changes of the ballot of voterId in a vote,
+// the latest first.
+func (s *PostgresStorage) GetBallotHistory(ctx context.Context, voterId string, voteId int) ([]BallotChange, error) {
+	const op = "storage.postgresql.GetBallotHistory"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT action, changed_at
+		FROM ballot_history
+		WHERE user_token = $1 AND vote_id = $2
+		ORDER BY changed_at DESC, id DESC`, voterId, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	history := []BallotChange{}
+	for rows.Next() {
+		var change BallotChange
+		if err := rows.Scan(&change.Action, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		history = append(history, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return history, nil
+}
+
+// WithdrawVote removes the ballot of voterId from a vote that still accepts
+// ballots, together with its follow-up answers and comment, so that it no
+// longer counts. A user without a ballot gets ErrBallotNotFound.
+func (s *PostgresStorage) WithdrawVote(ctx context.Context, voterId string, voteId int) error {
+	const op = "storage.postgresql.WithdrawVote"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, Categories...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	var category string
+	if err := tx.QueryRow(ctx, `SELECT category FROM votes WHERE id = $1`, voteId).Scan(&category); err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	kind := ballotKinds[category]
+	before, err := ballotSnapshot(ctx, tx, kind, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if before == "" {
+		return fmt.Errorf("%s: vote %d: %w", op, voteId, ErrBallotNotFound)
+	}
+
+	tables := []string{ballotTables[kind]}
+	if contains(followUpCategories, category) {
+		tables = append(tables, "follow_up_answers")
+	}
+	if contains(commentCategories, category) {
+		tables = append(tables, "ballot_comments")
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE vote_id = $1 AND user_token = $2`, voteId, voterId); err != nil {
+			return fmt.Errorf("%s: %s: %w", op, table, err)
+		}
+	}
+	if err := s.recordBallot(ctx, tx, kind, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// recordBallot adds the change of the ballot of voterId in a vote from
+// before, a snapshot taken ahead of the change, to its current state to the
+// ballot history and the audit log.
+func (s *PostgresStorage) recordBallot(ctx context.Context, tx pgx.Tx, kind string, voteId int, voterId, before string) error {
+	after, err := ballotSnapshot(ctx, tx, kind, voteId, voterId)
+	if err != nil {
+		return err
+	}
+	if action := ballotAction(before, after); action != "" {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ballot_history (vote_id, user_token, action, changed_at)
+			VALUES ($1, $2, $3, $4)`,
+			voteId, voterId, action, s.opts.clock.Now())
+		if err != nil {
+			return err
+		}
+	}
+	return s.auditBallot(ctx, tx, kind, voteId, voterId, before, after)
+}
@@ -0,0 +1,500 @@
+package storage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/jackc/pgx/v5"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ImportFormat string
+
+const (
+	FormatJSON ImportFormat = "json"
+	FormatYAML ImportFormat = "yaml"
+	FormatCSV  ImportFormat = "csv"
+)
+
+// csvOptionSeparator splits the options and topics columns of a CSV row
+// into separate values.
+const csvOptionSeparator = "|"
+
+// VoteRecord is the on-disk representation of a vote. StartTime and EndTime
+// are RFC 3339 timestamps; StartsIn and EndsIn are durations relative to the
+// import time and are used when the matching timestamp is empty. A vote
+// without a start opens immediately. Without an explicit status a new vote
+// is published right away, see InitialStatus.
+type VoteRecord struct {
+	ExternalKey   string           `json:"external_key" yaml:"external_key"`
+	Category      string           `json:"category" yaml:"category"`
+	Name          string           `json:"name" yaml:"name"`
+	Description   string           `json:"description" yaml:"description"`
+	Organization  string           `json:"organization" yaml:"organization"`
+	Topics        []string         `json:"topics" yaml:"topics"`
+	Photo         string           `json:"photo" yaml:"photo"`
+	StartTime     string           `json:"start_time" yaml:"start_time"`
+	StartsIn      string           `json:"starts_in" yaml:"starts_in"`
+	EndTime       string           `json:"end_time" yaml:"end_time"`
+	EndsIn        string           `json:"ends_in" yaml:"ends_in"`
+	Options       []RecordOption   `json:"options" yaml:"options"`
+	Questions     []RecordQuestion `json:"questions" yaml:"questions"`
+	FollowUps     []RecordFollowUp `json:"follow_ups" yaml:"follow_ups"`
+	RateMin       int              `json:"rate_min" yaml:"rate_min"`
+	RateMax       int              `json:"rate_max" yaml:"rate_max"`
+	RateStep      int              `json:"rate_step" yaml:"rate_step"`
+	MinSelections int              `json:"min_selections" yaml:"min_selections"`
+	MaxSelections int              `json:"max_selections" yaml:"max_selections"`
+	Budget        int              `json:"budget" yaml:"budget"`
+	SignatureGoal int              `json:"signature_goal" yaml:"signature_goal"`
+	Status        string           `json:"status" yaml:"status"`
+}
+
+// RecordQuestion is a question of a survey vote. Surveys can only be
+// imported from JSON and YAML.
+type RecordQuestion struct {
+	Kind     string         `json:"kind" yaml:"kind"`
+	Text     string         `json:"text" yaml:"text"`
+	Required bool           `json:"required" yaml:"required"`
+	Options  []RecordOption `json:"options" yaml:"options"`
+	RateMin  int            `json:"rate_min" yaml:"rate_min"`
+	RateMax  int            `json:"rate_max" yaml:"rate_max"`
+	RateStep int            `json:"rate_step" yaml:"rate_step"`
+}
+
+// RecordFollowUp is a follow-up question of a rate or choice vote. It is
+// triggered by a rating between trigger_min and trigger_max, or by
+// selecting one of the trigger_options, given as option texts.
+type RecordFollowUp struct {
+	RecordQuestion `yaml:",inline"`
+	TriggerMin     int      `json:"trigger_min" yaml:"trigger_min"`
+	TriggerMax     int      `json:"trigger_max" yaml:"trigger_max"`
+	TriggerOptions []string `json:"trigger_options" yaml:"trigger_options"`
+}
+
+// RecordOption is an option of a choice vote. In JSON and YAML it is either
+// a plain string with the option text or an object with text, description
+// and image.
+type RecordOption struct {
+	Text        string `json:"text" yaml:"text"`
+	Description string `json:"description" yaml:"description"`
+	Image       string `json:"image" yaml:"image"`
+}
+
+func (o *RecordOption) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*o = RecordOption{Text: text}
+		return nil
+	}
+	type plain RecordOption
+	return json.Unmarshal(data, (*plain)(o))
+}
+
+func (o *RecordOption) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*o = RecordOption{Text: node.Value}
+		return nil
+	}
+	type plain RecordOption
+	return node.Decode((*plain)(o))
+}
+
+type ImportRecord struct {
+	Row  int
+	Vote Vote
+	Err  error
+}
+
+type ImportOptions struct {
+	DryRun bool
+	Upsert bool
+}
+
+type ImportError struct {
+	Row         int
+	ExternalKey string
+	Err         error
+}
+
+func (e ImportError) Error() string {
+	if e.ExternalKey != "" {
+		return fmt.Sprintf("row %d (%s): %v", e.Row, e.ExternalKey, e.Err)
+	}
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+type ImportReport struct {
+	Created   int
+	Updated   int
+	Committed bool
+	Errors    []ImportError
+}
+
+func FormatFromPath(path string) (ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("cannot detect import format of %q", path)
+	}
+}
+
+func DecodeVotes(r io.Reader, format ImportFormat, now time.Time) ([]ImportRecord, error) {
+	const op = "storage.import.DecodeVotes"
+
+	var rows []decodedRow
+	switch format {
+	case FormatJSON, FormatYAML:
+		var records []VoteRecord
+		var err error
+		if format == FormatJSON {
+			err = json.NewDecoder(r).Decode(&records)
+		} else if err = yaml.NewDecoder(r).Decode(&records); errors.Is(err, io.EOF) {
+			err = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for i, record := range records {
+			rows = append(rows, decodedRow{record: record, row: i + 1})
+		}
+	case FormatCSV:
+		var err error
+		if rows, err = decodeCSV(r); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported format %q", op, format)
+	}
+
+	result := make([]ImportRecord, 0, len(rows))
+	for _, row := range rows {
+		if row.err != nil {
+			result = append(result, ImportRecord{Row: row.row, Vote: Vote{ExternalKey: row.record.ExternalKey}, Err: row.err})
+			continue
+		}
+		vote, err := row.record.toVote(now)
+		result = append(result, ImportRecord{Row: row.row, Vote: vote, Err: err})
+	}
+	return result, nil
+}
+
+// decodedRow is a record together with its position in the source file and
+// any error found while decoding that particular row.
+type decodedRow struct {
+	record VoteRecord
+	row    int
+	err    error
+}
+
+func decodeCSV(r io.Reader) ([]decodedRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+
+	var rows []decodedRow
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		row := decodedRow{row: line, record: VoteRecord{
+			ExternalKey:  get("external_key"),
+			Category:     get("category"),
+			Name:         get("name"),
+			Description:  get("description"),
+			Organization: get("organization"),
+			Photo:        get("photo"),
+			StartTime:    get("start_time"),
+			StartsIn:     get("starts_in"),
+			EndTime:      get("end_time"),
+			EndsIn:       get("ends_in"),
+			Status:       get("status"),
+		}}
+		for _, column := range []struct {
+			name  string
+			field *int
+		}{
+			{"rate_min", &row.record.RateMin}, {"rate_max", &row.record.RateMax}, {"rate_step", &row.record.RateStep},
+			{"min_selections", &row.record.MinSelections}, {"max_selections", &row.record.MaxSelections},
+			{"budget", &row.record.Budget}, {"signature_goal", &row.record.SignatureGoal},
+		} {
+			if value := get(column.name); value != "" {
+				n, err := strconv.Atoi(value)
+				if err != nil && row.err == nil {
+					row.err = fmt.Errorf("invalid %s %q", column.name, value)
+				}
+				*column.field = n
+			}
+		}
+		if topics := get("topics"); topics != "" {
+			for _, topic := range strings.Split(topics, csvOptionSeparator) {
+				row.record.Topics = append(row.record.Topics, strings.TrimSpace(topic))
+			}
+		}
+		if options := get("options"); options != "" {
+			for _, option := range strings.Split(options, csvOptionSeparator) {
+				row.record.Options = append(row.record.Options, RecordOption{Text: strings.TrimSpace(option)})
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r VoteRecord) toVote(now time.Time) (Vote, error) {
+	vote := Vote{
+		ExternalKey:   r.ExternalKey,
+		Category:      r.Category,
+		Name:          r.Name,
+		Description:   r.Description,
+		Organization:  r.Organization,
+		Topics:        append([]string{}, r.Topics...),
+		Photo:         r.Photo,
+		Options:       make([]VoteOption, 0, len(r.Options)),
+		Scale:         RateScale{Min: r.RateMin, Max: r.RateMax, Step: r.RateStep},
+		Selection:     SelectionRange{Min: r.MinSelections, Max: r.MaxSelections},
+		Budget:        r.Budget,
+		SignatureGoal: r.SignatureGoal,
+		Status:        r.Status,
+	}
+	for _, option := range r.Options {
+		vote.Options = append(vote.Options, VoteOption{Text: option.Text, Description: option.Description, Image: option.Image})
+	}
+	for _, record := range r.Questions {
+		vote.Questions = append(vote.Questions, record.toQuestion())
+	}
+	for _, record := range r.FollowUps {
+		question := record.toQuestion()
+		vote.FollowUps = append(vote.FollowUps, FollowUp{
+			Kind:    question.Kind,
+			Text:    question.Text,
+			Options: question.Options,
+			Scale:   question.Scale,
+			Trigger: FollowUpTrigger{MinRating: record.TriggerMin, MaxRating: record.TriggerMax, Options: record.TriggerOptions},
+		})
+	}
+
+	var err error
+	if vote.StartTime, err = parseRecordTime("start", r.StartTime, r.StartsIn, now); err != nil {
+		return vote, err
+	}
+	if vote.EndTime, err = parseRecordTime("end", r.EndTime, r.EndsIn, now); err != nil {
+		return vote, err
+	}
+	if vote.Status == "" {
+		vote.Status = InitialStatus(&vote, now)
+	}
+	return vote, nil
+}
+
+func (r RecordQuestion) toQuestion() Question {
+	question := Question{
+		Kind:     r.Kind,
+		Text:     r.Text,
+		Required: r.Required,
+		Options:  make([]VoteOption, 0, len(r.Options)),
+		Scale:    RateScale{Min: r.RateMin, Max: r.RateMax, Step: r.RateStep},
+	}
+	for _, option := range r.Options {
+		question.Options = append(question.Options, VoteOption{Text: option.Text, Description: option.Description, Image: option.Image})
+	}
+	return question
+}
+
+func parseRecordTime(field, timestamp, relative string, now time.Time) (time.Time, error) {
+	switch {
+	case timestamp != "":
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s_time: %w", field, err)
+		}
+		return t, nil
+	case relative != "":
+		d, err := time.ParseDuration(relative)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %ss_in: %w", field, err)
+		}
+		return now.Add(d), nil
+	}
+	return time.Time{}, nil
+}
+
+// ImportVotes writes the records in a single transaction. Every row is
+// validated and inserted in its own savepoint so that all failing rows are
+// reported; the transaction is committed only when no row failed and the
+// import is not a dry run. Upserting keeps the status of existing votes.
+// Every imported vote is audited with the actor "system:import".
+func (s *PostgresStorage) ImportVotes(ctx context.Context, records []ImportRecord, opts ImportOptions) (*ImportReport, error) {
+	const op = "storage.postgresql.ImportVotes"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	report := &ImportReport{}
+	now := s.opts.clock.Now()
+	ctx = audit.WithActor(ctx, actorImport)
+	for _, record := range records {
+		fail := func(err error) {
+			report.Errors = append(report.Errors, ImportError{Row: record.Row, ExternalKey: record.Vote.ExternalKey, Err: err})
+		}
+
+		if record.Err != nil {
+			fail(record.Err)
+			continue
+		}
+		if err := ValidateVote(&record.Vote, now); err != nil {
+			fail(err)
+			continue
+		}
+
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		inserted, err := s.importVote(ctx, sp, &record.Vote, opts.Upsert)
+		if err != nil {
+			if rbErr := sp.Rollback(ctx); rbErr != nil {
+				return nil, fmt.Errorf("%s: %w", op, rbErr)
+			}
+			fail(err)
+			continue
+		}
+		if err := sp.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if inserted {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	if len(report.Errors) > 0 || opts.DryRun {
+		return report, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	report.Committed = true
+	return report, nil
+}
+
+func (s *PostgresStorage) importVote(ctx context.Context, tx pgx.Tx, vote *Vote, upsert bool) (bool, error) {
+	conflict := `DO NOTHING`
+	if upsert {
+		conflict = `DO UPDATE SET category = EXCLUDED.category, name = EXCLUDED.name,
+			description = EXCLUDED.description, organization = EXCLUDED.organization,
+			photo = EXCLUDED.photo, start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+			rate_min = EXCLUDED.rate_min, rate_max = EXCLUDED.rate_max, rate_step = EXCLUDED.rate_step,
+			min_selections = EXCLUDED.min_selections, max_selections = EXCLUDED.max_selections,
+			budget = EXCLUDED.budget, signature_goal = EXCLUDED.signature_goal,
+			organization_id = EXCLUDED.organization_id`
+	}
+
+	var previous *Vote
+	if upsert && vote.ExternalKey != "" {
+		var existing Vote
+		err := scanVote(tx.QueryRow(ctx, `SELECT `+voteColumns+` FROM votes WHERE external_key = $1 FOR UPDATE`, vote.ExternalKey), &existing)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return false, err
+		default:
+			if err := s.loadVoteDetails(ctx, &existing); err != nil {
+				return false, err
+			}
+			if err := checkRulesChange(ctx, tx, &existing, vote); err != nil {
+				return false, err
+			}
+			previous = &existing
+		}
+	}
+
+	organization := Vote{Organization: vote.Organization}
+	if err := resolveOrganization(ctx, tx, &organization, true); err != nil {
+		return false, err
+	}
+
+	var voteID int
+	var inserted bool
+	err := tx.QueryRow(ctx, `
+		INSERT INTO votes (category, name, description, organization, photo, start_time, end_time, external_key,
+			rate_min, rate_max, rate_step, min_selections, max_selections, budget, status, signature_goal, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (external_key) `+conflict+`
+		RETURNING id, xmax = 0`,
+		vote.Category, vote.Name, vote.Description, organization.Organization, vote.Photo, nullTime(vote.StartTime), vote.EndTime, vote.ExternalKey,
+		vote.Scale.Min, vote.Scale.Max, vote.Scale.Step, vote.Selection.Min, vote.Selection.Max, vote.Budget, vote.Status, vote.SignatureGoal,
+		nullID(organization.OrganizationID)).Scan(&voteID, &inserted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("%w: vote with external key %q already exists", ErrConflict, vote.ExternalKey)
+	}
+	if err != nil {
+		return false, classifyError(err)
+	}
+
+	options, err := replaceOptions(ctx, tx, voteID, vote.Options)
+	if err != nil {
+		return false, err
+	}
+	questions, err := replaceQuestions(ctx, tx, voteID, vote.Questions)
+	if err != nil {
+		return false, err
+	}
+	followUps, err := replaceFollowUps(ctx, tx, voteID, resolveTriggers(vote.FollowUps, options))
+	if err != nil {
+		return false, err
+	}
+	if err := replaceTopics(ctx, tx, voteID, vote.Topics); err != nil {
+		return false, err
+	}
+
+	imported := *vote
+	imported.ID = voteID
+	imported.Organization, imported.OrganizationID = organization.Organization, organization.OrganizationID
+	imported.Options, imported.Questions, imported.FollowUps = options, questions, followUps
+	if previous != nil {
+		imported.Status = previous.Status
+	}
+	if err := s.auditChange(ctx, tx, "vote.import", auditVote, strconv.Itoa(voteID), previous, &imported); err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
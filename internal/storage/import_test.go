@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeVotes(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	want := []ImportRecord{
+		{Row: 1, Vote: Vote{
+			ExternalKey:  "park",
+			Category:     "choice",
+			Name:         "Новый парк",
+			Organization: "Администрация",
+			Topics:       []string{"parks", "ecology"},
+			Options:      []VoteOption{{Text: "Да"}, {Text: "Нет"}},
+			Selection:    SelectionRange{Max: 1},
+			EndTime:      validateNow.Add(48 * time.Hour),
+			Status:       StatusOpen,
+		}},
+		{Row: 2, Vote: Vote{
+			ExternalKey: "library",
+			Category:    "rate",
+			Name:        "Часы работы библиотеки",
+			Topics:      []string{},
+			Options:     []VoteOption{},
+			Scale:       RateScale{Min: 1, Max: 10, Step: 1},
+			StartTime:   start,
+			EndTime:     start.Add(7 * 24 * time.Hour),
+			Status:      StatusScheduled,
+		}},
+	}
+
+	tests := []struct {
+		format ImportFormat
+		data   string
+		rows   []int
+	}{
+		{FormatJSON, `[
+			{"external_key": "park", "category": "choice", "name": "Новый парк", "organization": "Администрация",
+			 "topics": ["parks", "ecology"], "options": ["Да", {"text": "Нет"}], "max_selections": 1, "ends_in": "48h"},
+			{"external_key": "library", "category": "rate", "name": "Часы работы библиотеки",
+			 "rate_min": 1, "rate_max": 10, "rate_step": 1, "start_time": "2026-03-02T09:00:00Z", "end_time": "2026-03-09T09:00:00Z"}
+		]`, []int{1, 2}},
+		{FormatYAML, `
+- external_key: park
+  category: choice
+  name: Новый парк
+  organization: Администрация
+  topics: [parks, ecology]
+  options:
+    - Да
+    - text: Нет
+  max_selections: 1
+  ends_in: 48h
+- external_key: library
+  category: rate
+  name: Часы работы библиотеки
+  rate_min: 1
+  rate_max: 10
+  rate_step: 1
+  start_time: "2026-03-02T09:00:00Z"
+  end_time: "2026-03-09T09:00:00Z"
+`, []int{1, 2}},
+		{FormatCSV, "External_Key,category,name,organization,topics,options,max_selections,rate_min,rate_max,rate_step,start_time,end_time,ends_in\n" +
+			"park,choice,Новый парк,Администрация,parks | ecology,Да|Нет,1,,,,,,48h\n" +
+			"library,rate,Часы работы библиотеки,,,,,1,10,1,2026-03-02T09:00:00Z,2026-03-09T09:00:00Z,\n", []int{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			records, err := DecodeVotes(strings.NewReader(tt.data), tt.format, validateNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != len(want) {
+				t.Fatalf("got %d records, want %d", len(records), len(want))
+			}
+			for i, record := range records {
+				expected := want[i]
+				expected.Row = tt.rows[i]
+				if !reflect.DeepEqual(record, expected) {
+					t.Errorf("record %d is %+v, want %+v", i, record, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeVotesRowErrors(t *testing.T) {
+	data := "external_key,category,name,rate_min,start_time,ends_in\n" +
+		"a,rate,Bad number,one,,24h\n" +
+		"b,rate,Bad time,,tomorrow,24h\n" +
+		"c,rate,Bad duration,,,a day\n" +
+		"d,rate,Good,,,24h\n"
+	records, err := DecodeVotes(strings.NewReader(data), FormatCSV, validateNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		row int
+		key string
+		err string
+	}{
+		{2, "a", `invalid rate_min "one"`},
+		{3, "b", "invalid start_time"},
+		{4, "c", "invalid ends_in"},
+		{5, "d", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i, w := range want {
+		record := records[i]
+		if record.Row != w.row || record.Vote.ExternalKey != w.key {
+			t.Errorf("record %d is row %d (%s), want row %d (%s)", i, record.Row, record.Vote.ExternalKey, w.row, w.key)
+		}
+		switch {
+		case w.err == "" && record.Err != nil:
+			t.Errorf("row %d: unexpected error: %v", w.row, record.Err)
+		case w.err != "" && (record.Err == nil || !strings.Contains(record.Err.Error(), w.err)):
+			t.Errorf("row %d: got error %v, want %q", w.row, record.Err, w.err)
+		}
+	}
+}
+
+func TestDecodeVotesFails(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ImportFormat
+		data   string
+	}{
+		{"malformed json", FormatJSON, `[{"name": "Vote"`},
+		{"json object", FormatJSON, `{"name": "Vote"}`},
+		{"malformed yaml", FormatYAML, "- name: [Vote"},
+		{"empty csv", FormatCSV, ""},
+		{"unsupported format", "xml", "<votes/>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeVotes(strings.NewReader(tt.data), tt.format, validateNow); err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+
+	records, err := DecodeVotes(strings.NewReader(""), FormatYAML, validateNow)
+	if err != nil || len(records) != 0 {
+		t.Errorf("got %d records and error %v for an empty YAML file", len(records), err)
+	}
+}
+
+func TestDecodeVotesValidation(t *testing.T) {
+	records, err := DecodeVotes(bytes.NewReader(seedVotes), FormatJSON, validateNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if record.Err != nil {
+			t.Fatalf("seed row %d: %v", record.Row, record.Err)
+		}
+		if err := ValidateVote(&record.Vote, validateNow); err != nil {
+			t.Errorf("seed row %d: %v", record.Row, err)
+		}
+	}
+
+	data := `[
+		{"category": "choice", "name": "No options", "ends_in": "24h"},
+		{"category": "rate", "name": "Ended", "end_time": "2026-02-01T00:00:00Z"},
+		{"category": "rate", "name": "Inverted scale", "rate_min": 5, "rate_max": 1, "ends_in": "24h"},
+		{"category": "poll", "name": "Unknown category", "ends_in": "24h"}
+	]`
+	if records, err = DecodeVotes(strings.NewReader(data), FormatJSON, validateNow); err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if record.Err != nil {
+			t.Fatalf("row %d: %v", record.Row, record.Err)
+		}
+		if err := ValidateVote(&record.Vote, validateNow); err == nil {
+			t.Errorf("row %d (%s) passed validation", record.Row, record.Vote.Name)
+		}
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]ImportFormat{
+		"votes.json":     FormatJSON,
+		"votes.YAML":     FormatYAML,
+		"dir/votes.yml":  FormatYAML,
+		"votes.csv":      FormatCSV,
+		"votes.txt":      "",
+		"votes":          "",
+		"votes.json.bak": "",
+	}
+	for path, want := range tests {
+		got, err := FormatFromPath(path)
+		if got != want || (err == nil) != (want != "") {
+			t.Errorf("FormatFromPath(%q) is %q, %v, want %q", path, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,2098 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/GP-Hacks/kdt2024-votes/internal/tally"
+	"github.com/GP-Hacks/kdt2024-votes/internal/textsearch"
+	"html"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type ballotKey struct {
+	voteId  int
+	voterId string
+}
+
+// MemoryStorage is an in-process Repository with the same semantics as
+// PostgresStorage: it returns the same sentinel errors, ballots are upserted
+// per (vote, voterId) and tallies are computed on read.
+type MemoryStorage struct {
+	opts               options
+	mu                 sync.RWMutex
+	nextID             int
+	nextOptionID       int
+	nextQuestionID     int
+	nextCommentID      int
+	nextOrganizationID int
+	nextTopicID        int
+	votes              map[int]*Vote
+	rates              map[ballotKey]int
+	petitions          map[ballotKey]string
+	choices            map[ballotKey][]int
+	rankings           map[ballotKey][]int
+	allocations        map[ballotKey][]Allocation
+	surveys            map[ballotKey]*SurveyResponse
+	followUps          map[ballotKey][]SurveyAnswer
+	comments           map[ballotKey]*Comment
+	responses          map[int]*PetitionResponse
+	organizations      map[int]*Organization
+	topics             map[string]*Topic
+	roles              []RoleAssignment
+	history            map[ballotKey][]BallotChange
+	auditLog           map[string][]audit.Entry
+}
+
+func NewMemoryStorage(opts ...Option) *MemoryStorage {
+	s := &MemoryStorage{
+		opts:               applyOptions(opts),
+		nextID:             1,
+		nextOptionID:       1,
+		nextQuestionID:     1,
+		nextCommentID:      1,
+		nextOrganizationID: 1,
+		nextTopicID:        1,
+		votes:              make(map[int]*Vote),
+		rates:              make(map[ballotKey]int),
+		petitions:          make(map[ballotKey]string),
+		choices:            make(map[ballotKey][]int),
+		rankings:           make(map[ballotKey][]int),
+		allocations:        make(map[ballotKey][]Allocation),
+		surveys:            make(map[ballotKey]*SurveyResponse),
+		followUps:          make(map[ballotKey][]SurveyAnswer),
+		comments:           make(map[ballotKey]*Comment),
+		responses:          make(map[int]*PetitionResponse),
+		organizations:      make(map[int]*Organization),
+		topics:             make(map[string]*Topic),
+		history:            make(map[ballotKey][]BallotChange),
+		auditLog:           make(map[string][]audit.Entry),
+	}
+	for _, topic := range DefaultTopics {
+		s.topics[topic.Slug] = &Topic{ID: s.nextTopicID, Slug: topic.Slug, Name: topic.Name}
+		s.nextTopicID++
+	}
+	return s
+}
+
+func (s *MemoryStorage) Close() {}
+
+// FetchAndStoreData loads the embedded seed votes when the storage is empty.
+func (s *MemoryStorage) FetchAndStoreData(ctx context.Context) error {
+	const op = "storage.memory.FetchAndStoreData"
+
+	s.mu.RLock()
+	empty := len(s.votes) == 0
+	s.mu.RUnlock()
+	if !empty {
+		return nil
+	}
+
+	records, err := DecodeVotes(bytes.NewReader(seedVotes), FormatJSON, s.opts.clock.Now())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, record := range records {
+		if record.Err != nil {
+			return fmt.Errorf("%s: %w", op, record.Err)
+		}
+		vote := record.Vote
+		if err := ValidateVote(&vote, s.opts.clock.Now()); err != nil {
+			return fmt.Errorf("%s: row %d: %w", op, record.Row, err)
+		}
+		if _, err := s.CreateVote(ctx, &vote); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) GetCategories(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	var categories []string
+	for _, vote := range s.votes {
+		if !isPublic(vote) {
+			continue
+		}
+		if _, ok := seen[vote.Category]; ok {
+			continue
+		}
+		seen[vote.Category] = struct{}{}
+		categories = append(categories, vote.Category)
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
+
+func (s *MemoryStorage) GetVotes(ctx context.Context, filter VoteFilter) ([]*Vote, error) {
+	return s.filterVotes(func(v *Vote) bool {
+		return isPublic(v) && (filter.Category == "" || v.Category == filter.Category) &&
+			(filter.OrganizationID == 0 || v.OrganizationID == filter.OrganizationID) &&
+			(filter.Topic == "" || contains(v.Topics, filter.Topic))
+	}), nil
+}
+
+func (s *MemoryStorage) ListAllVotes(ctx context.Context, status string) ([]*Vote, error) {
+	return s.filterVotes(func(v *Vote) bool { return status == "" || v.Status == status }), nil
+}
+
+// SearchVotes mirrors the Postgres search with the textsearch package. Name,
+// description and organization are weighted like the A, B and C parts of
+// the search_vector column.
+func (s *MemoryStorage) SearchVotes(ctx context.Context, query SearchQuery) (*SearchResults, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var candidates []*Vote
+	for _, id := range s.sortedIDs() {
+		vote := s.votes[id]
+		if isPublic(vote) && (query.Category == "" || vote.Category == query.Category) {
+			candidates = append(candidates, vote)
+		}
+	}
+
+	q := textsearch.ParseQuery(query.Text)
+	results := []SearchResult{}
+	for _, vote := range candidates {
+		rank := q.Rank(
+			textsearch.Field{Text: vote.Name, Weight: 1},
+			textsearch.Field{Text: vote.Description, Weight: 0.4},
+			textsearch.Field{Text: vote.Organization, Weight: 0.2},
+		)
+		if rank > 0 {
+			results = append(results, SearchResult{
+				Vote:     s.publicVote(vote),
+				Rank:     rank,
+				Headline: q.Headline(html.EscapeString(vote.Name), highlightStart, highlightStop, 0),
+				Snippet:  q.Headline(html.EscapeString(vote.Description), highlightStart, highlightStop, snippetWords),
+			})
+		}
+	}
+
+	fuzzy := len(results) == 0
+	if fuzzy {
+		for _, vote := range candidates {
+			if similarity := textsearch.WordSimilarity(query.Text, vote.Name); similarity >= fuzzyThreshold {
+				results = append(results, SearchResult{
+					Vote:     s.publicVote(vote),
+					Rank:     similarity,
+					Headline: html.EscapeString(vote.Name),
+					Snippet:  html.EscapeString(textsearch.FirstWords(vote.Description, snippetWords)),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank > results[j].Rank })
+	start := min(query.Offset, len(results))
+	end := min(start+query.Limit, len(results))
+	return &SearchResults{Results: results[start:end], Fuzzy: fuzzy}, nil
+}
+
+func isPublic(vote *Vote) bool {
+	return contains(PublicStatuses, vote.Status)
+}
+
+func (s *MemoryStorage) filterVotes(match func(*Vote) bool) []*Vote {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var votes []*Vote
+	for _, id := range s.sortedIDs() {
+		vote := s.votes[id]
+		if match(vote) {
+			votes = append(votes, s.publicVote(vote))
+		}
+	}
+	return votes
+}
+
+// publicVote copies a stored vote the way fetchVotes returns it: options are
+// only populated for option votes and questions for surveys.
+func (s *MemoryStorage) publicVote(vote *Vote) *Vote {
+	v := *vote
+	v.Topics = append([]string{}, vote.Topics...)
+	if UsesOptions(v.Category) {
+		v.Options = append([]VoteOption{}, vote.Options...)
+	} else {
+		v.Options = []VoteOption{}
+	}
+	v.Questions = nil
+	if v.Category == "survey" {
+		v.Questions = copyQuestions(vote.Questions)
+	}
+	v.FollowUps = nil
+	if contains(followUpCategories, v.Category) {
+		v.FollowUps = copyFollowUps(vote.FollowUps)
+	}
+	return &v
+}
+
+func (s *MemoryStorage) sortedIDs() []int {
+	ids := make([]int, 0, len(s.votes))
+	for id := range s.votes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (s *MemoryStorage) GetVote(ctx context.Context, voteId int) (*Vote, error) {
+	const op = "storage.memory.GetVote"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, ok := s.votes[voteId]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errVoteMissing(voteId))
+	}
+	return s.publicVote(vote), nil
+}
+
+func (s *MemoryStorage) GetUserRates(ctx context.Context, voterId string) ([]*UserRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rates []*UserRate
+	for key, rate := range s.rates {
+		if key.voterId == voterId {
+			rates = append(rates, &UserRate{ID: key.voteId, Rate: rate})
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].ID < rates[j].ID })
+	return rates, nil
+}
+
+func (s *MemoryStorage) GetUserChoices(ctx context.Context, voterId string) ([]*UserChoice, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var choices []*UserChoice
+	for key, optionIds := range s.choices {
+		if key.voterId != voterId {
+			continue
+		}
+		choice := &UserChoice{ID: key.voteId, OptionIDs: append([]int{}, optionIds...)}
+		for _, optionId := range optionIds {
+			choice.Choices = append(choice.Choices, s.optionText(key.voteId, optionId))
+		}
+		choices = append(choices, choice)
+	}
+	sort.Slice(choices, func(i, j int) bool { return choices[i].ID < choices[j].ID })
+	return choices, nil
+}
+
+func (s *MemoryStorage) GetUserPetitions(ctx context.Context, voterId string) ([]*UserPetition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var petitions []*UserPetition
+	for key, support := range s.petitions {
+		if key.voterId == voterId {
+			petitions = append(petitions, &UserPetition{ID: key.voteId, Support: support})
+		}
+	}
+	sort.Slice(petitions, func(i, j int) bool { return petitions[i].ID < petitions[j].ID })
+	return petitions, nil
+}
+
+func (s *MemoryStorage) GetUserRankings(ctx context.Context, voterId string) ([]*UserRanking, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rankings []*UserRanking
+	for key, optionIds := range s.rankings {
+		if key.voterId == voterId {
+			rankings = append(rankings, &UserRanking{ID: key.voteId, OptionIDs: append([]int{}, optionIds...)})
+		}
+	}
+	sort.Slice(rankings, func(i, j int) bool { return rankings[i].ID < rankings[j].ID })
+	return rankings, nil
+}
+
+func (s *MemoryStorage) GetUserAllocations(ctx context.Context, voterId string) ([]*UserAllocation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var allocations []*UserAllocation
+	for key, ballot := range s.allocations {
+		if key.voterId == voterId {
+			allocations = append(allocations, &UserAllocation{ID: key.voteId, Allocations: append([]Allocation{}, ballot...)})
+		}
+	}
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i].ID < allocations[j].ID })
+	return allocations, nil
+}
+
+func (s *MemoryStorage) GetSurveyResponse(ctx context.Context, voterId string, voteId int) (*SurveyResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	response, ok := s.surveys[ballotKey{voteId, voterId}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *response
+	copied.Answers = copyAnswers(response.Answers)
+	return &copied, nil
+}
+
+func (s *MemoryStorage) GetPendingFollowUps(ctx context.Context, voterId string, voteId int) ([]FollowUp, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, ok := s.votes[voteId]
+	if !ok {
+		return []FollowUp{}, nil
+	}
+	key := ballotKey{voteId, voterId}
+	rating, optionIds := s.ballotOf(key)
+	pending := []FollowUp{}
+	for _, followUp := range vote.FollowUps {
+		if followUp.triggeredBy(rating, optionIds) && !answersFollowUp(s.followUps[key], followUp.ID) {
+			pending = append(pending, followUp)
+		}
+	}
+	return copyFollowUps(pending), nil
+}
+
+func (s *MemoryStorage) GetUserComment(ctx context.Context, voterId string, voteId int) (*Comment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	comment, ok := s.comments[ballotKey{voteId, voterId}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+func (s *MemoryStorage) GetRateInfo(ctx context.Context, voteId int) (*RateInfo, error) {
+	const op = "storage.memory.GetRateInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, "rate")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var sum, count int
+	for key, rate := range s.rates {
+		if key.voteId == voteId {
+			sum += rate
+			count++
+		}
+	}
+	var mid float64
+	if count > 0 {
+		mid = float64(sum) / float64(count)
+	}
+
+	return &RateInfo{
+		ID:           vote.ID,
+		Category:     vote.Category,
+		Name:         vote.Name,
+		Description:  vote.Description,
+		Organization: vote.Organization,
+		EndTime:      vote.EndTime,
+		Photo:        vote.Photo,
+		Options:      []VoteOption{},
+		Mid:          mid,
+		Comments:     s.approvedComments(voteId),
+	}, nil
+}
+
+func (s *MemoryStorage) GetPetitionInfo(ctx context.Context, voteId int) (*PetitionInfo, error) {
+	const op = "storage.memory.GetPetitionInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, "petition")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats := s.petitionStats(voteId)
+
+	return &PetitionInfo{
+		ID:              vote.ID,
+		Category:        vote.Category,
+		Name:            vote.Name,
+		Description:     vote.Description,
+		Organization:    vote.Organization,
+		EndTime:         vote.EndTime,
+		Photo:           vote.Photo,
+		Options:         []VoteOption{},
+		Stats:           stats,
+		Status:          vote.Status,
+		SignatureGoal:   vote.SignatureGoal,
+		Signatures:      int(stats[signatureSupport]),
+		Response:        copyResponse(s.responses[voteId]),
+		ResponseDue:     vote.ResponseDue,
+		ResponseOverdue: vote.ResponseOverdue,
+	}, nil
+}
+
+func (s *MemoryStorage) GetChoiceInfo(ctx context.Context, voteId int) (*ChoiceInfo, error) {
+	const op = "storage.memory.GetChoiceInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, "choice")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats := make(map[string]int32)
+	var ballots int
+	for key, optionIds := range s.choices {
+		if key.voteId != voteId {
+			continue
+		}
+		ballots++
+		for _, optionId := range optionIds {
+			stats[s.optionText(voteId, optionId)]++
+		}
+	}
+
+	return &ChoiceInfo{
+		ID:           vote.ID,
+		Category:     vote.Category,
+		Name:         vote.Name,
+		Description:  vote.Description,
+		Organization: vote.Organization,
+		EndTime:      vote.EndTime,
+		Photo:        vote.Photo,
+		Options:      append([]VoteOption{}, vote.Options...),
+		Selection:    vote.Selection,
+		Stats:        stats,
+		Ballots:      ballots,
+		Comments:     s.approvedComments(voteId),
+	}, nil
+}
+
+func (s *MemoryStorage) GetRankedInfo(ctx context.Context, voteId int) (*RankedInfo, error) {
+	const op = "storage.memory.GetRankedInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, "ranked")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var ballots [][]int
+	for key, optionIds := range s.rankings {
+		if key.voteId == voteId {
+			ballots = append(ballots, optionIds)
+		}
+	}
+
+	return &RankedInfo{
+		ID:           vote.ID,
+		Category:     vote.Category,
+		Name:         vote.Name,
+		Description:  vote.Description,
+		Organization: vote.Organization,
+		EndTime:      vote.EndTime,
+		Photo:        vote.Photo,
+		Options:      append([]VoteOption{}, vote.Options...),
+		Ballots:      len(ballots),
+		Result:       tally.InstantRunoff(optionIDs(vote.Options), ballots),
+	}, nil
+}
+
+func (s *MemoryStorage) GetAllocationInfo(ctx context.Context, voteId int) (*AllocationInfo, error) {
+	const op = "storage.memory.GetAllocationInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, budgetCategories...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	counts := make(map[int]map[int]int)
+	var ballots int
+	for key, ballot := range s.allocations {
+		if key.voteId != voteId {
+			continue
+		}
+		ballots++
+		for _, allocation := range ballot {
+			if counts[allocation.OptionID] == nil {
+				counts[allocation.OptionID] = make(map[int]int)
+			}
+			counts[allocation.OptionID][allocation.Points]++
+		}
+	}
+
+	return &AllocationInfo{
+		ID:           vote.ID,
+		Category:     vote.Category,
+		Name:         vote.Name,
+		Description:  vote.Description,
+		Organization: vote.Organization,
+		EndTime:      vote.EndTime,
+		Photo:        vote.Photo,
+		Options:      append([]VoteOption{}, vote.Options...),
+		Budget:       vote.Budget,
+		Ballots:      ballots,
+		Results:      allocationResults(vote.Category, vote.Options, counts),
+	}, nil
+}
+
+func (s *MemoryStorage) GetSurveyInfo(ctx context.Context, voteId int) (*SurveyInfo, error) {
+	const op = "storage.memory.GetSurveyInfo"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vote, err := s.voteOfCategory(voteId, "survey")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	answers := make(map[int]int)
+	ratings := make(map[int]map[int]int)
+	options := make(map[int]map[int]int)
+	var responses int
+	for key, response := range s.surveys {
+		if key.voteId != voteId || !response.Submitted {
+			continue
+		}
+		responses++
+		for _, answer := range response.Answers {
+			answers[answer.QuestionID]++
+			if answer.Rating != nil {
+				if ratings[answer.QuestionID] == nil {
+					ratings[answer.QuestionID] = make(map[int]int)
+				}
+				ratings[answer.QuestionID][*answer.Rating]++
+			}
+			for _, optionId := range answer.OptionIDs {
+				if options[answer.QuestionID] == nil {
+					options[answer.QuestionID] = make(map[int]int)
+				}
+				options[answer.QuestionID][optionId]++
+			}
+		}
+	}
+
+	return &SurveyInfo{
+		ID:           vote.ID,
+		Category:     vote.Category,
+		Name:         vote.Name,
+		Description:  vote.Description,
+		Organization: vote.Organization,
+		EndTime:      vote.EndTime,
+		Photo:        vote.Photo,
+		Questions:    copyQuestions(vote.Questions),
+		Responses:    responses,
+		Results:      surveyResults(vote.Questions, answers, ratings, options),
+	}, nil
+}
+
+func (s *MemoryStorage) optionText(voteId, optionId int) string {
+	for _, option := range s.votes[voteId].Options {
+		if option.ID == optionId {
+			return option.Text
+		}
+	}
+	return ""
+}
+
+func (s *MemoryStorage) voteOfCategory(voteId int, categories ...string) (*Vote, error) {
+	vote, ok := s.votes[voteId]
+	if !ok || !isPublic(vote) {
+		return nil, errVoteMissing(voteId)
+	}
+	if err := checkCategory(voteId, vote.Category, categories...); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func (s *MemoryStorage) VoteRate(ctx context.Context, voterId string, voteId int, rating int, comment *Comment) error {
+	const op = "storage.memory.VoteRate"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, "rate")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateRating(vote, rating); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateComment(vote, comment); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	before := s.ballotSnapshot(ballotRate, key)
+	s.rates[key] = rating
+	s.pruneFollowUpAnswers(voteId, voterId)
+	s.saveComment(key, comment)
+	if err := s.recordBallot(ctx, ballotRate, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) VotePetition(ctx context.Context, voterId string, voteId int, support string) error {
+	const op = "storage.memory.VotePetition"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.checkBallotAllowed(voteId, "petition"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateSupport(support); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	before := s.ballotSnapshot(ballotPetition, key)
+	s.petitions[key] = support
+	if err := s.recordBallot(ctx, ballotPetition, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.promotePetitions(ctx, voteId); err != nil {
+		s.opts.logger.Error("Failed to promote petition", slog.String("op", op), slog.Int("vote_id", voteId),
+			slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+func (s *MemoryStorage) VoteChoice(ctx context.Context, voterId string, voteId int, optionIds []int, comment *Comment) error {
+	const op = "storage.memory.VoteChoice"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, "choice")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateChoice(vote, optionIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateComment(vote, comment); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	before := s.ballotSnapshot(ballotChoice, key)
+	s.choices[key] = inDisplayOrder(vote.Options, optionIds)
+	s.pruneFollowUpAnswers(voteId, voterId)
+	s.saveComment(key, comment)
+	if err := s.recordBallot(ctx, ballotChoice, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) VoteRanked(ctx context.Context, voterId string, voteId int, optionIds []int) error {
+	const op = "storage.memory.VoteRanked"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, "ranked")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateRanking(vote, optionIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	before := s.ballotSnapshot(ballotRanked, key)
+	s.rankings[key] = append([]int{}, optionIds...)
+	if err := s.recordBallot(ctx, ballotRanked, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) VoteAllocation(ctx context.Context, voterId string, voteId int, allocations []Allocation) error {
+	const op = "storage.memory.VoteAllocation"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, budgetCategories...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateAllocation(vote, allocations); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	before := s.ballotSnapshot(ballotAllocation, key)
+	s.allocations[key] = allocationsInDisplayOrder(vote.Options, allocations)
+	if err := s.recordBallot(ctx, ballotAllocation, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) SubmitSurvey(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer, draft bool) error {
+	const op = "storage.memory.SubmitSurvey"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, "survey")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateSurvey(vote, answers, draft); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	if existing, ok := s.surveys[key]; ok && existing.Submitted && draft {
+		return fmt.Errorf("%s: %w: survey %d was already submitted", op, ErrConflict, voteId)
+	}
+
+	ordered := make([]SurveyAnswer, 0, len(answers))
+	for _, question := range vote.Questions {
+		for _, answer := range answers {
+			if answer.QuestionID == question.ID {
+				answer.OptionIDs = inDisplayOrder(question.Options, answer.OptionIDs)
+				ordered = append(ordered, answer)
+			}
+		}
+	}
+	before := s.ballotSnapshot(ballotSurvey, key)
+	s.surveys[key] = &SurveyResponse{
+		VoteID:    voteId,
+		Submitted: !draft,
+		UpdatedAt: s.opts.clock.Now(),
+		Answers:   copyAnswers(ordered),
+	}
+	if err := s.recordBallot(ctx, ballotSurvey, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) AnswerFollowUps(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer) error {
+	const op = "storage.memory.AnswerFollowUps"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, followUpCategories...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := ValidateFollowUpAnswers(vote, answers); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := ballotKey{voteId, voterId}
+	rating, optionIds := s.ballotOf(key)
+	merged := make([]SurveyAnswer, 0, len(vote.FollowUps))
+	for _, followUp := range vote.FollowUps {
+		var answer *SurveyAnswer
+		for i := range answers {
+			if answers[i].QuestionID == followUp.ID {
+				answer = &answers[i]
+			}
+		}
+		if answer == nil {
+			for _, previous := range s.followUps[key] {
+				if previous.QuestionID == followUp.ID {
+					merged = append(merged, previous)
+				}
+			}
+			continue
+		}
+		if !followUp.triggeredBy(rating, optionIds) {
+			return fmt.Errorf("%s: %w: follow-up %d is not triggered by the ballot", op, ErrInvalidBallot, followUp.ID)
+		}
+		stored := copyAnswers([]SurveyAnswer{*answer})[0]
+		stored.OptionIDs = inDisplayOrder(followUp.Options, stored.OptionIDs)
+		merged = append(merged, stored)
+	}
+	before := s.ballotSnapshot(ballotFollowUps, key)
+	s.followUps[key] = merged
+	if err := s.auditBallot(ctx, ballotFollowUps, key, before, s.ballotSnapshot(ballotFollowUps, key)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) checkBallotAllowed(voteId int, categories ...string) (*Vote, error) {
+	vote, ok := s.votes[voteId]
+	if !ok {
+		return nil, errVoteMissing(voteId)
+	}
+	if err := checkCategory(voteId, vote.Category, categories...); err != nil {
+		return nil, err
+	}
+	if err := checkVoteOpen(voteId, vote.Status, vote.StartTime, vote.EndTime, s.opts.clock.Now()); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+func errVoteMissing(voteId int) error {
+	return fmt.Errorf("vote %d: %w", voteId, ErrNotFound)
+}
+
+func (s *MemoryStorage) CreateVote(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.memory.CreateVote"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created, err := s.insertVote(vote, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "vote.create", auditVote, strconv.Itoa(created.ID), nil, created); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+func (s *MemoryStorage) insertVote(vote *Vote, createOrganization bool) (*Vote, error) {
+	if err := s.checkExternalKey(vote.ExternalKey, 0); err != nil {
+		return nil, err
+	}
+
+	created := *vote
+	if created.Status == "" {
+		created.Status = StatusDraft
+	}
+	if err := s.checkTopics(vote.Topics); err != nil {
+		return nil, err
+	}
+	if err := s.resolveOrganization(&created, createOrganization); err != nil {
+		return nil, err
+	}
+	created.ID = s.nextID
+	s.nextID++
+	options, err := s.replaceOptions(nil, vote.Options)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.replaceQuestions(nil, vote.Questions)
+	if err != nil {
+		return nil, err
+	}
+	followUps, err := s.replaceFollowUps(nil, resolveTriggers(vote.FollowUps, options))
+	if err != nil {
+		return nil, err
+	}
+	created.Options = options
+	created.Questions = questions
+	created.FollowUps = followUps
+	created.Topics = append([]string{}, vote.Topics...)
+	s.votes[created.ID] = &created
+
+	result := created
+	result.Topics = append([]string{}, created.Topics...)
+	result.Options = append([]VoteOption{}, created.Options...)
+	result.Questions = copyQuestions(created.Questions)
+	result.FollowUps = copyFollowUps(created.FollowUps)
+	return &result, nil
+}
+
+func (s *MemoryStorage) UpdateVote(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.memory.UpdateVote"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.votes[vote.ID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errVoteMissing(vote.ID))
+	}
+	if rulesChanged(existing, vote) {
+		if err := checkRulesLocked(existing, s.hasBallots(vote.ID)); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := s.checkExternalKey(vote.ExternalKey, vote.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.checkTopics(vote.Topics); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	options, err := s.replaceOptions(existing.Options, vote.Options)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	questions, err := s.replaceQuestions(existing.Questions, vote.Questions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	followUps, err := s.replaceFollowUps(existing.FollowUps, resolveTriggers(vote.FollowUps, options))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated := *vote
+	if err := s.resolveOrganization(&updated, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated.Topics = append([]string{}, vote.Topics...)
+	updated.Options = options
+	updated.Questions = questions
+	updated.FollowUps = followUps
+	updated.Status = existing.Status
+	updated.Author = existing.Author
+	updated.ReviewReason = existing.ReviewReason
+	updated.ResponseDue = existing.ResponseDue
+	updated.ResponseOverdue = existing.ResponseOverdue
+	s.votes[vote.ID] = &updated
+	pruneBallots(s.choices, vote.ID, updated.Options)
+	pruneBallots(s.rankings, vote.ID, updated.Options)
+	for key, ballot := range s.allocations {
+		if key.voteId != vote.ID {
+			continue
+		}
+		kept := allocationsInDisplayOrder(updated.Options, ballot)
+		if len(kept) == 0 {
+			delete(s.allocations, key)
+		} else {
+			s.allocations[key] = kept
+		}
+	}
+	for key, optionIds := range s.choices {
+		if key.voteId == vote.ID {
+			s.choices[key] = inDisplayOrder(updated.Options, optionIds)
+		}
+	}
+	s.pruneSurveyAnswers(vote.ID, updated.Questions)
+	s.pruneFollowUpAnswers(vote.ID, "")
+
+	result := updated
+	result.Topics = append([]string{}, updated.Topics...)
+	result.Options = append([]VoteOption{}, updated.Options...)
+	result.Questions = copyQuestions(updated.Questions)
+	result.FollowUps = copyFollowUps(updated.FollowUps)
+	err = s.appendAudit(ctx, audit.Actor(ctx), "vote.update", auditVote, strconv.Itoa(vote.ID), s.publicVote(existing), s.publicVote(&updated))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &result, nil
+}
+
+// hasBallots mirrors the SQL hasBallots.
+func (s *MemoryStorage) hasBallots(voteId int) bool {
+	var keys []ballotKey
+	for key := range s.rates {
+		keys = append(keys, key)
+	}
+	for key := range s.petitions {
+		keys = append(keys, key)
+	}
+	for key := range s.choices {
+		keys = append(keys, key)
+	}
+	for key := range s.rankings {
+		keys = append(keys, key)
+	}
+	for key := range s.allocations {
+		keys = append(keys, key)
+	}
+	for key := range s.surveys {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if key.voteId == voteId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MemoryStorage) DeleteVote(ctx context.Context, voteId int) error {
+	const op = "storage.memory.DeleteVote"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.votes[voteId]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, errVoteMissing(voteId))
+	}
+	if s.hasBallots(voteId) {
+		return fmt.Errorf("%s: %w: vote %d", op, ErrVoteHasBallots, voteId)
+	}
+	previous := s.publicVote(existing)
+	delete(s.votes, voteId)
+	for key := range s.rates {
+		if key.voteId == voteId {
+			delete(s.rates, key)
+		}
+	}
+	for key := range s.petitions {
+		if key.voteId == voteId {
+			delete(s.petitions, key)
+		}
+	}
+	for key := range s.choices {
+		if key.voteId == voteId {
+			delete(s.choices, key)
+		}
+	}
+	for key := range s.rankings {
+		if key.voteId == voteId {
+			delete(s.rankings, key)
+		}
+	}
+	for key := range s.allocations {
+		if key.voteId == voteId {
+			delete(s.allocations, key)
+		}
+	}
+	for key := range s.surveys {
+		if key.voteId == voteId {
+			delete(s.surveys, key)
+		}
+	}
+	for key := range s.followUps {
+		if key.voteId == voteId {
+			delete(s.followUps, key)
+		}
+	}
+	for key := range s.comments {
+		if key.voteId == voteId {
+			delete(s.comments, key)
+		}
+	}
+	for key := range s.history {
+		if key.voteId == voteId {
+			delete(s.history, key)
+		}
+	}
+	delete(s.responses, voteId)
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "vote.delete", auditVote, strconv.Itoa(voteId), previous, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *MemoryStorage) SetVoteStatus(ctx context.Context, voteId int, status string) (*Vote, error) {
+	const op = "storage.memory.SetVoteStatus"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, ok := s.votes[voteId]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errVoteMissing(voteId))
+	}
+	if err := checkTransition(voteId, vote.Status, status, vote.StartTime, vote.EndTime, s.opts.clock.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	err := s.appendAudit(ctx, audit.Actor(ctx), "vote.status", auditVote, strconv.Itoa(voteId),
+		map[string]string{"Status": vote.Status}, map[string]string{"Status": status})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	vote.Status = status
+	return s.publicVote(vote), nil
+}
+
+func (s *MemoryStorage) AdvanceVoteStatuses(ctx context.Context) (StatusChanges, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	const op = "storage.memory.AdvanceVoteStatuses"
+
+	var changes StatusChanges
+	now := s.opts.clock.Now()
+	promoted, err := s.promotePetitions(ctx, 0)
+	if err != nil {
+		return changes, fmt.Errorf("%s: %w", op, err)
+	}
+	changes.AwaitingResponse = promoted
+	for _, id := range s.sortedIDs() {
+		vote := s.votes[id]
+		next := nextStatus(vote.Status, vote.StartTime, vote.EndTime, now)
+		if next == vote.Status {
+			continue
+		}
+		err := s.appendAudit(ctx, actorLifecycle, "vote.status", auditVote, strconv.Itoa(id),
+			map[string]string{"Status": vote.Status}, map[string]string{"Status": next})
+		if err != nil {
+			return changes, fmt.Errorf("%s: %w", op, err)
+		}
+		vote.Status = next
+		if next == StatusOpen {
+			changes.Opened++
+		} else {
+			changes.Closed++
+		}
+	}
+	for _, id := range s.sortedIDs() {
+		vote := s.votes[id]
+		if vote.Status == StatusAwaitingResponse && !vote.ResponseOverdue && !now.Before(vote.ResponseDue) {
+			err := s.appendAudit(ctx, actorLifecycle, "petition.overdue", auditVote, strconv.Itoa(id),
+				map[string]bool{"ResponseOverdue": false}, map[string]bool{"ResponseOverdue": true})
+			if err != nil {
+				return changes, fmt.Errorf("%s: %w", op, err)
+			}
+			vote.ResponseOverdue = true
+			changes.Overdue++
+		}
+	}
+	return changes, nil
+}
+
+func (s *MemoryStorage) CreatePetition(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.memory.CreatePetition"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending int
+	for _, existing := range s.votes {
+		if existing.Author == vote.Author && existing.Status == StatusModeration {
+			pending++
+		}
+	}
+	if pending >= maxPendingPetitions {
+		return nil, fmt.Errorf("%s: %w: %d petitions of the author are awaiting moderation", op, ErrConflict, pending)
+	}
+
+	created, err := s.insertVote(vote, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.appendAudit(ctx, vote.Author, "petition.create", auditVote, strconv.Itoa(created.ID), nil, created); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+func (s *MemoryStorage) ReviewPetition(ctx context.Context, voteId int, status, reason string, signatureGoal int) (*Vote, error) {
+	const op = "storage.memory.ReviewPetition"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, ok := s.votes[voteId]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errVoteMissing(voteId))
+	}
+	now := s.opts.clock.Now()
+	if err := checkPetitionReview(voteId, vote.Category, vote.Status, status, vote.EndTime, now); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previous := map[string]interface{}{"Status": vote.Status, "ReviewReason": vote.ReviewReason, "SignatureGoal": vote.SignatureGoal}
+	vote.Status = status
+	vote.ReviewReason = strings.TrimSpace(reason)
+	if signatureGoal > 0 {
+		vote.SignatureGoal = signatureGoal
+	}
+	if status == StatusOpen {
+		vote.StartTime = now
+	}
+	err := s.appendAudit(ctx, audit.Actor(ctx), "petition.review", auditVote, strconv.Itoa(voteId), previous,
+		map[string]interface{}{"Status": vote.Status, "ReviewReason": vote.ReviewReason, "SignatureGoal": vote.SignatureGoal})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.publicVote(vote), nil
+}
+
+func (s *MemoryStorage) PublishResponse(ctx context.Context, response *PetitionResponse) (*PetitionResponse, error) {
+	const op = "storage.memory.PublishResponse"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, ok := s.votes[response.VoteID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, errVoteMissing(response.VoteID))
+	}
+	if err := checkRespondable(vote.ID, vote.Category, vote.Status); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	published := copyResponse(response)
+	published.RespondedAt = s.opts.clock.Now()
+	if published.Organization == "" {
+		published.Organization = vote.Organization
+	}
+	previous := s.responses[vote.ID]
+	s.responses[vote.ID] = published
+	if vote.Status == StatusAwaitingResponse {
+		vote.Status = StatusClosed
+	}
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "petition.respond", auditVote, strconv.Itoa(vote.ID), previous, published); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return copyResponse(published), nil
+}
+
+func (s *MemoryStorage) ListOverduePetitions(ctx context.Context) ([]*Vote, error) {
+	votes := s.filterVotes(func(vote *Vote) bool {
+		return vote.Status == StatusAwaitingResponse && vote.ResponseOverdue
+	})
+	sort.SliceStable(votes, func(i, j int) bool {
+		return votes[i].ResponseDue.Before(votes[j].ResponseDue)
+	})
+	return votes, nil
+}
+
+func copyResponse(response *PetitionResponse) *PetitionResponse {
+	if response == nil {
+		return nil
+	}
+	c := *response
+	c.Attachments = append([]Attachment{}, response.Attachments...)
+	return &c
+}
+
+// promotePetitions moves open petitions whose signatures have reached their
+// goal to awaiting_response and returns how many moved. A voteId of 0
+// checks every petition.
+func (s *MemoryStorage) promotePetitions(ctx context.Context, voteId int) (int, error) {
+	var promoted int
+	responseDue := s.opts.clock.Now().Add(s.opts.responseWindow)
+	for _, id := range s.sortedIDs() {
+		vote := s.votes[id]
+		if voteId != 0 && id != voteId {
+			continue
+		}
+		if vote.Category != "petition" || vote.Status != StatusOpen || vote.SignatureGoal <= 0 {
+			continue
+		}
+		if int(s.petitionStats(id)[signatureSupport]) >= vote.SignatureGoal {
+			err := s.appendAudit(ctx, actorLifecycle, "vote.status", auditVote, strconv.Itoa(id),
+				map[string]interface{}{"Status": StatusOpen},
+				map[string]interface{}{"Status": StatusAwaitingResponse, "ResponseDue": responseDue.UTC()})
+			if err != nil {
+				return promoted, err
+			}
+			vote.Status = StatusAwaitingResponse
+			vote.ResponseDue = responseDue
+			promoted++
+		}
+	}
+	return promoted, nil
+}
+
+func (s *MemoryStorage) petitionStats(voteId int) map[string]int32 {
+	stats := make(map[string]int32)
+	for key, support := range s.petitions {
+		if key.voteId == voteId {
+			stats[support]++
+		}
+	}
+	return stats
+}
+
+// pruneBallots drops removed options from the ballots of a vote, like the
+// cascading foreign keys do, and deletes ballots left without options.
+func pruneBallots(ballots map[ballotKey][]int, voteId int, options []VoteOption) {
+	for key, optionIds := range ballots {
+		if key.voteId != voteId {
+			continue
+		}
+		kept := optionIds[:0]
+		for _, optionId := range optionIds {
+			if hasOptionID(options, optionId) {
+				kept = append(kept, optionId)
+			}
+		}
+		if len(kept) == 0 {
+			delete(ballots, key)
+		} else {
+			ballots[key] = kept
+		}
+	}
+}
+
+func inDisplayOrder(options []VoteOption, optionIds []int) []int {
+	ordered := make([]int, 0, len(optionIds))
+	for _, option := range options {
+		if containsInt(optionIds, option.ID) {
+			ordered = append(ordered, option.ID)
+		}
+	}
+	return ordered
+}
+
+// allocationsInDisplayOrder orders a ballot like the options and drops
+// allocations to options that no longer exist.
+func allocationsInDisplayOrder(options []VoteOption, allocations []Allocation) []Allocation {
+	ordered := make([]Allocation, 0, len(allocations))
+	for _, option := range options {
+		for _, allocation := range allocations {
+			if allocation.OptionID == option.ID {
+				ordered = append(ordered, allocation)
+			}
+		}
+	}
+	return ordered
+}
+
+// replaceOptions mirrors the SQL replaceOptions: options are matched by ID or,
+// without an ID, by text and get their position from the order of options.
+func (s *MemoryStorage) replaceOptions(existing, options []VoteOption) ([]VoteOption, error) {
+	renamed := renamedTexts(options)
+	stored := make([]VoteOption, 0, len(options))
+	for position, option := range options {
+		option.Position = position
+		switch {
+		case option.ID != 0:
+			if !hasOptionID(existing, option.ID) {
+				return nil, fmt.Errorf("%w: option %d does not belong to this vote", ErrInvalidOption, option.ID)
+			}
+		default:
+			if id := optionIDByText(existing, option.Text); id != 0 && !isRenamed(renamed, id, option.Text) {
+				option.ID = id
+			} else {
+				option.ID = s.nextOptionID
+				s.nextOptionID++
+			}
+		}
+		stored = append(stored, option)
+	}
+	return stored, nil
+}
+
+// isRenamed reports whether an existing entry is given a new text by ID,
+// which frees its old text for another entry like moveRenamed does.
+func isRenamed(renamed map[int]string, id int, text string) bool {
+	newText, ok := renamed[id]
+	return ok && newText != text
+}
+
+// replaceQuestions mirrors the SQL replaceQuestions: questions are matched by
+// ID or, without an ID, by text, and their options the same way within the
+// question.
+func (s *MemoryStorage) replaceQuestions(existing, questions []Question) ([]Question, error) {
+	renamed := make(map[int]string, len(questions))
+	for _, question := range questions {
+		if question.ID != 0 {
+			renamed[question.ID] = question.Text
+		}
+	}
+	stored := make([]Question, 0, len(questions))
+	for position, question := range questions {
+		question.Position = position
+		var previous Question
+		switch {
+		case question.ID != 0:
+			var ok bool
+			if previous, ok = findQuestion(existing, question.ID); !ok {
+				return nil, fmt.Errorf("%w: question %d does not belong to this vote", ErrInvalidQuestion, question.ID)
+			}
+		default:
+			for _, candidate := range existing {
+				if candidate.Text == question.Text && !isRenamed(renamed, candidate.ID, candidate.Text) {
+					previous = candidate
+				}
+			}
+			if previous.ID != 0 {
+				question.ID = previous.ID
+			} else {
+				question.ID = s.nextQuestionID
+				s.nextQuestionID++
+			}
+		}
+		options, err := s.replaceOptions(previous.Options, question.Options)
+		if err != nil {
+			return nil, err
+		}
+		question.Options = options
+		stored = append(stored, question)
+	}
+	return stored, nil
+}
+
+// pruneSurveyAnswers drops answers that no longer fit their question after
+// an edit, like the SQL pruneSurveyAnswers and the cascading foreign keys.
+func (s *MemoryStorage) pruneSurveyAnswers(voteId int, questions []Question) {
+	for key, response := range s.surveys {
+		if key.voteId != voteId {
+			continue
+		}
+		kept := make([]SurveyAnswer, 0, len(response.Answers))
+		for _, question := range questions {
+			for _, answer := range response.Answers {
+				if answer.QuestionID != question.ID {
+					continue
+				}
+				answer.OptionIDs = inDisplayOrder(question.Options, answer.OptionIDs)
+				if validateAnswer(question, answer) == nil {
+					kept = append(kept, answer)
+				}
+			}
+		}
+		response.Answers = kept
+	}
+}
+
+// replaceFollowUps matches follow-ups like replaceQuestions matches survey
+// questions.
+func (s *MemoryStorage) replaceFollowUps(existing, followUps []FollowUp) ([]FollowUp, error) {
+	questions, err := s.replaceQuestions(followUpQuestions(existing), followUpQuestions(followUps))
+	if err != nil {
+		return nil, err
+	}
+	stored := make([]FollowUp, 0, len(followUps))
+	for i, followUp := range followUps {
+		followUp.ID = questions[i].ID
+		followUp.Position = questions[i].Position
+		followUp.Options = questions[i].Options
+		stored = append(stored, followUp)
+	}
+	return stored, nil
+}
+
+// pruneFollowUpAnswers mirrors the SQL pruneFollowUpAnswers: answers to
+// follow-ups the ballot no longer triggers or that no longer fit the
+// follow-up are dropped. An empty voterId prunes every user of the vote.
+func (s *MemoryStorage) pruneFollowUpAnswers(voteId int, voterId string) {
+	vote := s.votes[voteId]
+	for key, answers := range s.followUps {
+		if key.voteId != voteId || (voterId != "" && key.voterId != voterId) {
+			continue
+		}
+		rating, optionIds := s.ballotOf(key)
+		kept := make([]SurveyAnswer, 0, len(answers))
+		for _, followUp := range vote.FollowUps {
+			for _, answer := range answers {
+				if answer.QuestionID != followUp.ID {
+					continue
+				}
+				answer.OptionIDs = inDisplayOrder(followUp.Options, answer.OptionIDs)
+				if followUp.triggeredBy(rating, optionIds) && validateAnswer(followUp.question(), answer) == nil {
+					kept = append(kept, answer)
+				}
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.followUps, key)
+		} else {
+			s.followUps[key] = kept
+		}
+	}
+}
+
+// ballotOf returns the rating and the selected options of a ballot.
+func (s *MemoryStorage) ballotOf(key ballotKey) (*int, []int) {
+	var rating *int
+	if rate, ok := s.rates[key]; ok {
+		rating = &rate
+	}
+	return rating, s.choices[key]
+}
+
+func answersFollowUp(answers []SurveyAnswer, followUpId int) bool {
+	for _, answer := range answers {
+		if answer.QuestionID == followUpId {
+			return true
+		}
+	}
+	return false
+}
+
+func copyAnswers(answers []SurveyAnswer) []SurveyAnswer {
+	copied := make([]SurveyAnswer, 0, len(answers))
+	for _, answer := range answers {
+		if answer.Rating != nil {
+			rating := *answer.Rating
+			answer.Rating = &rating
+		}
+		answer.OptionIDs = append([]int(nil), answer.OptionIDs...)
+		copied = append(copied, answer)
+	}
+	return copied
+}
+
+func (s *MemoryStorage) ListComments(ctx context.Context, status string, voteId int) ([]Comment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	comments := []Comment{}
+	for _, comment := range s.comments {
+		if comment.Status == status && (voteId == 0 || comment.VoteID == voteId) {
+			comments = append(comments, *comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	return comments, nil
+}
+
+func (s *MemoryStorage) ReviewComment(ctx context.Context, commentId int, status, reason string) (*Comment, error) {
+	const op = "storage.memory.ReviewComment"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, comment := range s.comments {
+		if comment.ID == commentId {
+			previous := *comment
+			comment.Status = status
+			comment.Reason = strings.TrimSpace(reason)
+			comment.ReviewedAt = s.opts.clock.Now()
+			copied := *comment
+			if err := s.appendAudit(ctx, audit.Actor(ctx), "comment.review", auditComment, strconv.Itoa(commentId), &previous, &copied); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w: %d", op, ErrCommentNotFound, commentId)
+}
+
+// saveComment mirrors the upsert of the Postgres storage: the same text
+// keeps its moderation state, new text goes back to the queue.
+func (s *MemoryStorage) saveComment(key ballotKey, comment *Comment) {
+	if comment == nil {
+		return
+	}
+	existing, ok := s.comments[key]
+	if ok && existing.Text == comment.Text {
+		return
+	}
+	stored := &Comment{
+		VoteID:    key.voteId,
+		Text:      comment.Text,
+		Status:    comment.Status,
+		Reason:    comment.Reason,
+		CreatedAt: s.opts.clock.Now(),
+	}
+	if ok {
+		stored.ID = existing.ID
+	} else {
+		stored.ID = s.nextCommentID
+		s.nextCommentID++
+	}
+	s.comments[key] = stored
+}
+
+// approvedComments returns the newest approved comments of a vote.
+func (s *MemoryStorage) approvedComments(voteId int) []Comment {
+	comments := []Comment{}
+	for _, comment := range s.comments {
+		if comment.VoteID == voteId && comment.Status == CommentApproved {
+			comments = append(comments, *comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.After(comments[j].CreatedAt)
+		}
+		return comments[i].ID > comments[j].ID
+	})
+	if len(comments) > maxPublicComments {
+		comments = comments[:maxPublicComments]
+	}
+	return comments
+}
+
+func (s *MemoryStorage) checkExternalKey(key string, selfId int) error {
+	if key == "" {
+		return nil
+	}
+	for id, vote := range s.votes {
+		if id != selfId && vote.ExternalKey == key {
+			return fmt.Errorf("%w: vote with external key %q already exists", ErrConflict, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organizations := make([]Organization, 0, len(s.organizations))
+	for _, organization := range s.organizations {
+		organizations = append(organizations, s.countedOrganization(organization))
+	}
+	sort.Slice(organizations, func(i, j int) bool {
+		if organizations[i].Name != organizations[j].Name {
+			return organizations[i].Name < organizations[j].Name
+		}
+		return organizations[i].ID < organizations[j].ID
+	})
+	return organizations, nil
+}
+
+func (s *MemoryStorage) GetOrganization(ctx context.Context, organizationId int) (*Organization, error) {
+	const op = "storage.memory.GetOrganization"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	organization, ok := s.organizations[organizationId]
+	if !ok {
+		return nil, fmt.Errorf("%s: organization %d: %w", op, organizationId, ErrOrganizationNotFound)
+	}
+	counted := s.countedOrganization(organization)
+	return &counted, nil
+}
+
+func (s *MemoryStorage) CreateOrganization(ctx context.Context, organization *Organization) (*Organization, error) {
+	const op = "storage.memory.CreateOrganization"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkOrganizationName(organization.Name, 0); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	created := *organization
+	created.ID = s.nextOrganizationID
+	created.Votes = 0
+	s.nextOrganizationID++
+	s.organizations[created.ID] = &created
+	c := created
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "organization.create", auditOrganization, strconv.Itoa(c.ID), nil, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &c, nil
+}
+
+func (s *MemoryStorage) UpdateOrganization(ctx context.Context, organization *Organization) (*Organization, error) {
+	const op = "storage.memory.UpdateOrganization"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.organizations[organization.ID]
+	if !ok {
+		return nil, fmt.Errorf("%s: organization %d: %w", op, organization.ID, ErrOrganizationNotFound)
+	}
+	if err := s.checkOrganizationName(organization.Name, organization.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previous := s.countedOrganization(existing)
+	updated := *organization
+	s.organizations[updated.ID] = &updated
+	for _, vote := range s.votes {
+		if vote.OrganizationID == updated.ID {
+			vote.Organization = updated.Name
+		}
+	}
+	counted := s.countedOrganization(&updated)
+	err := s.appendAudit(ctx, audit.Actor(ctx), "organization.update", auditOrganization, strconv.Itoa(updated.ID), &previous, &counted)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &counted, nil
+}
+
+// countedOrganization returns a copy of organization with its public votes
+// counted.
+func (s *MemoryStorage) countedOrganization(organization *Organization) Organization {
+	counted := *organization
+	counted.Votes = 0
+	for _, vote := range s.votes {
+		if vote.OrganizationID == organization.ID && isPublic(vote) {
+			counted.Votes++
+		}
+	}
+	return counted
+}
+
+// resolveOrganization mirrors the PostgreSQL version: vote is pointed at its
+// organization by ID or by name ignoring case, and with create set an
+// unknown name becomes a new unverified organization.
+func (s *MemoryStorage) resolveOrganization(vote *Vote, create bool) error {
+	name := strings.TrimSpace(vote.Organization)
+	if vote.OrganizationID != 0 {
+		organization, ok := s.organizations[vote.OrganizationID]
+		if !ok {
+			return fmt.Errorf("organization %d: %w", vote.OrganizationID, ErrOrganizationNotFound)
+		}
+		vote.Organization = organization.Name
+		return nil
+	}
+	if name == "" {
+		vote.Organization = ""
+		return nil
+	}
+	for _, organization := range s.organizations {
+		if strings.EqualFold(organization.Name, name) {
+			vote.OrganizationID, vote.Organization = organization.ID, organization.Name
+			return nil
+		}
+	}
+	if !create {
+		return fmt.Errorf("organization %q: %w", name, ErrOrganizationNotFound)
+	}
+	organization := &Organization{ID: s.nextOrganizationID, Name: name}
+	s.nextOrganizationID++
+	s.organizations[organization.ID] = organization
+	vote.OrganizationID, vote.Organization = organization.ID, organization.Name
+	return nil
+}
+
+func (s *MemoryStorage) checkOrganizationName(name string, selfId int) error {
+	for id, organization := range s.organizations {
+		if id != selfId && strings.EqualFold(organization.Name, name) {
+			return fmt.Errorf("%w: organization %q already exists", ErrConflict, name)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) ListTopics(ctx context.Context) ([]Topic, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	topics := make([]Topic, 0, len(s.topics))
+	for _, topic := range s.topics {
+		topics = append(topics, s.countedTopic(topic))
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if topics[i].Name != topics[j].Name {
+			return topics[i].Name < topics[j].Name
+		}
+		return topics[i].ID < topics[j].ID
+	})
+	return topics, nil
+}
+
+func (s *MemoryStorage) CreateTopic(ctx context.Context, topic *Topic) (*Topic, error) {
+	const op = "storage.memory.CreateTopic"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.topics[topic.Slug]; ok {
+		return nil, fmt.Errorf("%s: %w: topic %q already exists", op, ErrConflict, topic.Slug)
+	}
+	created := *topic
+	created.ID = s.nextTopicID
+	created.Votes = 0
+	s.nextTopicID++
+	s.topics[created.Slug] = &created
+	c := created
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "topic.create", auditTopic, c.Slug, nil, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &c, nil
+}
+
+func (s *MemoryStorage) UpdateTopic(ctx context.Context, topic *Topic) (*Topic, error) {
+	const op = "storage.memory.UpdateTopic"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.topics[topic.Slug]
+	if !ok {
+		return nil, fmt.Errorf("%s: topic %q: %w", op, topic.Slug, ErrTopicNotFound)
+	}
+	previous := s.countedTopic(existing)
+	existing.Name = topic.Name
+	updated := s.countedTopic(existing)
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "topic.update", auditTopic, topic.Slug, &previous, &updated); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &updated, nil
+}
+
+// countedTopic returns a copy of topic with its public votes counted.
+func (s *MemoryStorage) countedTopic(topic *Topic) Topic {
+	counted := *topic
+	counted.Votes = 0
+	for _, vote := range s.votes {
+		if isPublic(vote) && contains(vote.Topics, topic.Slug) {
+			counted.Votes++
+		}
+	}
+	return counted
+}
+
+func (s *MemoryStorage) checkTopics(slugs []string) error {
+	for _, slug := range slugs {
+		if _, ok := s.topics[slug]; !ok {
+			return fmt.Errorf("topic %q: %w", slug, ErrTopicNotFound)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStorage) GetRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	assignments := []RoleAssignment{}
+	for _, assignment := range s.roles {
+		if assignment.UserID == userId {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+func (s *MemoryStorage) ListRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error) {
+	if userId != "" {
+		return s.GetRoleAssignments(ctx, userId)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]RoleAssignment{}, s.roles...), nil
+}
+
+func (s *MemoryStorage) GrantRole(ctx context.Context, assignment *RoleAssignment) (*RoleAssignment, error) {
+	const op = "storage.memory.GrantRole"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if assignment.OrganizationID != 0 {
+		if _, ok := s.organizations[assignment.OrganizationID]; !ok {
+			return nil, fmt.Errorf("%s: organization %d: %w", op, assignment.OrganizationID, ErrOrganizationNotFound)
+		}
+	}
+	if s.findRole(assignment) >= 0 {
+		return nil, fmt.Errorf("%s: %w: %q already has the %s role", op, ErrConflict, assignment.UserID, assignment.Role)
+	}
+	granted := *assignment
+	granted.GrantedAt = s.opts.clock.Now()
+	s.roles = append(s.roles, granted)
+	sort.SliceStable(s.roles, func(i, j int) bool {
+		a, b := s.roles[i], s.roles[j]
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.OrganizationID < b.OrganizationID
+	})
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "role.grant", auditRole, roleEntityID(&granted), nil, &granted); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &granted, nil
+}
+
+func (s *MemoryStorage) RevokeRole(ctx context.Context, assignment *RoleAssignment) error {
+	const op = "storage.memory.RevokeRole"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findRole(assignment)
+	if i < 0 {
+		return fmt.Errorf("%s: %s of %q: %w", op, assignment.Role, assignment.UserID, ErrRoleNotFound)
+	}
+	revoked := s.roles[i]
+	s.roles = append(s.roles[:i], s.roles[i+1:]...)
+	if err := s.appendAudit(ctx, audit.Actor(ctx), "role.revoke", auditRole, roleEntityID(&revoked), &revoked, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// findRole returns the index of the assignment in s.roles, or -1.
+func (s *MemoryStorage) findRole(assignment *RoleAssignment) int {
+	for i, existing := range s.roles {
+		if existing.UserID == assignment.UserID && existing.Role == assignment.Role &&
+			existing.OrganizationID == assignment.OrganizationID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MemoryStorage) AuditLog(ctx context.Context, afterStream string, afterSeq int64, limit int) ([]audit.Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	streams := make([]string, 0, len(s.auditLog))
+	for stream := range s.auditLog {
+		if stream >= afterStream {
+			streams = append(streams, stream)
+		}
+	}
+	sort.Strings(streams)
+
+	entries := []audit.Entry{}
+	for _, stream := range streams {
+		for _, entry := range s.auditLog[stream] {
+			if stream == afterStream && entry.Seq <= afterSeq {
+				continue
+			}
+			if len(entries) == limit {
+				return entries, nil
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// appendAudit seals a change onto the end of its stream. The caller holds
+// s.mu.
+func (s *MemoryStorage) appendAudit(ctx context.Context, actor, action, entity, entityId string, before, after interface{}) error {
+	entry, err := newAuditEntry(ctx, s.opts.clock.Now(), actor, action, entity, entityId, before, after)
+	if err != nil {
+		return err
+	}
+	stream := s.auditLog[entry.Stream]
+	prevHash := audit.GenesisHash
+	if n := len(stream); n > 0 {
+		prevHash = stream[n-1].Hash
+	}
+	entry.Seal(int64(len(stream))+1, prevHash)
+	s.auditLog[entry.Stream] = append(stream, *entry)
+	return nil
+}
+
+// auditBallot mirrors the PostgreSQL version with snapshots taken by
+// ballotSnapshot.
+func (s *MemoryStorage) auditBallot(ctx context.Context, kind string, key ballotKey, before, after interface{}) error {
+	return s.appendAudit(ctx, key.voterId, "ballot."+kind, auditVote, strconv.Itoa(key.voteId), before, after)
+}
+
+// ballotSnapshot returns a ballot in the shape of the documents of
+// ballotSnapshots, or nil when there is none.
+func (s *MemoryStorage) ballotSnapshot(kind string, key ballotKey) interface{} {
+	switch kind {
+	case ballotRate:
+		if rating, ok := s.rates[key]; ok {
+			return map[string]interface{}{"rating": rating}
+		}
+	case ballotPetition:
+		if support, ok := s.petitions[key]; ok {
+			return map[string]interface{}{"support": support}
+		}
+	case ballotChoice:
+		if optionIds, ok := s.choices[key]; ok {
+			sorted := append([]int{}, optionIds...)
+			sort.Ints(sorted)
+			return map[string]interface{}{"option_ids": sorted}
+		}
+	case ballotRanked:
+		if optionIds, ok := s.rankings[key]; ok {
+			return map[string]interface{}{"option_ids": optionIds}
+		}
+	case ballotAllocation:
+		if ballot, ok := s.allocations[key]; ok {
+			sorted := append([]Allocation{}, ballot...)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i].OptionID < sorted[j].OptionID })
+			allocations := make([]map[string]interface{}, 0, len(sorted))
+			for _, allocation := range sorted {
+				allocations = append(allocations, map[string]interface{}{"option_id": allocation.OptionID, "points": allocation.Points})
+			}
+			return map[string]interface{}{"allocations": allocations}
+		}
+	case ballotSurvey:
+		if response, ok := s.surveys[key]; ok {
+			return map[string]interface{}{"submitted": response.Submitted, "answers": answerSnapshots(response.Answers, "question_id")}
+		}
+	case ballotFollowUps:
+		if answers := s.followUps[key]; len(answers) > 0 {
+			return map[string]interface{}{"answers": answerSnapshots(answers, "follow_up_id")}
+		}
+	}
+	return nil
+}
+
+func answerSnapshots(answers []SurveyAnswer, idKey string) []map[string]interface{} {
+	sorted := append([]SurveyAnswer{}, answers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].QuestionID < sorted[j].QuestionID })
+	snapshots := make([]map[string]interface{}, 0, len(sorted))
+	for _, answer := range sorted {
+		optionIds := append([]int{}, answer.OptionIDs...)
+		sort.Ints(optionIds)
+		var text *string
+		if answer.Text != "" {
+			text = &answer.Text
+		}
+		snapshots = append(snapshots, map[string]interface{}{
+			idKey: answer.QuestionID, "rating": answer.Rating, "text": text, "option_ids": optionIds,
+		})
+	}
+	return snapshots
+}
+
+func (s *MemoryStorage) GetBallotHistory(ctx context.Context, voterId string, voteId int) ([]BallotChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	changes := s.history[ballotKey{voteId, voterId}]
+	history := make([]BallotChange, 0, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		history = append(history, changes[i])
+	}
+	return history, nil
+}
+
+func (s *MemoryStorage) WithdrawVote(ctx context.Context, voterId string, voteId int) error {
+	const op = "storage.memory.WithdrawVote"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote, err := s.checkBallotAllowed(voteId, Categories...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key := ballotKey{voteId, voterId}
+	kind := ballotKinds[vote.Category]
+	before := s.ballotSnapshot(kind, key)
+	if before == nil {
+		return fmt.Errorf("%s: vote %d: %w", op, voteId, ErrBallotNotFound)
+	}
+	delete(s.rates, key)
+	delete(s.petitions, key)
+	delete(s.choices, key)
+	delete(s.rankings, key)
+	delete(s.allocations, key)
+	delete(s.surveys, key)
+	delete(s.followUps, key)
+	delete(s.comments, key)
+	if err := s.recordBallot(ctx, kind, key, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// recordBallot mirrors the PostgreSQL version. Snapshots are compared in
+// their audit encoding.
+func (s *MemoryStorage) recordBallot(ctx context.Context, kind string, key ballotKey, before interface{}) error {
+	after := s.ballotSnapshot(kind, key)
+	encodedBefore, err := auditValue(before)
+	if err != nil {
+		return err
+	}
+	encodedAfter, err := auditValue(after)
+	if err != nil {
+		return err
+	}
+	if action := ballotAction(encodedBefore, encodedAfter); action != "" {
+		s.history[key] = append(s.history[key], BallotChange{Action: action, ChangedAt: s.opts.clock.Now()})
+	}
+	return s.auditBallot(ctx, kind, key, encodedBefore, encodedAfter)
+}
@@ -0,0 +1,943 @@
+package storage
+
+import (
+	"context"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestStorage(t *testing.T, votes ...Vote) (*MemoryStorage, []*Vote) {
+	t.Helper()
+	s := NewMemoryStorage(WithClock(clock.NewFake(validateNow)))
+	created := make([]*Vote, 0, len(votes))
+	for _, vote := range votes {
+		if err := ValidateVote(&vote, validateNow); err != nil {
+			t.Fatal(err)
+		}
+		stored, err := s.CreateVote(context.Background(), &vote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, stored)
+	}
+	return s, created
+}
+
+func TestUpdateVoteRenamesOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(a, b VoteOption) []VoteOption
+		want []string
+		kept []bool
+	}{
+		{
+			name: "swap",
+			edit: func(a, b VoteOption) []VoteOption {
+				a.Text, b.Text = b.Text, a.Text
+				return []VoteOption{a, b}
+			},
+			want: []string{"B", "A"},
+			kept: []bool{true, true},
+		},
+		{
+			name: "old text for a new option",
+			edit: func(a, b VoteOption) []VoteOption {
+				a.Text = "C"
+				return []VoteOption{{Text: "A"}, a, b}
+			},
+			want: []string{"A", "C", "B"},
+			kept: []bool{false, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vote := validVote("choice")
+			vote.Status = StatusScheduled
+			vote.StartTime = validateNow.Add(time.Hour)
+			s, created := newTestStorage(t, vote)
+			original := created[0]
+
+			edited := *original
+			edited.Options = tt.edit(original.Options[0], original.Options[1])
+			updated, err := s.UpdateVote(context.Background(), &edited)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := OptionTexts(updated.Options); len(got) != len(tt.want) {
+				t.Fatalf("got options %q, want %q", got, tt.want)
+			}
+			seen := make(map[int]bool)
+			for i, option := range updated.Options {
+				if option.Text != tt.want[i] {
+					t.Errorf("option %d is %q, want %q", i, option.Text, tt.want[i])
+				}
+				if seen[option.ID] {
+					t.Errorf("option ID %d is used twice", option.ID)
+				}
+				seen[option.ID] = true
+				if kept := option.ID == original.Options[0].ID || option.ID == original.Options[1].ID; kept != tt.kept[i] {
+					t.Errorf("option %q kept its ID: %v, want %v", option.Text, kept, tt.kept[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateVoteLocksRules(t *testing.T) {
+	scheduled := validVote("rate")
+	scheduled.Status = StatusScheduled
+	scheduled.StartTime = validateNow.Add(time.Hour)
+	open := validVote("rate")
+	open.Status = StatusOpen
+
+	tests := []struct {
+		name   string
+		vote   Vote
+		ballot bool
+		edit   func(*Vote)
+		err    error
+	}{
+		{"scheduled scale", scheduled, false, func(v *Vote) { v.Scale = RateScale{Min: 0, Max: 10, Step: 1} }, nil},
+		{"scheduled category", scheduled, false, func(v *Vote) { v.Category = "petition" }, nil},
+		{"open scale", open, false, func(v *Vote) { v.Scale = RateScale{Min: 0, Max: 10, Step: 1} }, ErrRulesLocked},
+		{"open category", open, false, func(v *Vote) { v.Category = "petition" }, ErrRulesLocked},
+		{"open name", open, false, func(v *Vote) { v.Name = "Renamed" }, nil},
+		{"voted scale", open, true, func(v *Vote) { v.Scale = RateScale{Min: 1, Max: 9, Step: 2} }, ErrRulesLocked},
+		{"voted description", open, true, func(v *Vote) { v.Description = "More detail" }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, created := newTestStorage(t, tt.vote)
+			if tt.ballot {
+				if err := s.VoteRate(context.Background(), "voter", created[0].ID, 3, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			edited := *created[0]
+			tt.edit(&edited)
+			if err := ValidateVote(&edited, validateNow); err != nil {
+				t.Fatal(err)
+			}
+			_, err := s.UpdateVote(context.Background(), &edited)
+			checkValidation(t, err, tt.err)
+		})
+	}
+}
+
+func TestUpdateVoteLocksOptions(t *testing.T) {
+	scheduled := validVote("choice")
+	scheduled.Status = StatusScheduled
+	scheduled.StartTime = validateNow.Add(time.Hour)
+	open := validVote("choice")
+	open.Status = StatusOpen
+
+	tests := []struct {
+		name   string
+		vote   Vote
+		ballot bool
+		edit   func(options []VoteOption) []VoteOption
+		err    error
+	}{
+		{"scheduled removal", scheduled, false, func(o []VoteOption) []VoteOption { return []VoteOption{o[0], {Text: "C"}} }, nil},
+		{"open removal", open, false, func(o []VoteOption) []VoteOption { return []VoteOption{o[0], {Text: "C"}} }, ErrRulesLocked},
+		{"voted removal", open, true, func(o []VoteOption) []VoteOption { return []VoteOption{o[0], {Text: "C"}} }, ErrRulesLocked},
+		{"voted rename", open, true, func(o []VoteOption) []VoteOption {
+			o[1].Text = "C"
+			return o
+		}, ErrRulesLocked},
+		{"voted rename by text", open, true, func(o []VoteOption) []VoteOption { return []VoteOption{{Text: "A"}, {Text: "C"}} }, ErrRulesLocked},
+		{"voted addition", open, true, func(o []VoteOption) []VoteOption { return append(o, VoteOption{Text: "C"}) }, nil},
+		{"voted reorder", open, true, func(o []VoteOption) []VoteOption { return []VoteOption{o[1], o[0]} }, nil},
+		{"voted match by text", open, true, func(o []VoteOption) []VoteOption { return []VoteOption{{Text: "B"}, {Text: "A"}} }, nil},
+		{"voted description", open, true, func(o []VoteOption) []VoteOption {
+			o[1].Description = "More detail"
+			return o
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, created := newTestStorage(t, tt.vote)
+			original := created[0]
+			if tt.ballot {
+				if err := s.VoteChoice(context.Background(), "voter", original.ID, []int{original.Options[1].ID}, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			edited := *original
+			edited.Options = tt.edit(append([]VoteOption{}, original.Options...))
+			if err := ValidateVote(&edited, validateNow); err != nil {
+				t.Fatal(err)
+			}
+			_, err := s.UpdateVote(context.Background(), &edited)
+			checkValidation(t, err, tt.err)
+
+			if !tt.ballot {
+				return
+			}
+			info, err := s.GetChoiceInfo(context.Background(), original.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Ballots != 1 {
+				t.Errorf("got %d ballots, want the ballot to survive the edit", info.Ballots)
+			}
+		})
+	}
+}
+
+func TestUpdateVoteLocksQuestions(t *testing.T) {
+	survey := validVote("survey")
+	survey.Status = StatusOpen
+	survey.Questions = []Question{
+		{Kind: QuestionRating, Text: "How?"},
+		{Kind: QuestionSingle, Text: "Which?", Options: []VoteOption{{Text: "A"}, {Text: "B"}}},
+	}
+
+	tests := []struct {
+		name     string
+		response bool
+		edit     func(questions []Question) []Question
+		err      error
+	}{
+		{"open removal", false, func(q []Question) []Question { return q[:1] }, ErrRulesLocked},
+		{"answered removal", true, func(q []Question) []Question { return q[:1] }, ErrRulesLocked},
+		{"answered retype", true, func(q []Question) []Question {
+			q[0].Kind, q[0].Scale = QuestionText, RateScale{}
+			return q
+		}, ErrRulesLocked},
+		{"answered scale", true, func(q []Question) []Question {
+			q[0].Scale = RateScale{Min: 1, Max: 10, Step: 1}
+			return q
+		}, ErrRulesLocked},
+		{"answered rename", true, func(q []Question) []Question {
+			q[1].Text = "Whose?"
+			return q
+		}, ErrRulesLocked},
+		{"answered option removal", true, func(q []Question) []Question {
+			q[1].Options = append(q[1].Options[:1], VoteOption{Text: "C"})
+			return q
+		}, ErrRulesLocked},
+		{"answered addition", true, func(q []Question) []Question {
+			return append(q, Question{Kind: QuestionText, Text: "Why?"})
+		}, nil},
+		{"answered reorder", true, func(q []Question) []Question { return []Question{q[1], q[0]} }, nil},
+		{"answered required", true, func(q []Question) []Question {
+			q[1].Required = true
+			return q
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, created := newTestStorage(t, survey)
+			original := created[0]
+			if tt.response {
+				rating := 3
+				answers := []SurveyAnswer{{QuestionID: original.Questions[0].ID, Rating: &rating}}
+				if err := s.SubmitSurvey(context.Background(), "voter", original.ID, answers, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			edited := *original
+			edited.Questions = tt.edit(copyQuestions(original.Questions))
+			if err := ValidateVote(&edited, validateNow); err != nil {
+				t.Fatal(err)
+			}
+			_, err := s.UpdateVote(context.Background(), &edited)
+			checkValidation(t, err, tt.err)
+
+			if !tt.response {
+				return
+			}
+			response, err := s.GetSurveyResponse(context.Background(), "voter", original.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(response.Answers) != 1 {
+				t.Errorf("got %d answers, want the answer to survive the edit", len(response.Answers))
+			}
+		})
+	}
+}
+
+func TestUpdateVoteLocksFollowUps(t *testing.T) {
+	open := validVote("rate")
+	open.Status = StatusOpen
+	open.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{MinRating: 1, MaxRating: 3}}}
+
+	tests := []struct {
+		name string
+		edit func(followUps []FollowUp) []FollowUp
+		err  error
+	}{
+		{"removal", func(f []FollowUp) []FollowUp { return nil }, ErrRulesLocked},
+		{"trigger", func(f []FollowUp) []FollowUp {
+			f[0].Trigger.MaxRating = 2
+			return f
+		}, ErrRulesLocked},
+		{"retype", func(f []FollowUp) []FollowUp {
+			f[0].Kind, f[0].Scale = QuestionRating, RateScale{Min: 1, Max: 5, Step: 1}
+			return f
+		}, ErrRulesLocked},
+		{"addition", func(f []FollowUp) []FollowUp {
+			return append(f, FollowUp{Kind: QuestionText, Text: "What else?", Trigger: FollowUpTrigger{MinRating: 4, MaxRating: 5}})
+		}, nil},
+		{"unchanged", func(f []FollowUp) []FollowUp { return f }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, created := newTestStorage(t, open)
+			original := created[0]
+			if err := s.VoteRate(context.Background(), "voter", original.ID, 2, nil); err != nil {
+				t.Fatal(err)
+			}
+			answer := []SurveyAnswer{{QuestionID: original.FollowUps[0].ID, Text: "Too far"}}
+			if err := s.AnswerFollowUps(context.Background(), "voter", original.ID, answer); err != nil {
+				t.Fatal(err)
+			}
+			edited := *original
+			edited.FollowUps = tt.edit(copyFollowUps(original.FollowUps))
+			if err := ValidateVote(&edited, validateNow); err != nil {
+				t.Fatal(err)
+			}
+			_, err := s.UpdateVote(context.Background(), &edited)
+			checkValidation(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeleteVoteKeepsBallots(t *testing.T) {
+	s, created := newTestStorage(t, validVote("rate"), validVote("rate"))
+	voted, unvoted := created[0], created[1]
+	if _, err := s.SetVoteStatus(context.Background(), voted.ID, StatusOpen); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VoteRate(context.Background(), "voter", voted.ID, 3, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	checkValidation(t, s.DeleteVote(context.Background(), voted.ID), ErrVoteHasBallots)
+	info, err := s.GetRateInfo(context.Background(), voted.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mid != 3 {
+		t.Errorf("got average %v, want the ballot to survive", info.Mid)
+	}
+	checkValidation(t, s.DeleteVote(context.Background(), unvoted.ID), nil)
+}
+
+func TestWithdrawVote(t *testing.T) {
+	vote := validVote("choice")
+	vote.Status = StatusOpen
+	vote.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{Options: []string{"B"}}}}
+	if err := ValidateVote(&vote, validateNow); err != nil {
+		t.Fatal(err)
+	}
+	fake := clock.NewFake(validateNow)
+	s := NewMemoryStorage(WithClock(fake))
+	ctx := context.Background()
+	created, err := s.CreateVote(ctx, &vote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := created.Options[0].ID, created.Options[1].ID
+	followUp := created.FollowUps[0].ID
+
+	if err := s.VoteChoice(ctx, "voter", created.ID, []int{a}, nil); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(time.Minute)
+	if err := s.VoteChoice(ctx, "voter", created.ID, []int{b}, &Comment{Text: "Steel rusts", Status: CommentPending}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AnswerFollowUps(ctx, "voter", created.ID, []SurveyAnswer{{QuestionID: followUp, Text: "Cheaper"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VoteChoice(ctx, "other", created.ID, []int{a}, nil); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(time.Minute)
+	if err := s.WithdrawVote(ctx, "voter", created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := s.GetChoiceInfo(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Ballots != 1 || info.Stats["A"] != 1 || info.Stats["B"] != 0 {
+		t.Errorf("got %d ballots with %v, want the other ballot only", info.Ballots, info.Stats)
+	}
+	comment, err := s.GetUserComment(ctx, "voter", created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment != nil {
+		t.Errorf("got comment %q, want it removed with the ballot", comment.Text)
+	}
+	// Casting the ballot again asks the follow-up again: its answer is gone.
+	if err := s.VoteChoice(ctx, "voter", created.ID, []int{b}, nil); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := s.GetPendingFollowUps(ctx, "voter", created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ID != followUp {
+		t.Errorf("got %d pending follow-ups, want the answered one again", len(pending))
+	}
+
+	history, err := s.GetBallotHistory(ctx, "voter", created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BallotChange{
+		{BallotCast, validateNow.Add(2 * time.Minute)},
+		{BallotWithdrawn, validateNow.Add(2 * time.Minute)},
+		{BallotChanged, validateNow.Add(time.Minute)},
+		{BallotCast, validateNow},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("got history %v, want %v", history, want)
+	}
+	for i := range want {
+		if history[i].Action != want[i].Action || !history[i].ChangedAt.Equal(want[i].ChangedAt) {
+			t.Errorf("history entry %d is %v, want %v", i, history[i], want[i])
+		}
+	}
+}
+
+func TestWithdrawVoteRefused(t *testing.T) {
+	s, created := newTestStorage(t, validVote("rate"), validVote("rate"))
+	ctx := context.Background()
+	for _, vote := range created {
+		if _, err := s.SetVoteStatus(ctx, vote.ID, StatusOpen); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.VoteRate(ctx, "voter", vote.ID, 3, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	closed := created[1].ID
+	if _, err := s.SetVoteStatus(ctx, closed, StatusClosed); err != nil {
+		t.Fatal(err)
+	}
+
+	checkValidation(t, s.WithdrawVote(ctx, "voter", closed), ErrVoteClosed)
+	info, err := s.GetRateInfo(ctx, closed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mid != 3 {
+		t.Errorf("got average %v, want the ballot on the closed vote to count", info.Mid)
+	}
+	checkValidation(t, s.WithdrawVote(ctx, "nobody", created[0].ID), ErrBallotNotFound)
+	checkValidation(t, s.WithdrawVote(ctx, "voter", created[0].ID), nil)
+	checkValidation(t, s.WithdrawVote(ctx, "voter", created[0].ID), ErrBallotNotFound)
+}
+
+func TestSearchVotesEscapesHTML(t *testing.T) {
+	park := validVote("rate")
+	park.Status = StatusOpen
+	park.Name = `Парк <img src=x onerror="alert(1)">`
+	park.Description = "Новый парк & <b>сквер</b>"
+	library := validVote("rate")
+	library.Status = StatusOpen
+	library.Name = "Часы работы <библиотеки>"
+	s, _ := newTestStorage(t, park, library)
+
+	tests := []struct {
+		query    string
+		fuzzy    bool
+		headline string
+		snippet  string
+	}{
+		{"парк", false, "<b>Парк</b> &lt;img src=x onerror=&#34;alert(1)&#34;&gt;", "Новый <b>парк</b> &amp; &lt;b&gt;сквер&lt;/b&gt;"},
+		{"библиотка", true, "Часы работы &lt;библиотеки&gt;", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query := SearchQuery{Text: tt.query}
+			if err := ValidateSearch(&query); err != nil {
+				t.Fatal(err)
+			}
+			found, err := s.SearchVotes(context.Background(), query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found.Fuzzy != tt.fuzzy || len(found.Results) != 1 {
+				t.Fatalf("got %d results, fuzzy %v, want 1, fuzzy %v", len(found.Results), found.Fuzzy, tt.fuzzy)
+			}
+			if result := found.Results[0]; result.Headline != tt.headline || result.Snippet != tt.snippet {
+				t.Errorf("got headline %q and snippet %q, want %q and %q", result.Headline, result.Snippet, tt.headline, tt.snippet)
+			}
+		})
+	}
+}
+
+func TestAdvanceVoteStatusesAudits(t *testing.T) {
+	scheduled := validVote("rate")
+	scheduled.Status = StatusScheduled
+	scheduled.StartTime = validateNow.Add(time.Hour)
+	ending := validVote("rate")
+	ending.Status = StatusOpen
+	ending.EndTime = validateNow.Add(time.Hour)
+	petition := validVote("petition")
+	petition.Status = StatusOpen
+	petition.SignatureGoal = 1
+
+	fake := clock.NewFake(validateNow)
+	s := NewMemoryStorage(WithClock(fake))
+	ids := make([]int, 0, 3)
+	for _, vote := range []Vote{scheduled, ending, petition} {
+		if err := ValidateVote(&vote, validateNow); err != nil {
+			t.Fatal(err)
+		}
+		created, err := s.CreateVote(context.Background(), &vote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, created.ID)
+	}
+	if err := s.VotePetition(context.Background(), "voter", ids[2], signatureSupport); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(2 * time.Hour)
+	if _, err := s.AdvanceVoteStatuses(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := s.AuditLog(context.Background(), "", 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type change struct {
+		stream        string
+		seq           int64
+		actor, action string
+	}
+	want := []change{
+		{"vote:" + strconv.Itoa(ids[0]), 1, "", "vote.create"},
+		{"vote:" + strconv.Itoa(ids[0]), 2, actorLifecycle, "vote.status"},
+		{"vote:" + strconv.Itoa(ids[1]), 1, "", "vote.create"},
+		{"vote:" + strconv.Itoa(ids[1]), 2, actorLifecycle, "vote.status"},
+		{"vote:" + strconv.Itoa(ids[2]), 1, "", "vote.create"},
+		{"vote:" + strconv.Itoa(ids[2]), 2, "voter", "ballot.petition"},
+		{"vote:" + strconv.Itoa(ids[2]), 3, actorLifecycle, "vote.status"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d audit entries, want %d", len(entries), len(want))
+	}
+	verifier := audit.NewVerifier()
+	for i, entry := range entries {
+		if got := (change{entry.Stream, entry.Seq, entry.Actor, entry.Action}); got != want[i] {
+			t.Errorf("entry %d is %+v, want %+v", i+1, got, want[i])
+		}
+		if err := verifier.Check(&entry); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditLogPages(t *testing.T) {
+	s, _ := newTestStorage(t, validVote("rate"), validVote("rate"), validVote("petition"))
+	all, err := s.AuditLog(context.Background(), "", 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paged []audit.Entry
+	var afterStream string
+	var afterSeq int64
+	for {
+		page, err := s.AuditLog(context.Background(), afterStream, afterSeq, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paged = append(paged, page...)
+		if len(page) < 2 {
+			break
+		}
+		afterStream, afterSeq = page[len(page)-1].Stream, page[len(page)-1].Seq
+	}
+	if len(paged) != len(all) || len(all) != 3 {
+		t.Fatalf("got %d entries in pages and %d at once, want 3", len(paged), len(all))
+	}
+	for i := range all {
+		if paged[i].Hash != all[i].Hash {
+			t.Errorf("entry %d differs between pages and the whole log", i+1)
+		}
+	}
+}
+
+func TestVoteAllocation(t *testing.T) {
+	tests := []struct {
+		category string
+		credits  []int
+	}{
+		{"score", []int{3, 3, 0}},
+		{"quadratic", []int{5, 5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			vote := validVote(tt.category)
+			vote.Status = StatusOpen
+			vote.Options = append(vote.Options, VoteOption{Text: "C"})
+			rate := validVote("rate")
+			rate.Status = StatusOpen
+			s, created := newTestStorage(t, vote, rate)
+			ctx := context.Background()
+			voteId := created[0].ID
+			a, b, c := created[0].Options[0].ID, created[0].Options[1].ID, created[0].Options[2].ID
+
+			for _, ballot := range []struct {
+				voterId     string
+				allocations []Allocation
+			}{
+				{"voter", []Allocation{{c, 1}, {a, 3}}},
+				{"other", []Allocation{{b, 2}, {a, 1}}},
+				{"voter", []Allocation{{b, 1}, {a, 2}}},
+			} {
+				if err := s.VoteAllocation(ctx, ballot.voterId, voteId, ballot.allocations); err != nil {
+					t.Fatal(err)
+				}
+			}
+			checkValidation(t, s.VoteAllocation(ctx, "voter", voteId, []Allocation{{a, 6}, {b, 5}}), ErrInvalidBallot)
+			checkValidation(t, s.VoteAllocation(ctx, "voter", created[1].ID, []Allocation{{a, 1}}), ErrWrongVoteType)
+
+			info, err := s.GetAllocationInfo(ctx, voteId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Ballots != 2 || info.Budget != vote.Budget {
+				t.Errorf("got %d ballots with a budget of %d, want 2 with %d", info.Ballots, info.Budget, vote.Budget)
+			}
+			want := []OptionAllocation{
+				{OptionID: a, Total: 3, Credits: tt.credits[0], Supporters: 2, Distribution: map[int]int{1: 1, 2: 1}},
+				{OptionID: b, Total: 3, Credits: tt.credits[1], Supporters: 2, Distribution: map[int]int{1: 1, 2: 1}},
+				{OptionID: c, Total: 0, Credits: tt.credits[2], Supporters: 0, Distribution: map[int]int{}},
+			}
+			if !reflect.DeepEqual(info.Results, want) {
+				t.Errorf("got results %+v, want %+v", info.Results, want)
+			}
+
+			ballots, err := s.GetUserAllocations(ctx, "voter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantBallot := []Allocation{{a, 2}, {b, 1}}
+			if len(ballots) != 1 || ballots[0].ID != voteId || !reflect.DeepEqual(ballots[0].Allocations, wantBallot) {
+				t.Errorf("got ballots %+v, want %v in display order", ballots, wantBallot)
+			}
+		})
+	}
+}
+
+func TestFollowUps(t *testing.T) {
+	vote := validVote("rate")
+	vote.Status = StatusOpen
+	vote.FollowUps = []FollowUp{
+		{Kind: QuestionText, Text: "Why so low?", Trigger: FollowUpTrigger{MinRating: 1, MaxRating: 2}},
+		{Kind: QuestionText, Text: "What did you like?", Trigger: FollowUpTrigger{MinRating: 4, MaxRating: 5}},
+	}
+	s, created := newTestStorage(t, vote)
+	ctx := context.Background()
+	voteId := created[0].ID
+	low, high := created[0].FollowUps[0].ID, created[0].FollowUps[1].ID
+
+	checkPending := func(want ...int) {
+		t.Helper()
+		pending, err := s.GetPendingFollowUps(ctx, "voter", voteId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]int, 0, len(pending))
+		for _, followUp := range pending {
+			got = append(got, followUp.ID)
+		}
+		if !reflect.DeepEqual(got, append([]int{}, want...)) {
+			t.Errorf("got pending follow-ups %v, want %v", got, want)
+		}
+	}
+	rate := func(rating int) {
+		t.Helper()
+		if err := s.VoteRate(ctx, "voter", voteId, rating, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkPending()
+	rate(2)
+	checkPending(low)
+
+	rating := 3
+	checkValidation(t, s.AnswerFollowUps(ctx, "voter", voteId, []SurveyAnswer{{QuestionID: high, Text: "Clean"}}), ErrInvalidBallot)
+	checkValidation(t, s.AnswerFollowUps(ctx, "voter", voteId, []SurveyAnswer{{QuestionID: 999, Text: "Late"}}), ErrInvalidQuestion)
+	checkValidation(t, s.AnswerFollowUps(ctx, "voter", voteId, []SurveyAnswer{{QuestionID: low, Rating: &rating}}), ErrInvalidBallot)
+	checkValidation(t, s.AnswerFollowUps(ctx, "other", voteId, []SurveyAnswer{{QuestionID: low, Text: "Late"}}), ErrInvalidBallot)
+	checkPending(low)
+
+	checkValidation(t, s.AnswerFollowUps(ctx, "voter", voteId, []SurveyAnswer{{QuestionID: low, Text: "Buses are late"}}), nil)
+	checkPending()
+	// A rating in the same range keeps the answer, leaving the range drops it.
+	rate(1)
+	checkPending()
+	rate(5)
+	checkPending(high)
+	rate(2)
+	checkPending(low)
+}
+
+func TestPetitionResponse(t *testing.T) {
+	petition := validVote("petition")
+	petition.Status = StatusOpen
+	petition.Organization = "Администрация"
+	petition.EndTime = validateNow.Add(30 * 24 * time.Hour)
+	petition.SignatureGoal = 2
+	draft := validVote("petition")
+	draft.Status = StatusDraft
+	rate := validVote("rate")
+	rate.Status = StatusOpen
+	for _, vote := range []*Vote{&petition, &draft, &rate} {
+		if err := ValidateVote(vote, validateNow); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake := clock.NewFake(validateNow)
+	s := NewMemoryStorage(WithClock(fake), WithResponseWindow(48*time.Hour))
+	ctx := context.Background()
+	var ids []int
+	for _, vote := range []*Vote{&petition, &draft, &rate} {
+		created, err := s.CreateVote(ctx, vote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, created.ID)
+	}
+	voteId := ids[0]
+
+	for _, voterId := range []string{"first", "second"} {
+		if err := s.VotePetition(ctx, voterId, voteId, signatureSupport); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := s.GetPetitionInfo(ctx, voteId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := validateNow.Add(48 * time.Hour)
+	if info.Status != StatusAwaitingResponse || !info.ResponseDue.Equal(due) || info.Signatures != 2 {
+		t.Fatalf("got %s with %d signatures due %v, want %s due %v", info.Status, info.Signatures, info.ResponseDue, StatusAwaitingResponse, due)
+	}
+
+	fake.Advance(47 * time.Hour)
+	if changes, err := s.AdvanceVoteStatuses(ctx); err != nil || changes.Overdue != 0 {
+		t.Fatalf("got %+v, %v before the deadline", changes, err)
+	}
+	fake.Advance(time.Hour)
+	if changes, err := s.AdvanceVoteStatuses(ctx); err != nil || changes.Overdue != 1 {
+		t.Fatalf("got %+v, %v at the deadline, want one overdue petition", changes, err)
+	}
+	overdue, err := s.ListOverduePetitions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overdue) != 1 || overdue[0].ID != voteId || !overdue[0].ResponseOverdue {
+		t.Fatalf("got %d overdue petitions, want the answered one", len(overdue))
+	}
+
+	_, err = s.PublishResponse(ctx, &PetitionResponse{VoteID: ids[1], Decision: DecisionRejected, Text: "Нет"})
+	checkValidation(t, err, ErrInvalidTransition)
+	_, err = s.PublishResponse(ctx, &PetitionResponse{VoteID: ids[2], Decision: DecisionRejected, Text: "Нет"})
+	checkValidation(t, err, ErrWrongVoteType)
+
+	published, err := s.PublishResponse(ctx, &PetitionResponse{
+		VoteID:      voteId,
+		Decision:    DecisionPartiallyAccepted,
+		Text:        "Сквер будет благоустроен в следующем году",
+		Attachments: []Attachment{{Name: "План", URL: "https://example.org/plan.pdf"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.Organization != petition.Organization || !published.RespondedAt.Equal(validateNow.Add(48*time.Hour)) {
+		t.Errorf("got a response of %q at %v, want the petition organization at the deadline", published.Organization, published.RespondedAt)
+	}
+	if info, err = s.GetPetitionInfo(ctx, voteId); err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != StatusClosed || info.Response == nil || info.Response.Decision != DecisionPartiallyAccepted || len(info.Response.Attachments) != 1 {
+		t.Errorf("got %s with response %+v, want it closed with the published response", info.Status, info.Response)
+	}
+	if overdue, err = s.ListOverduePetitions(ctx); err != nil || len(overdue) != 0 {
+		t.Errorf("got %d overdue petitions and error %v after the response", len(overdue), err)
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	city, err := s.CreateOrganization(ctx, &Organization{Name: "Администрация города", Verified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CreateOrganization(ctx, &Organization{Name: "администрация ГОРОДА"})
+	checkValidation(t, err, ErrConflict)
+
+	create := func(organization string, organizationId int, status string) (*Vote, error) {
+		t.Helper()
+		vote := validVote("rate")
+		vote.Organization, vote.OrganizationID, vote.Status = organization, organizationId, status
+		if err := ValidateVote(&vote, validateNow); err != nil {
+			t.Fatal(err)
+		}
+		return s.CreateVote(ctx, &vote)
+	}
+	byName, err := create("администрация города", 0, StatusOpen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byName.OrganizationID != city.ID || byName.Organization != city.Name {
+		t.Errorf("got organization %d %q, want %d %q", byName.OrganizationID, byName.Organization, city.ID, city.Name)
+	}
+	if _, err := create("", city.ID, StatusDraft); err != nil {
+		t.Fatal(err)
+	}
+	parks, err := create("Парки", 0, StatusOpen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = create("", 999, StatusOpen)
+	checkValidation(t, err, ErrOrganizationNotFound)
+
+	organizations, err := s.ListOrganizations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Organization{
+		{ID: city.ID, Name: "Администрация города", Verified: true, Votes: 1},
+		{ID: parks.OrganizationID, Name: "Парки", Votes: 1},
+	}
+	if !reflect.DeepEqual(organizations, want) {
+		t.Errorf("got organizations %+v, want %+v", organizations, want)
+	}
+
+	votes, err := s.GetVotes(ctx, VoteFilter{OrganizationID: city.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 || votes[0].ID != byName.ID {
+		t.Errorf("got %d votes of the organization, want the public one", len(votes))
+	}
+
+	renamed := *city
+	renamed.Name = "Мэрия"
+	if _, err := s.UpdateOrganization(ctx, &renamed); err != nil {
+		t.Fatal(err)
+	}
+	vote, err := s.GetVote(ctx, byName.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vote.Organization != "Мэрия" {
+		t.Errorf("got organization %q, want the new name", vote.Organization)
+	}
+
+	_, err = s.GetOrganization(ctx, 999)
+	checkValidation(t, err, ErrOrganizationNotFound)
+	_, err = s.UpdateOrganization(ctx, &Organization{ID: 999, Name: "Нет"})
+	checkValidation(t, err, ErrOrganizationNotFound)
+	renamed.ID, renamed.Name = parks.OrganizationID, "мэрия"
+	_, err = s.UpdateOrganization(ctx, &renamed)
+	checkValidation(t, err, ErrConflict)
+}
+
+func TestTopics(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	if _, err := s.CreateTopic(ctx, &Topic{Slug: "parks", Name: "Парки"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.CreateTopic(ctx, &Topic{Slug: "parks", Name: "Скверы"})
+	checkValidation(t, err, ErrConflict)
+
+	create := func(category, status string, topics ...string) (*Vote, error) {
+		t.Helper()
+		vote := validVote(category)
+		vote.Status, vote.Topics = status, topics
+		if err := ValidateVote(&vote, validateNow); err != nil {
+			t.Fatal(err)
+		}
+		return s.CreateVote(ctx, &vote)
+	}
+	choice, err := create("choice", StatusOpen, "transport", "parks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"parks", "transport"}; !reflect.DeepEqual(choice.Topics, want) {
+		t.Errorf("got topics %q, want %q", choice.Topics, want)
+	}
+	rate, err := create("rate", StatusOpen, "parks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := create("rate", StatusDraft, "parks"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = create("rate", StatusOpen, "space")
+	checkValidation(t, err, ErrTopicNotFound)
+
+	tests := []struct {
+		filter VoteFilter
+		want   []int
+	}{
+		{VoteFilter{Topic: "parks"}, []int{choice.ID, rate.ID}},
+		{VoteFilter{Topic: "parks", Category: "rate"}, []int{rate.ID}},
+		{VoteFilter{Topic: "transport"}, []int{choice.ID}},
+		{VoteFilter{Topic: "culture"}, []int{}},
+	}
+	for _, tt := range tests {
+		votes, err := s.GetVotes(ctx, tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]int, 0, len(votes))
+		for _, vote := range votes {
+			got = append(got, vote.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v: got votes %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	if _, err := s.UpdateTopic(ctx, &Topic{Slug: "parks", Name: "Парки и скверы"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateTopic(ctx, &Topic{Slug: "space", Name: "Космос"})
+	checkValidation(t, err, ErrTopicNotFound)
+
+	topics, err := s.ListTopics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != len(DefaultTopics)+1 {
+		t.Fatalf("got %d topics, want %d", len(topics), len(DefaultTopics)+1)
+	}
+	counts := make(map[string]int)
+	for i, topic := range topics {
+		if i > 0 && topics[i-1].Name > topic.Name {
+			t.Errorf("topic %q is listed after %q", topic.Name, topics[i-1].Name)
+		}
+		counts[topic.Slug] = topic.Votes
+		if topic.Slug == "parks" && topic.Name != "Парки и скверы" {
+			t.Errorf("got name %q, want the new name", topic.Name)
+		}
+	}
+	if counts["parks"] != 2 || counts["transport"] != 1 || counts["culture"] != 0 {
+		t.Errorf("got vote counts %v, want 2 parks and 1 transport votes", counts)
+	}
+}
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the pg_advisory_lock key that serializes migrations
+// between service instances starting at the same time.
+const migrationLockID = 7_240_517_001
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+	Applied   bool
+}
+
+// Migrations returns the embedded migrations ordered by version. Files are
+// named <version>_<name>.up.sql and <version>_<name>.down.sql.
+func Migrations() ([]Migration, error) {
+	const op = "storage.migrate.Migrations"
+
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base, direction, ok := cutMigrationSuffix(fileName)
+		if !ok {
+			return nil, fmt.Errorf("%s: unexpected migration file %q", op, fileName)
+		}
+		versionPart, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("%s: migration %q has no name", op, fileName)
+		}
+		version, err := strconv.Atoi(versionPart)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%s: migration %q has invalid version", op, fileName)
+		}
+
+		body, err := migrationFiles.ReadFile(path.Join("migrations", fileName))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("%s: version %d is used by %q and %q", op, version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("%s: migration %d_%s has no up script", op, m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func cutMigrationSuffix(fileName string) (string, string, bool) {
+	if base, ok := strings.CutSuffix(fileName, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok := strings.CutSuffix(fileName, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// Migrate applies every pending migration.
+func (s *PostgresStorage) Migrate(ctx context.Context) error {
+	migrations, err := Migrations()
+	if err != nil {
+		return err
+	}
+	return s.MigrateTo(ctx, migrations[len(migrations)-1].Version)
+}
+
+// MigrateTo moves the schema up or down to the given version. Version 0
+// reverts every migration.
+func (s *PostgresStorage) MigrateTo(ctx context.Context, target int) error {
+	const op = "storage.postgresql.MigrateTo"
+
+	migrations, err := Migrations()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if target != 0 && !hasMigration(migrations, target) {
+		return fmt.Errorf("%s: unknown migration version %d", op, target)
+	}
+
+	return s.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, m := range migrations {
+			if m.Version > target || applied[m.Version] {
+				continue
+			}
+			if err := runMigration(ctx, conn, m.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+				return fmt.Errorf("%s: apply %d_%s: %w", op, m.Version, m.Name, err)
+			}
+		}
+
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if m.Version <= target || !applied[m.Version] {
+				continue
+			}
+			if m.Down == "" {
+				return fmt.Errorf("%s: migration %d_%s cannot be reverted", op, m.Version, m.Name)
+			}
+			if err := runMigration(ctx, conn, m.Down, `DELETE FROM schema_migrations WHERE version = $1`, m.Version); err != nil {
+				return fmt.Errorf("%s: revert %d_%s: %w", op, m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts the given number of most recently applied migrations.
+func (s *PostgresStorage) MigrateDown(ctx context.Context, steps int) error {
+	const op = "storage.postgresql.MigrateDown"
+
+	statuses, err := s.MigrationStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var applied []int
+	for _, st := range statuses {
+		if st.Applied {
+			applied = append(applied, st.Version)
+		}
+	}
+	if steps <= 0 || len(applied) == 0 {
+		return nil
+	}
+	if steps >= len(applied) {
+		return s.MigrateTo(ctx, 0)
+	}
+	return s.MigrateTo(ctx, applied[len(applied)-steps-1])
+}
+
+func (s *PostgresStorage) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	const op = "storage.postgresql.MigrationStatus"
+
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.db.Exec(ctx, createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		appliedAt[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		at, ok := appliedAt[m.Version]
+		statuses = append(statuses, MigrationStatus{Version: m.Version, Name: m.Name, AppliedAt: at, Applied: ok})
+	}
+	return statuses, nil
+}
+
+const createSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`
+
+// withMigrationLock runs fn on a single connection holding a session-level
+// advisory lock, so concurrent pods wait for each other instead of applying
+// the same migration twice.
+func (s *PostgresStorage) withMigrationLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	if _, err := conn.Exec(ctx, createSchemaMigrations); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]bool, error) {
+	rows, err := conn.Query(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func runMigration(ctx context.Context, conn *pgxpool.Conn, script, bookkeeping string, args ...any) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func hasMigration(migrations []Migration, version int) bool {
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS votes (
+    id SERIAL PRIMARY KEY,
+    category VARCHAR(255),
+    name TEXT,
+    description TEXT,
+    organization TEXT,
+    photo TEXT,
+    end_time TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS options (
+    vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
+    option VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS rate_results (
+    vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT,
+    rate INT,
+    UNIQUE (vote_id, user_token)
+);
+
+CREATE TABLE IF NOT EXISTS petition_results (
+    vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT,
+    support VARCHAR(50),
+    UNIQUE (vote_id, user_token)
+);
+
+CREATE TABLE IF NOT EXISTS choices_results (
+    vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT,
+    choice TEXT,
+    UNIQUE (vote_id, user_token)
+);
@@ -0,0 +1 @@
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS external_key TEXT UNIQUE;
@@ -0,0 +1 @@
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS start_time TIMESTAMP;
@@ -0,0 +1,12 @@
+ALTER TABLE choices_results DROP CONSTRAINT IF EXISTS choices_results_option_fkey;
+ALTER TABLE options DROP CONSTRAINT IF EXISTS options_vote_id_option_key;
+ALTER TABLE petition_results DROP CONSTRAINT IF EXISTS petition_results_support_check;
+
+DROP TRIGGER IF EXISTS rate_results_scale_check ON rate_results;
+DROP FUNCTION IF EXISTS check_rate_scale();
+
+ALTER TABLE votes
+    DROP CONSTRAINT IF EXISTS votes_rate_scale_check,
+    DROP COLUMN IF EXISTS rate_step,
+    DROP COLUMN IF EXISTS rate_max,
+    DROP COLUMN IF EXISTS rate_min;
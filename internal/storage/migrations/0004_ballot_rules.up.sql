@@ -0,0 +1,38 @@
+ALTER TABLE votes
+    ADD COLUMN rate_min INT NOT NULL DEFAULT 1,
+    ADD COLUMN rate_max INT NOT NULL DEFAULT 5,
+    ADD COLUMN rate_step INT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT votes_rate_scale_check
+        CHECK (rate_step > 0 AND rate_min < rate_max AND (rate_max - rate_min) % rate_step = 0);
+
+CREATE FUNCTION check_rate_scale() RETURNS trigger AS $$
+DECLARE
+    scale RECORD;
+BEGIN
+    SELECT rate_min, rate_max, rate_step INTO scale FROM votes WHERE id = NEW.vote_id;
+    IF FOUND AND (NEW.rate < scale.rate_min OR NEW.rate > scale.rate_max
+        OR (NEW.rate - scale.rate_min) % scale.rate_step <> 0) THEN
+        RAISE EXCEPTION 'rate % is outside the scale of vote %', NEW.rate, NEW.vote_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER rate_results_scale_check
+    BEFORE INSERT OR UPDATE ON rate_results
+    FOR EACH ROW EXECUTE FUNCTION check_rate_scale();
+
+-- Existing rows are left as they are; only new ballots must use the vocabulary.
+ALTER TABLE petition_results
+    ADD CONSTRAINT petition_results_support_check CHECK (support IN ('for', 'against')) NOT VALID;
+
+DELETE FROM options a
+    USING options b
+    WHERE a.ctid > b.ctid AND a.vote_id = b.vote_id AND a.option = b.option;
+
+ALTER TABLE options ADD CONSTRAINT options_vote_id_option_key UNIQUE (vote_id, option);
+
+ALTER TABLE choices_results
+    ADD CONSTRAINT choices_results_option_fkey FOREIGN KEY (vote_id, choice)
+        REFERENCES options (vote_id, option) ON UPDATE CASCADE ON DELETE CASCADE NOT VALID;
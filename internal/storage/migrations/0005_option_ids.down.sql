@@ -0,0 +1,20 @@
+ALTER TABLE choices_results DROP CONSTRAINT choices_results_option_fkey;
+ALTER TABLE choices_results ADD COLUMN choice TEXT;
+
+UPDATE choices_results c
+SET choice = o.option
+FROM options o
+WHERE o.id = c.option_id;
+
+ALTER TABLE choices_results DROP COLUMN option_id;
+
+ALTER TABLE options
+    DROP CONSTRAINT options_id_vote_id_key,
+    DROP COLUMN image,
+    DROP COLUMN description,
+    DROP COLUMN position,
+    DROP COLUMN id;
+
+ALTER TABLE choices_results
+    ADD CONSTRAINT choices_results_option_fkey FOREIGN KEY (vote_id, choice)
+        REFERENCES options (vote_id, option) ON UPDATE CASCADE ON DELETE CASCADE NOT VALID;
@@ -0,0 +1,33 @@
+ALTER TABLE choices_results DROP CONSTRAINT choices_results_option_fkey;
+
+ALTER TABLE options
+    ADD COLUMN id SERIAL PRIMARY KEY,
+    ADD COLUMN position INT NOT NULL DEFAULT 0,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN image TEXT NOT NULL DEFAULT '',
+    ADD CONSTRAINT options_id_vote_id_key UNIQUE (id, vote_id);
+
+-- Existing options keep the order in which they were inserted.
+UPDATE options o
+SET position = ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY vote_id ORDER BY id) - 1 AS position
+    FROM options
+) ranked
+WHERE o.id = ranked.id;
+
+ALTER TABLE choices_results ADD COLUMN option_id INT;
+
+UPDATE choices_results c
+SET option_id = o.id
+FROM options o
+WHERE o.vote_id = c.vote_id AND o.option = c.choice;
+
+-- Ballots whose text never matched an option cannot be attributed to one.
+DELETE FROM choices_results WHERE option_id IS NULL;
+
+ALTER TABLE choices_results
+    ALTER COLUMN option_id SET NOT NULL,
+    DROP COLUMN choice,
+    ADD CONSTRAINT choices_results_option_fkey FOREIGN KEY (option_id, vote_id)
+        REFERENCES options (id, vote_id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS votes_status_idx;
+ALTER TABLE votes DROP COLUMN status;
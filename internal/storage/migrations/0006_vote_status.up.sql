@@ -0,0 +1,12 @@
+ALTER TABLE votes ADD COLUMN status TEXT NOT NULL DEFAULT 'draft'
+    CONSTRAINT votes_status_check CHECK (status IN ('draft', 'scheduled', 'open', 'closed', 'archived'));
+
+-- Votes created before the lifecycle existed were public from the start.
+UPDATE votes
+SET status = CASE
+    WHEN end_time <= LOCALTIMESTAMP THEN 'closed'
+    WHEN start_time > LOCALTIMESTAMP THEN 'scheduled'
+    ELSE 'open'
+END;
+
+CREATE INDEX votes_status_idx ON votes (status);
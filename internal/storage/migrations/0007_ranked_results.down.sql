@@ -0,0 +1 @@
+DROP TABLE ranked_results;
@@ -0,0 +1,10 @@
+CREATE TABLE ranked_results (
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT NOT NULL,
+    option_id INT NOT NULL,
+    rank INT NOT NULL CHECK (rank > 0),
+    PRIMARY KEY (vote_id, user_token, rank),
+    CONSTRAINT ranked_results_option_key UNIQUE (vote_id, user_token, option_id),
+    CONSTRAINT ranked_results_option_fkey FOREIGN KEY (option_id, vote_id)
+        REFERENCES options (id, vote_id) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+-- Only the first selected option of an approval ballot survives the downgrade.
+DELETE FROM choices_results a
+    USING choices_results b
+    WHERE a.vote_id = b.vote_id AND a.user_token = b.user_token AND a.option_id > b.option_id;
+
+ALTER TABLE choices_results
+    DROP CONSTRAINT choices_results_vote_id_user_token_option_id_key,
+    ADD CONSTRAINT choices_results_vote_id_user_token_key UNIQUE (vote_id, user_token);
+
+ALTER TABLE votes
+    DROP CONSTRAINT votes_selections_check,
+    DROP COLUMN max_selections,
+    DROP COLUMN min_selections;
@@ -0,0 +1,8 @@
+ALTER TABLE votes
+    ADD COLUMN min_selections INT NOT NULL DEFAULT 1,
+    ADD COLUMN max_selections INT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT votes_selections_check CHECK (min_selections >= 1 AND min_selections <= max_selections);
+
+ALTER TABLE choices_results
+    DROP CONSTRAINT choices_results_vote_id_user_token_key,
+    ADD CONSTRAINT choices_results_vote_id_user_token_option_id_key UNIQUE (vote_id, user_token, option_id);
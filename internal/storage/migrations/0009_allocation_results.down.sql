@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS allocation_results;
+DROP FUNCTION IF EXISTS check_allocation_budget();
+ALTER TABLE votes DROP COLUMN IF EXISTS budget;
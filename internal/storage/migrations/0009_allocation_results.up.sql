@@ -0,0 +1,39 @@
+ALTER TABLE votes ADD COLUMN budget INT NOT NULL DEFAULT 0
+    CONSTRAINT votes_budget_check CHECK (budget >= 0);
+
+CREATE TABLE allocation_results (
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT NOT NULL,
+    option_id INT NOT NULL,
+    points INT NOT NULL CHECK (points > 0),
+    PRIMARY KEY (vote_id, user_token, option_id),
+    CONSTRAINT allocation_results_option_fkey FOREIGN KEY (option_id, vote_id)
+        REFERENCES options (id, vote_id) ON DELETE CASCADE
+);
+
+-- A row-level BEFORE trigger sees the rows already inserted by the same
+-- statement, so a whole ballot inserted at once is checked as a sum.
+CREATE FUNCTION check_allocation_budget() RETURNS trigger AS $$
+DECLARE
+    v RECORD;
+    spent INT;
+BEGIN
+    SELECT category, budget INTO v FROM votes WHERE id = NEW.vote_id;
+    IF NOT FOUND THEN
+        RETURN NEW;
+    END IF;
+    SELECT COALESCE(SUM(CASE WHEN v.category = 'quadratic' THEN points * points ELSE points END), 0) INTO spent
+        FROM allocation_results
+        WHERE vote_id = NEW.vote_id AND user_token = NEW.user_token AND option_id <> NEW.option_id;
+    spent := spent + CASE WHEN v.category = 'quadratic' THEN NEW.points * NEW.points ELSE NEW.points END;
+    IF spent > v.budget THEN
+        RAISE EXCEPTION 'ballot of vote % spends % of a budget of %', NEW.vote_id, spent, v.budget
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER allocation_results_budget_check
+    BEFORE INSERT OR UPDATE ON allocation_results
+    FOR EACH ROW EXECUTE FUNCTION check_allocation_budget();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS survey_answer_options;
+DROP TABLE IF EXISTS survey_answers;
+DROP TABLE IF EXISTS survey_responses;
+DROP TABLE IF EXISTS survey_question_options;
+DROP TABLE IF EXISTS survey_questions;
@@ -0,0 +1,60 @@
+CREATE TABLE survey_questions (
+    id SERIAL PRIMARY KEY,
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL CHECK (kind IN ('rating', 'single', 'multi', 'text')),
+    text TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT false,
+    rate_min INT NOT NULL DEFAULT 1,
+    rate_max INT NOT NULL DEFAULT 5,
+    rate_step INT NOT NULL DEFAULT 1,
+    CONSTRAINT survey_questions_id_vote_id_key UNIQUE (id, vote_id),
+    CONSTRAINT survey_questions_vote_id_text_key UNIQUE (vote_id, text),
+    CONSTRAINT survey_questions_rate_scale_check
+        CHECK (rate_step > 0 AND rate_min < rate_max AND (rate_max - rate_min) % rate_step = 0)
+);
+
+CREATE TABLE survey_question_options (
+    id SERIAL PRIMARY KEY,
+    question_id INT NOT NULL REFERENCES survey_questions(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    option VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    CONSTRAINT survey_question_options_id_question_id_key UNIQUE (id, question_id),
+    CONSTRAINT survey_question_options_question_id_option_key UNIQUE (question_id, option)
+);
+
+-- A response is a draft until it is submitted; only submitted responses are
+-- counted in the results.
+CREATE TABLE survey_responses (
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT NOT NULL,
+    submitted BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (vote_id, user_token)
+);
+
+CREATE TABLE survey_answers (
+    vote_id INT NOT NULL,
+    user_token TEXT NOT NULL,
+    question_id INT NOT NULL,
+    rating INT,
+    text TEXT,
+    PRIMARY KEY (vote_id, user_token, question_id),
+    FOREIGN KEY (vote_id, user_token) REFERENCES survey_responses (vote_id, user_token) ON DELETE CASCADE,
+    CONSTRAINT survey_answers_question_fkey FOREIGN KEY (question_id, vote_id)
+        REFERENCES survey_questions (id, vote_id) ON DELETE CASCADE
+);
+
+CREATE TABLE survey_answer_options (
+    vote_id INT NOT NULL,
+    user_token TEXT NOT NULL,
+    question_id INT NOT NULL,
+    option_id INT NOT NULL,
+    PRIMARY KEY (vote_id, user_token, question_id, option_id),
+    FOREIGN KEY (vote_id, user_token, question_id)
+        REFERENCES survey_answers (vote_id, user_token, question_id) ON DELETE CASCADE,
+    CONSTRAINT survey_answer_options_option_fkey FOREIGN KEY (option_id, question_id)
+        REFERENCES survey_question_options (id, question_id) ON DELETE CASCADE
+);
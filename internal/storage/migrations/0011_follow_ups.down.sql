@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS follow_up_answer_options;
+DROP TABLE IF EXISTS follow_up_answers;
+DROP TABLE IF EXISTS follow_up_triggers;
+DROP TABLE IF EXISTS follow_up_options;
+DROP TABLE IF EXISTS follow_ups;
@@ -0,0 +1,65 @@
+-- Follow-up questions of rate and choice votes. A follow-up of a rate vote
+-- is triggered by a rating between trigger_min and trigger_max; one of a
+-- choice vote by selecting any of its follow_up_triggers options.
+CREATE TABLE follow_ups (
+    id SERIAL PRIMARY KEY,
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    kind TEXT NOT NULL CHECK (kind IN ('rating', 'single', 'multi', 'text')),
+    text TEXT NOT NULL,
+    rate_min INT NOT NULL DEFAULT 1,
+    rate_max INT NOT NULL DEFAULT 5,
+    rate_step INT NOT NULL DEFAULT 1,
+    trigger_min INT,
+    trigger_max INT,
+    CONSTRAINT follow_ups_id_vote_id_key UNIQUE (id, vote_id),
+    CONSTRAINT follow_ups_vote_id_text_key UNIQUE (vote_id, text),
+    CONSTRAINT follow_ups_rate_scale_check
+        CHECK (rate_step > 0 AND rate_min < rate_max AND (rate_max - rate_min) % rate_step = 0),
+    CONSTRAINT follow_ups_trigger_check CHECK (trigger_min <= trigger_max)
+);
+
+CREATE TABLE follow_up_options (
+    id SERIAL PRIMARY KEY,
+    follow_up_id INT NOT NULL REFERENCES follow_ups(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    option VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    CONSTRAINT follow_up_options_id_follow_up_id_key UNIQUE (id, follow_up_id),
+    CONSTRAINT follow_up_options_follow_up_id_option_key UNIQUE (follow_up_id, option)
+);
+
+CREATE TABLE follow_up_triggers (
+    follow_up_id INT NOT NULL,
+    vote_id INT NOT NULL,
+    option_id INT NOT NULL,
+    PRIMARY KEY (follow_up_id, option_id),
+    FOREIGN KEY (follow_up_id, vote_id) REFERENCES follow_ups (id, vote_id) ON DELETE CASCADE,
+    CONSTRAINT follow_up_triggers_option_fkey FOREIGN KEY (option_id, vote_id)
+        REFERENCES options (id, vote_id) ON DELETE CASCADE
+);
+
+CREATE TABLE follow_up_answers (
+    vote_id INT NOT NULL,
+    user_token TEXT NOT NULL,
+    follow_up_id INT NOT NULL,
+    rating INT,
+    text TEXT,
+    answered_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (vote_id, user_token, follow_up_id),
+    CONSTRAINT follow_up_answers_follow_up_fkey FOREIGN KEY (follow_up_id, vote_id)
+        REFERENCES follow_ups (id, vote_id) ON DELETE CASCADE
+);
+
+CREATE TABLE follow_up_answer_options (
+    vote_id INT NOT NULL,
+    user_token TEXT NOT NULL,
+    follow_up_id INT NOT NULL,
+    option_id INT NOT NULL,
+    PRIMARY KEY (vote_id, user_token, follow_up_id, option_id),
+    FOREIGN KEY (vote_id, user_token, follow_up_id)
+        REFERENCES follow_up_answers (vote_id, user_token, follow_up_id) ON DELETE CASCADE,
+    CONSTRAINT follow_up_answer_options_option_fkey FOREIGN KEY (option_id, follow_up_id)
+        REFERENCES follow_up_options (id, follow_up_id) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+-- Free-text comments sent with rate and choice ballots. Every ballot has at
+-- most one comment; sending a new one replaces the text and puts it back in
+-- the moderation queue.
+CREATE TABLE ballot_comments (
+    id SERIAL PRIMARY KEY,
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT NOT NULL,
+    text TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    reviewed_at TIMESTAMP,
+    CONSTRAINT ballot_comments_vote_id_user_token_key UNIQUE (vote_id, user_token)
+);
+
+CREATE INDEX ballot_comments_status_idx ON ballot_comments (status, created_at);
@@ -0,0 +1,13 @@
+-- Full-text search over votes with the Russian configuration. Names weigh
+-- more than descriptions, descriptions more than organizations. The
+-- trigram index backs the typo-tolerant fallback on names.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE votes ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('russian', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('russian', COALESCE(organization, '')), 'C')
+) STORED;
+
+CREATE INDEX votes_search_idx ON votes USING GIN (search_vector);
+CREATE INDEX votes_name_trgm_idx ON votes USING GIN (name gin_trgm_ops);
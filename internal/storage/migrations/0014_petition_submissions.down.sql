@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS votes_author_token_idx;
+
+ALTER TABLE votes DROP COLUMN review_reason;
+ALTER TABLE votes DROP COLUMN author_token;
+ALTER TABLE votes DROP COLUMN signature_goal;
+
+UPDATE votes
+SET status = CASE status
+    WHEN 'moderation' THEN 'draft'
+    WHEN 'rejected' THEN 'archived'
+    ELSE 'closed'
+END
+WHERE status IN ('moderation', 'rejected', 'awaiting_response');
+
+ALTER TABLE votes DROP CONSTRAINT votes_status_check;
+ALTER TABLE votes ADD CONSTRAINT votes_status_check
+    CHECK (status IN ('draft', 'scheduled', 'open', 'closed', 'archived'));
@@ -0,0 +1,13 @@
+-- Petitions submitted by citizens wait in moderation until a moderator opens
+-- or rejects them. A petition with a signature goal moves to
+-- awaiting_response once enough citizens have signed it.
+ALTER TABLE votes DROP CONSTRAINT votes_status_check;
+ALTER TABLE votes ADD CONSTRAINT votes_status_check
+    CHECK (status IN ('draft', 'scheduled', 'open', 'closed', 'archived', 'moderation', 'rejected', 'awaiting_response'));
+
+ALTER TABLE votes ADD COLUMN signature_goal INT NOT NULL DEFAULT 0
+    CONSTRAINT votes_signature_goal_check CHECK (signature_goal >= 0);
+ALTER TABLE votes ADD COLUMN author_token TEXT;
+ALTER TABLE votes ADD COLUMN review_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX votes_author_token_idx ON votes (author_token, status) WHERE author_token IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS votes_response_due_idx;
+ALTER TABLE votes DROP COLUMN response_overdue;
+ALTER TABLE votes DROP COLUMN response_due;
+
+DROP TABLE IF EXISTS petition_response_attachments;
+DROP TABLE IF EXISTS petition_responses;
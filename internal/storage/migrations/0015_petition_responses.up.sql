@@ -0,0 +1,27 @@
+-- The official response of the organization a petition was addressed to.
+-- A petition has at most one response; publishing again replaces it.
+CREATE TABLE petition_responses (
+    vote_id INT PRIMARY KEY REFERENCES votes(id) ON DELETE CASCADE,
+    decision TEXT NOT NULL CHECK (decision IN ('accepted', 'partially_accepted', 'rejected')),
+    text TEXT NOT NULL,
+    organization TEXT NOT NULL,
+    responded_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE petition_response_attachments (
+    id SERIAL PRIMARY KEY,
+    vote_id INT NOT NULL REFERENCES petition_responses(vote_id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    position INT NOT NULL
+);
+
+CREATE INDEX petition_response_attachments_vote_id_idx ON petition_response_attachments (vote_id, position);
+
+-- A petition that reaches its signature goal must be answered by
+-- response_due; the lifecycle job sets response_overdue once it passes
+-- without a response.
+ALTER TABLE votes ADD COLUMN response_due TIMESTAMP;
+ALTER TABLE votes ADD COLUMN response_overdue BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX votes_response_due_idx ON votes (response_due) WHERE status = 'awaiting_response';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS votes_organization_id_idx;
+ALTER TABLE votes DROP COLUMN organization_id;
+DROP TABLE IF EXISTS organizations;
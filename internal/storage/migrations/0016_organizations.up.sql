@@ -0,0 +1,31 @@
+-- Organizations that votes are run by or petitions are addressed to.
+-- votes.organization stays as a copy of the name so that full-text search
+-- and older clients keep working; the service keeps it in sync.
+CREATE TABLE organizations (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK (name <> '' AND name = btrim(name)),
+    logo TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    contact TEXT NOT NULL DEFAULT '',
+    verified BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX organizations_name_key ON organizations (lower(name));
+
+-- Spellings that only differ in case or surrounding spaces are merged; the
+-- most frequent one becomes the name.
+INSERT INTO organizations (name)
+SELECT DISTINCT ON (lower(btrim(organization))) btrim(organization)
+FROM votes
+WHERE btrim(organization) <> ''
+GROUP BY btrim(organization)
+ORDER BY lower(btrim(organization)), COUNT(*) DESC, btrim(organization);
+
+ALTER TABLE votes ADD COLUMN organization_id INT REFERENCES organizations(id);
+
+UPDATE votes v
+SET organization_id = o.id, organization = o.name
+FROM organizations o
+WHERE lower(btrim(v.organization)) = lower(o.name);
+
+CREATE INDEX votes_organization_id_idx ON votes (organization_id);
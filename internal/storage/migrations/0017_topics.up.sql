@@ -0,0 +1,42 @@
+-- Topics are the subjects votes are about, independent of the voting
+-- mechanism stored in votes.category. A vote can have several topics.
+CREATE TABLE topics (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    name TEXT NOT NULL CHECK (name <> '')
+);
+
+CREATE TABLE vote_topics (
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    topic_id INT NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+    PRIMARY KEY (vote_id, topic_id)
+);
+
+CREATE INDEX vote_topics_topic_id_idx ON vote_topics (topic_id);
+
+-- Keep in sync with DefaultTopics.
+INSERT INTO topics (slug, name) VALUES
+    ('transport', 'Транспорт'),
+    ('culture', 'Культура'),
+    ('youth', 'Молодежь'),
+    ('tourism', 'Туризм'),
+    ('ecology', 'Экология'),
+    ('urban', 'Благоустройство'),
+    ('education', 'Образование'),
+    ('health', 'Здравоохранение'),
+    ('sport', 'Спорт');
+
+-- Votes loaded from seed.json before topics existed.
+INSERT INTO vote_topics (vote_id, topic_id)
+SELECT v.id, t.id
+FROM (VALUES
+    ('seed-youth-clubs', 'youth'),
+    ('seed-tatarstan-leisure', 'tourism'),
+    ('seed-kazan-bike-lanes', 'transport'),
+    ('seed-kazan-bike-lanes', 'urban'),
+    ('seed-public-transport-petition', 'transport'),
+    ('seed-public-transport-review', 'transport'),
+    ('seed-culture-event-review', 'culture')
+) AS s(external_key, slug)
+JOIN votes v ON v.external_key = s.external_key
+JOIN topics t ON t.slug = s.slug;
@@ -0,0 +1,16 @@
+ALTER TABLE follow_up_answer_options
+    DROP CONSTRAINT follow_up_answer_options_vote_id_user_token_follow_up_id_fkey,
+    ADD CONSTRAINT follow_up_answer_options_vote_id_user_token_follow_up_id_fkey FOREIGN KEY (vote_id, user_token, follow_up_id)
+        REFERENCES follow_up_answers (vote_id, user_token, follow_up_id) ON DELETE CASCADE;
+
+ALTER TABLE survey_answer_options
+    DROP CONSTRAINT survey_answer_options_vote_id_user_token_question_id_fkey,
+    ADD CONSTRAINT survey_answer_options_vote_id_user_token_question_id_fkey FOREIGN KEY (vote_id, user_token, question_id)
+        REFERENCES survey_answers (vote_id, user_token, question_id) ON DELETE CASCADE;
+
+ALTER TABLE survey_answers
+    DROP CONSTRAINT survey_answers_vote_id_user_token_fkey,
+    ADD CONSTRAINT survey_answers_vote_id_user_token_fkey FOREIGN KEY (vote_id, user_token)
+        REFERENCES survey_responses (vote_id, user_token) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS voter_key_layers;
@@ -0,0 +1,32 @@
+-- The user_token and author_token columns hold voter pseudonyms derived
+-- from the authenticated user ID, see package pseudonym. votesctl rekey
+-- apply applies a new pepper to every stored value with pgcrypto's hmac().
+-- Rows stored before this migration hold the raw tokens of their voters;
+-- the service does not start until votesctl rekey apply has resolved them
+-- to user IDs, dropping the ballots of tokens that no longer resolve and
+-- duplicate ballots of one user in a vote.
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- One row per pepper the stored pseudonyms are keyed with, oldest first.
+-- The service refuses to start when its peppers do not match.
+CREATE TABLE voter_key_layers (
+    position INT PRIMARY KEY,
+    fingerprint TEXT NOT NULL UNIQUE,
+    applied_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Re-keying updates the parent rows only; answers follow them.
+ALTER TABLE survey_answers
+    DROP CONSTRAINT survey_answers_vote_id_user_token_fkey,
+    ADD CONSTRAINT survey_answers_vote_id_user_token_fkey FOREIGN KEY (vote_id, user_token)
+        REFERENCES survey_responses (vote_id, user_token) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE survey_answer_options
+    DROP CONSTRAINT survey_answer_options_vote_id_user_token_question_id_fkey,
+    ADD CONSTRAINT survey_answer_options_vote_id_user_token_question_id_fkey FOREIGN KEY (vote_id, user_token, question_id)
+        REFERENCES survey_answers (vote_id, user_token, question_id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE follow_up_answer_options
+    DROP CONSTRAINT follow_up_answer_options_vote_id_user_token_follow_up_id_fkey,
+    ADD CONSTRAINT follow_up_answer_options_vote_id_user_token_follow_up_id_fkey FOREIGN KEY (vote_id, user_token, follow_up_id)
+        REFERENCES follow_up_answers (vote_id, user_token, follow_up_id) ON DELETE CASCADE ON UPDATE CASCADE;
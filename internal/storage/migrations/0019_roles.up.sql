@@ -0,0 +1,12 @@
+-- Staff roles by the user ID their token resolves to. Editors manage the
+-- votes of one organization; the other roles are global.
+CREATE TABLE role_assignments (
+    id SERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'editor', 'moderator', 'analyst')),
+    organization_id INT REFERENCES organizations(id) ON DELETE CASCADE,
+    granted_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT role_assignments_organization_check CHECK ((role = 'editor') = (organization_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX role_assignments_key ON role_assignments (user_id, role, COALESCE(organization_id, 0));
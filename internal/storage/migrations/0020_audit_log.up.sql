@@ -0,0 +1,35 @@
+-- Append-only log of ballot changes and admin actions. The log is split
+-- into streams, one per vote and one per other kind of entity, and every
+-- row carries the SHA-256 hash of its predecessor in the stream and of
+-- itself, see package audit; votesctl audit verify walks the chains.
+CREATE TABLE audit_log (
+    stream TEXT NOT NULL,
+    seq BIGINT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    actor TEXT NOT NULL,
+    request_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    old_value TEXT NOT NULL,
+    new_value TEXT NOT NULL,
+    prev_hash TEXT NOT NULL,
+    hash TEXT NOT NULL UNIQUE,
+    PRIMARY KEY (stream, seq)
+);
+
+CREATE INDEX audit_log_entity_idx ON audit_log (entity, entity_id);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only' USING ERRCODE = 'insufficient_privilege';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
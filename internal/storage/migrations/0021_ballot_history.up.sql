@@ -0,0 +1,11 @@
+-- When each user cast, changed or withdrew their ballot in a vote. A
+-- withdrawn ballot is deleted, so tallies only count current ballots.
+CREATE TABLE ballot_history (
+    id BIGSERIAL PRIMARY KEY,
+    vote_id INT NOT NULL REFERENCES votes(id) ON DELETE CASCADE,
+    user_token TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('cast', 'change', 'withdraw')),
+    changed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ballot_history_user_idx ON ballot_history (user_token, vote_id, changed_at);
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"github.com/GP-Hacks/kdt2024-votes/internal/clock"
+	"log/slog"
+	"time"
+)
+
+type options struct {
+	clock          clock.Clock
+	responseWindow time.Duration
+	logger         *slog.Logger
+}
+
+type Option func(*options)
+
+// WithClock replaces the wall clock used to decide whether a vote accepts ballots.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithResponseWindow sets how long an organization has to answer a petition
+// that reached its signature goal. It defaults to DefaultResponseWindow.
+func WithResponseWindow(d time.Duration) Option {
+	return func(o *options) {
+		o.responseWindow = d
+	}
+}
+
+// WithLogger sets the logger for failures that do not fail the operation,
+// such as a petition that could not be promoted after a signature. It
+// defaults to slog.Default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+func applyOptions(opts []Option) options {
+	o := options{clock: clock.System, responseWindow: DefaultResponseWindow, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
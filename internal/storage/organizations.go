@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxOrganizationName and maxOrganizationText bound the name and the other
+// text fields of an organization.
+const (
+	maxOrganizationName = 200
+	maxOrganizationText = 2000
+)
+
+// Organization runs votes or receives petitions. Votes counts its public
+// votes; it is only filled in when reading.
+type Organization struct {
+	ID          int
+	Name        string
+	Logo        string
+	Description string
+	Contact     string
+	Verified    bool
+	Votes       int
+}
+
+// ValidateOrganization checks an organization before it is stored and trims
+// its text fields.
+func ValidateOrganization(organization *Organization) error {
+	organization.Name = strings.TrimSpace(organization.Name)
+	organization.Description = strings.TrimSpace(organization.Description)
+	organization.Contact = strings.TrimSpace(organization.Contact)
+	if organization.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(organization.Name) > maxOrganizationName {
+		return fmt.Errorf("name is longer than %d characters", maxOrganizationName)
+	}
+	if utf8.RuneCountInString(organization.Description) > maxOrganizationText {
+		return fmt.Errorf("description is longer than %d characters", maxOrganizationText)
+	}
+	if utf8.RuneCountInString(organization.Contact) > maxOrganizationText {
+		return fmt.Errorf("contact is longer than %d characters", maxOrganizationText)
+	}
+	if organization.Logo != "" && !isHTTPURL(organization.Logo) {
+		return fmt.Errorf("logo %q is not a valid http(s) URL", organization.Logo)
+	}
+	return nil
+}
+
+// organizationColumns is the column list read by scanOrganization; the vote
+// count expects votes to be joined as v and PublicStatuses as $1.
+const organizationColumns = `o.id, o.name, o.logo, o.description, o.contact, o.verified,
+		COUNT(v.id) FILTER (WHERE v.status = ANY($1))`
+
+func scanOrganization(row pgx.Row, organization *Organization) error {
+	return row.Scan(&organization.ID, &organization.Name, &organization.Logo, &organization.Description,
+		&organization.Contact, &organization.Verified, &organization.Votes)
+}
+
+func (s *PostgresStorage) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	const op = "storage.postgresql.ListOrganizations"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organizations o
+		LEFT JOIN votes v ON v.organization_id = o.id
+		GROUP BY o.id
+		ORDER BY o.name, o.id`, PublicStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	organizations := []Organization{}
+	for rows.Next() {
+		var organization Organization
+		if err := scanOrganization(rows, &organization); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		organizations = append(organizations, organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return organizations, nil
+}
+
+func (s *PostgresStorage) GetOrganization(ctx context.Context, organizationId int) (*Organization, error) {
+	const op = "storage.postgresql.GetOrganization"
+
+	var organization Organization
+	err := scanOrganization(s.db.QueryRow(ctx, `
+		SELECT `+organizationColumns+`
+		FROM organizations o
+		LEFT JOIN votes v ON v.organization_id = o.id
+		WHERE o.id = $2
+		GROUP BY o.id`, PublicStatuses, organizationId), &organization)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: organization %d: %w", op, organizationId, ErrOrganizationNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &organization, nil
+}
+
+// CreateOrganization stores a new organization. Names are unique ignoring
+// case; a duplicate is refused with ErrConflict.
+func (s *PostgresStorage) CreateOrganization(ctx context.Context, organization *Organization) (*Organization, error) {
+	const op = "storage.postgresql.CreateOrganization"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	created := *organization
+	created.Votes = 0
+	err = tx.QueryRow(ctx, `
+		INSERT INTO organizations (name, logo, description, contact, verified)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		organization.Name, organization.Logo, organization.Description, organization.Contact, organization.Verified).
+		Scan(&created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	err = s.auditChange(ctx, tx, "organization.create", auditOrganization, strconv.Itoa(created.ID), nil, &created)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &created, nil
+}
+
+// UpdateOrganization replaces an organization and copies a new name to its
+// votes.
+func (s *PostgresStorage) UpdateOrganization(ctx context.Context, organization *Organization) (*Organization, error) {
+	const op = "storage.postgresql.UpdateOrganization"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The row is locked before it is read so that the audit log records the
+	// state this update replaces.
+	var id int
+	err = tx.QueryRow(ctx, `SELECT id FROM organizations WHERE id = $1 FOR UPDATE`, organization.ID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: organization %d: %w", op, organization.ID, ErrOrganizationNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previous, err := s.GetOrganization(ctx, organization.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE organizations
+		SET name = $2, logo = $3, description = $4, contact = $5, verified = $6
+		WHERE id = $1`,
+		organization.ID, organization.Name, organization.Logo, organization.Description, organization.Contact, organization.Verified)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	_, err = tx.Exec(ctx, `UPDATE votes SET organization = $2 WHERE organization_id = $1 AND organization <> $2`,
+		organization.ID, organization.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated := *organization
+	updated.Votes = previous.Votes
+	err = s.auditChange(ctx, tx, "organization.update", auditOrganization, strconv.Itoa(organization.ID), previous, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.GetOrganization(ctx, organization.ID)
+}
+
+// resolveOrganization points vote at its organization and copies the name
+// of the organization into vote.Organization. Without an organization ID
+// the vote is matched by name, ignoring case and surrounding spaces; when
+// create is set an unknown name becomes a new unverified organization.
+func resolveOrganization(ctx context.Context, tx pgx.Tx, vote *Vote, create bool) error {
+	name := strings.TrimSpace(vote.Organization)
+	var err error
+	switch {
+	case vote.OrganizationID != 0:
+		err = tx.QueryRow(ctx, `SELECT name FROM organizations WHERE id = $1`, vote.OrganizationID).Scan(&vote.Organization)
+	case name == "":
+		vote.Organization = ""
+		return nil
+	case create:
+		err = tx.QueryRow(ctx, `
+			INSERT INTO organizations (name) VALUES ($1)
+			ON CONFLICT ((lower(name))) DO UPDATE SET name = organizations.name
+			RETURNING id, name`, name).Scan(&vote.OrganizationID, &vote.Organization)
+	default:
+		err = tx.QueryRow(ctx, `SELECT id, name FROM organizations WHERE lower(name) = lower($1)`, name).
+			Scan(&vote.OrganizationID, &vote.Organization)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		if vote.OrganizationID != 0 {
+			return fmt.Errorf("organization %d: %w", vote.OrganizationID, ErrOrganizationNotFound)
+		}
+		return fmt.Errorf("organization %q: %w", name, ErrOrganizationNotFound)
+	}
+	return err
+}
+
+func nullID(id int) *int {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
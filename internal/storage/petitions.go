@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits of petitions submitted by citizens: how many of one author may wait
+// in moderation at a time, how long a petition may collect signatures and
+// the size of its text.
+const (
+	maxPendingPetitions    = 3
+	maxPetitionDuration    = 365 * 24 * time.Hour
+	maxPetitionName        = 200
+	maxPetitionDescription = 5000
+)
+
+// signatureSupport is the support that counts towards the signature goal.
+const signatureSupport = "for"
+
+// ValidatePetition checks a petition submitted by a citizen before it is
+// stored. On top of the rules of ValidateVote it needs an author and a
+// description, bounds the text and the end time, and leaves the start to the
+// moderator who opens it.
+func ValidatePetition(vote *Vote, now time.Time) error {
+	if vote.Author == "" {
+		return errors.New("author is required")
+	}
+	if vote.Category != "petition" {
+		return fmt.Errorf("only petitions can be submitted, not %s votes", vote.Category)
+	}
+	if vote.Status != "" {
+		return errors.New("status is set by moderation")
+	}
+	if !vote.StartTime.IsZero() {
+		return errors.New("petitions start when they are approved")
+	}
+	if utf8.RuneCountInString(vote.Name) > maxPetitionName {
+		return fmt.Errorf("name is longer than %d characters", maxPetitionName)
+	}
+	if strings.TrimSpace(vote.Description) == "" {
+		return errors.New("description is required")
+	}
+	if utf8.RuneCountInString(vote.Description) > maxPetitionDescription {
+		return fmt.Errorf("description is longer than %d characters", maxPetitionDescription)
+	}
+	if err := ValidateVote(vote, now); err != nil {
+		return err
+	}
+	if vote.EndTime.After(now.Add(maxPetitionDuration)) {
+		return fmt.Errorf("end time must be within %d days", int(maxPetitionDuration/(24*time.Hour)))
+	}
+	return nil
+}
+
+// ValidatePetitionReview checks a moderator's decision on a submitted
+// petition: it is either opened or rejected with a reason. A positive
+// signature goal replaces the one the petition was submitted with.
+func ValidatePetitionReview(status, reason string, signatureGoal int) error {
+	switch status {
+	case StatusOpen:
+	case StatusRejected:
+		if strings.TrimSpace(reason) == "" {
+			return errors.New("a rejection requires a reason")
+		}
+	default:
+		return fmt.Errorf("status must be %s or %s", StatusOpen, StatusRejected)
+	}
+	if signatureGoal < 0 {
+		return errors.New("signature goal must not be negative")
+	}
+	return nil
+}
+
+// CreatePetition stores a petition submitted by vote.Author. It is refused
+// with ErrConflict while the author already has maxPendingPetitions
+// petitions waiting in moderation.
+func (s *PostgresStorage) CreatePetition(ctx context.Context, vote *Vote) (*Vote, error) {
+	const op = "storage.postgresql.CreatePetition"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Submissions of one author are serialized so that concurrent requests
+	// cannot both pass the limit.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('petition:' || $1))`, vote.Author); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var pending int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM votes WHERE author_token = $1 AND status = $2`, vote.Author, StatusModeration).
+		Scan(&pending)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if pending >= maxPendingPetitions {
+		return nil, fmt.Errorf("%s: %w: %d petitions of the author are awaiting moderation", op, ErrConflict, pending)
+	}
+
+	// Citizens can only address organizations that already exist.
+	created, err := insertVote(ctx, tx, vote, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	entry, err := newAuditEntry(ctx, s.opts.clock.Now(), vote.Author, "petition.create", auditVote, strconv.Itoa(created.ID), nil, created)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := appendAudit(ctx, tx, entry); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, nil
+}
+
+// ReviewPetition opens or rejects a petition waiting in moderation. An
+// opened petition starts collecting signatures at once.
+func (s *PostgresStorage) ReviewPetition(ctx context.Context, voteId int, status, reason string, signatureGoal int) (*Vote, error) {
+	const op = "storage.postgresql.ReviewPetition"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var category, current, currentReason string
+	var currentGoal int
+	var endTime time.Time
+	err = tx.QueryRow(ctx, `SELECT category, status, review_reason, signature_goal, end_time FROM votes WHERE id = $1 FOR UPDATE`, voteId).
+		Scan(&category, &current, &currentReason, &currentGoal, &endTime)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	now := s.opts.clock.Now()
+	if err := checkPetitionReview(voteId, category, current, status, endTime, now); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE votes
+		SET status = $2, review_reason = $3,
+			signature_goal = CASE WHEN $4 > 0 THEN $4 ELSE signature_goal END,
+			start_time = CASE WHEN $2 = 'open' THEN $5 ELSE start_time END
+		WHERE id = $1`,
+		voteId, status, strings.TrimSpace(reason), signatureGoal, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if signatureGoal <= 0 {
+		signatureGoal = currentGoal
+	}
+	err = s.auditChange(ctx, tx, "petition.review", auditVote, strconv.Itoa(voteId),
+		map[string]interface{}{"Status": current, "ReviewReason": currentReason, "SignatureGoal": currentGoal},
+		map[string]interface{}{"Status": status, "ReviewReason": strings.TrimSpace(reason), "SignatureGoal": signatureGoal})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s.GetVote(ctx, voteId)
+}
+
+// promotePetitions moves open petitions whose signatures have reached their
+// goal to awaiting_response, starting the response window, and returns how
+// many moved. A voteId of 0 checks every petition. The moves are audited
+// with the actor "system:lifecycle".
+func (s *PostgresStorage) promotePetitions(ctx context.Context, voteId int) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	responseDue := s.opts.clock.Now().Add(s.opts.responseWindow)
+	rows, err := tx.Query(ctx, `
+		UPDATE votes v SET status = 'awaiting_response', response_due = $3
+		WHERE ($1 = 0 OR v.id = $1) AND v.category = 'petition' AND v.status = 'open' AND v.signature_goal > 0
+			AND (SELECT COUNT(*) FROM petition_results p WHERE p.vote_id = v.id AND p.support = $2) >= v.signature_goal
+		RETURNING v.id`,
+		voteId, signatureSupport, responseDue)
+	if err != nil {
+		return 0, err
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = audit.WithActor(ctx, actorLifecycle)
+	for _, id := range ids {
+		err := s.auditChange(ctx, tx, "vote.status", auditVote, strconv.Itoa(id),
+			map[string]interface{}{"Status": StatusOpen},
+			map[string]interface{}{"Status": StatusAwaitingResponse, "ResponseDue": responseDue.UTC()})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// checkPetitionReview verifies that a vote is a petition waiting in
+// moderation and may move to status at now.
+func checkPetitionReview(voteId int, category, current, status string, end, now time.Time) error {
+	if err := checkCategory(voteId, category, "petition"); err != nil {
+		return err
+	}
+	if current != StatusModeration {
+		return fmt.Errorf("%w: petition %d is %s, not in moderation", ErrInvalidTransition, voteId, current)
+	}
+	return checkTransition(voteId, current, status, time.Time{}, end, now)
+}
@@ -0,0 +1,357 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/audit"
+	"github.com/GP-Hacks/kdt2024-votes/internal/pseudonym"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// voterColumns are the columns holding voter pseudonyms. Survey and
+// follow-up answers reference their responses and follow them through
+// ON UPDATE CASCADE, so only the parent tables are listed. A voter has at
+// most one ballot per vote in the tables marked ballot.
+var voterColumns = []struct {
+	table, column string
+	ballot        bool
+}{
+	{"rate_results", "user_token", true},
+	{"petition_results", "user_token", true},
+	{"choices_results", "user_token", true},
+	{"ranked_results", "user_token", true},
+	{"allocation_results", "user_token", true},
+	{"survey_responses", "user_token", true},
+	{"follow_up_answers", "user_token", true},
+	{"ballot_comments", "user_token", true},
+	{"ballot_history", "user_token", false},
+	{"votes", "author_token", false},
+}
+
+// LegacyResolver resolves a token stored before the service authenticated
+// users to the ID of its user. ok is false for a token that does not
+// resolve any more.
+type LegacyResolver func(token string) (userId string, ok bool)
+
+// Rekeyed reports what ApplyVoterKey changed.
+type Rekeyed struct {
+	// Rows is the number of rows keyed with the pepper.
+	Rows int64
+	// Resolved and Unresolved count the legacy tokens that were and were
+	// not resolved to a user ID.
+	Resolved, Unresolved int
+	// Dropped is the number of rows of unresolved tokens and of duplicate
+	// ballots that were deleted or, for petition authors, cleared.
+	Dropped int64
+}
+
+// KeyLayer records a pepper the stored voter pseudonyms are keyed with.
+type KeyLayer struct {
+	Position    int
+	Fingerprint string
+	AppliedAt   time.Time
+}
+
+// KeyLayers returns the peppers the stored pseudonyms are keyed with,
+// oldest first.
+func (s *PostgresStorage) KeyLayers(ctx context.Context) ([]KeyLayer, error) {
+	const op = "storage.postgresql.KeyLayers"
+
+	layers, err := keyLayers(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return layers, nil
+}
+
+// CheckVoterKeys verifies that the stored pseudonyms are keyed with the
+// peppers identified by fingerprints, oldest first. A database without
+// ballots adopts missing peppers at once; otherwise they have to be applied
+// with ApplyVoterKey first.
+func (s *PostgresStorage) CheckVoterKeys(ctx context.Context, fingerprints []string) error {
+	const op = "storage.postgresql.CheckVoterKeys"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE voter_key_layers IN EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	layers, err := keyLayers(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := checkKeyLayers(layers, fingerprints); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(layers) == len(fingerprints) {
+		return nil
+	}
+
+	var keyed bool
+	if err := tx.QueryRow(ctx, `SELECT `+voterRowsExist()).Scan(&keyed); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if keyed {
+		return fmt.Errorf("%s: %w", op, missingKeyLayers(len(layers), len(fingerprints)))
+	}
+	for i := len(layers); i < len(fingerprints); i++ {
+		_, err := tx.Exec(ctx, `INSERT INTO voter_key_layers (position, fingerprint) VALUES ($1, $2)`, i+1, fingerprints[i])
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// ApplyVoterKey re-keys every stored pseudonym with pepper, which becomes
+// the layer at position. Layers are applied in order; a pepper that is
+// already applied is refused with ErrConflict.
+//
+// Rows stored before the first pepper hold the raw tokens voters sent
+// before the service authenticated them. Applying the first pepper
+// resolves those tokens with legacy and keys the rows by user ID. Rows of
+// tokens that do not resolve are dropped, and where several tokens of one
+// user voted in the same vote, the ballot of only one of them is kept so
+// that no user is counted twice. legacy may be nil when there are no such
+// rows. The re-keying is audited with the actor "system:rekey".
+func (s *PostgresStorage) ApplyVoterKey(ctx context.Context, position int, pepper string, legacy LegacyResolver) (Rekeyed, error) {
+	const op = "storage.postgresql.ApplyVoterKey"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE voter_key_layers IN EXCLUSIVE MODE`); err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+	var applied int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM voter_key_layers`).Scan(&applied); err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if position != applied+1 {
+		return Rekeyed{}, fmt.Errorf("%s: %w: %d peppers are applied, cannot apply pepper %d", op, ErrConflict, applied, position)
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO voter_key_layers (position, fingerprint) VALUES ($1, $2)`,
+		position, pseudonym.Fingerprint(pepper))
+	if err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+
+	var report Rekeyed
+	if applied == 0 {
+		report, err = rekeyLegacyVoters(ctx, tx, pepper, legacy)
+	} else {
+		report.Rows, err = rekeyVoters(ctx, tx, pepper)
+	}
+	if err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+	err = s.auditChange(audit.WithActor(ctx, actorRekey), tx, "voter_key.apply", auditVoterKey, strconv.Itoa(position), nil,
+		map[string]interface{}{"Fingerprint": pseudonym.Fingerprint(pepper), "Report": report})
+	if err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Rekeyed{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return report, nil
+}
+
+// rekeyVoters applies pepper to every stored pseudonym.
+func rekeyVoters(ctx context.Context, tx pgx.Tx, pepper string) (int64, error) {
+	var rekeyed int64
+	for _, c := range voterColumns {
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`
+			UPDATE %[1]s SET %[2]s = encode(hmac(%[2]s, $1, 'sha256'), 'hex')
+			WHERE %[2]s IS NOT NULL`, c.table, c.column), pepper)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", c.table, err)
+		}
+		rekeyed += tag.RowsAffected()
+	}
+	return rekeyed, nil
+}
+
+// rekeyLegacyVoters keys the raw tokens stored before the first pepper
+// with pepper by the IDs of their users. The rows of tokens that do not
+// resolve are dropped, and so are all but one ballot per user and vote.
+func rekeyLegacyVoters(ctx context.Context, tx pgx.Tx, pepper string, legacy LegacyResolver) (Rekeyed, error) {
+	var report Rekeyed
+	selects := make([]string, 0, len(voterColumns))
+	for _, c := range voterColumns {
+		selects = append(selects, fmt.Sprintf(`SELECT %[2]s FROM %[1]s WHERE %[2]s IS NOT NULL`, c.table, c.column))
+	}
+	stored, err := storedTokens(ctx, tx, strings.Join(selects, " UNION "))
+	if err != nil {
+		return Rekeyed{}, err
+	}
+	if len(stored) == 0 {
+		return report, nil
+	}
+	if legacy == nil {
+		return Rekeyed{}, fmt.Errorf("%d stored tokens need to be resolved to user IDs", len(stored))
+	}
+
+	tokens, userIds, unresolved := resolveTokens(stored, legacy)
+	report.Resolved, report.Unresolved = len(tokens), unresolved
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMPORARY TABLE legacy_voters (token TEXT PRIMARY KEY, user_id TEXT NOT NULL) ON COMMIT DROP`); err != nil {
+		return Rekeyed{}, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO legacy_voters (token, user_id) SELECT * FROM unnest($1::text[], $2::text[])`,
+		tokens, userIds); err != nil {
+		return Rekeyed{}, err
+	}
+
+	// The ballots of a user in a vote are kept under the smallest of their
+	// tokens in every table, so that answers stay with their response.
+	ballots := make([]string, 0, len(voterColumns))
+	for _, c := range voterColumns {
+		if c.ballot {
+			ballots = append(ballots, fmt.Sprintf(`SELECT vote_id, %s AS token FROM %s`, c.column, c.table))
+		}
+	}
+	if _, err := tx.Exec(ctx, `
+		CREATE TEMPORARY TABLE legacy_ballots ON COMMIT DROP AS
+		SELECT b.vote_id, min(b.token) AS token
+		FROM (`+strings.Join(ballots, " UNION ")+`) b
+		JOIN legacy_voters l ON l.token = b.token
+		GROUP BY b.vote_id, l.user_id`); err != nil {
+		return Rekeyed{}, err
+	}
+
+	for _, c := range voterColumns {
+		dropped := fmt.Sprintf(`%[2]s IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM legacy_voters l WHERE l.token = %[1]s.%[2]s)`, c.table, c.column)
+		if c.ballot {
+			dropped = fmt.Sprintf(`%[2]s IS NOT NULL AND NOT EXISTS (
+				SELECT 1 FROM legacy_ballots k WHERE k.vote_id = %[1]s.vote_id AND k.token = %[1]s.%[2]s)`,
+				c.table, c.column)
+		}
+		query := fmt.Sprintf(`DELETE FROM %s WHERE %s`, c.table, dropped)
+		if c.table == "votes" {
+			query = fmt.Sprintf(`UPDATE votes SET %s = NULL WHERE %s`, c.column, dropped)
+		}
+		tag, err := tx.Exec(ctx, query)
+		if err != nil {
+			return Rekeyed{}, fmt.Errorf("%s: %w", c.table, err)
+		}
+		report.Dropped += tag.RowsAffected()
+	}
+
+	for _, c := range voterColumns {
+		tag, err := tx.Exec(ctx, fmt.Sprintf(`
+			UPDATE %[1]s SET %[2]s = encode(hmac(l.user_id, $1, 'sha256'), 'hex')
+			FROM legacy_voters l WHERE %[1]s.%[2]s = l.token`, c.table, c.column), pepper)
+		if err != nil {
+			return Rekeyed{}, fmt.Errorf("%s: %w", c.table, err)
+		}
+		report.Rows += tag.RowsAffected()
+	}
+	return report, nil
+}
+
+func storedTokens(ctx context.Context, tx pgx.Tx, query string) ([]string, error) {
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// resolveTokens resolves legacy tokens and returns the resolved ones with
+// their user IDs, and the number of tokens that did not resolve.
+func resolveTokens(stored []string, legacy LegacyResolver) (tokens, userIds []string, unresolved int) {
+	tokens = make([]string, 0, len(stored))
+	userIds = make([]string, 0, len(stored))
+	for _, token := range stored {
+		userId, ok := legacy(token)
+		if !ok || userId == "" {
+			unresolved++
+			continue
+		}
+		tokens = append(tokens, token)
+		userIds = append(userIds, userId)
+	}
+	return tokens, userIds, unresolved
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+func keyLayers(ctx context.Context, db querier) ([]KeyLayer, error) {
+	rows, err := db.Query(ctx, `SELECT position, fingerprint, applied_at FROM voter_key_layers ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	layers := []KeyLayer{}
+	for rows.Next() {
+		var layer KeyLayer
+		if err := rows.Scan(&layer.Position, &layer.Fingerprint, &layer.AppliedAt); err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+	return layers, rows.Err()
+}
+
+// checkKeyLayers verifies that the stored layers are the first of the
+// configured fingerprints.
+func checkKeyLayers(layers []KeyLayer, fingerprints []string) error {
+	if len(layers) > len(fingerprints) {
+		return fmt.Errorf("ballots are keyed with %d peppers but only %d are configured", len(layers), len(fingerprints))
+	}
+	for i, layer := range layers {
+		if layer.Fingerprint != fingerprints[i] {
+			return fmt.Errorf("ballots are keyed with pepper %s at position %d, not %s",
+				layer.Fingerprint, layer.Position, fingerprints[i])
+		}
+	}
+	return nil
+}
+
+// missingKeyLayers is the error CheckVoterKeys fails with when applied of
+// the configured peppers are applied to stored rows.
+func missingKeyLayers(applied, configured int) error {
+	if applied == 0 {
+		return errors.New("ballots hold the tokens voters sent before authentication, run votesctl rekey apply to resolve them")
+	}
+	return fmt.Errorf("ballots are keyed with %d of %d peppers, run votesctl rekey apply", applied, configured)
+}
+
+func voterRowsExist() string {
+	checks := make([]string, 0, len(voterColumns))
+	for _, c := range voterColumns {
+		checks = append(checks, fmt.Sprintf(`EXISTS (SELECT 1 FROM %s WHERE %s IS NOT NULL)`, c.table, c.column))
+	}
+	return strings.Join(checks, " OR ")
+}
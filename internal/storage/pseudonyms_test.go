@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func keyLayersOf(fingerprints ...string) []KeyLayer {
+	layers := make([]KeyLayer, 0, len(fingerprints))
+	for i, fingerprint := range fingerprints {
+		layers = append(layers, KeyLayer{Position: i + 1, Fingerprint: fingerprint, AppliedAt: validateNow})
+	}
+	return layers
+}
+
+func TestCheckKeyLayers(t *testing.T) {
+	tests := []struct {
+		name         string
+		layers       []KeyLayer
+		fingerprints []string
+		err          string
+	}{
+		{"none applied", keyLayersOf(), []string{"a"}, ""},
+		{"all applied", keyLayersOf("a", "b"), []string{"a", "b"}, ""},
+		{"one missing", keyLayersOf("a"), []string{"a", "b"}, ""},
+		{"more applied than configured", keyLayersOf("a", "b"), []string{"a"}, "keyed with 2 peppers but only 1 are configured"},
+		{"other pepper", keyLayersOf("a", "c"), []string{"a", "b"}, "pepper c at position 2, not b"},
+		{"other order", keyLayersOf("b", "a"), []string{"a", "b"}, "pepper b at position 1, not a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkKeyLayers(tt.layers, tt.fingerprints)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestMissingKeyLayers(t *testing.T) {
+	tests := []struct {
+		name                string
+		applied, configured int
+		err                 string
+	}{
+		{"legacy tokens", 0, 1, "tokens voters sent before authentication"},
+		{"one layer missing", 1, 2, "keyed with 1 of 2 peppers"},
+		{"two layers missing", 1, 3, "keyed with 1 of 3 peppers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := missingKeyLayers(tt.applied, tt.configured)
+			if err == nil || !strings.Contains(err.Error(), tt.err) || !strings.Contains(err.Error(), "votesctl rekey apply") {
+				t.Errorf("got error %v, want %q and a pointer to votesctl rekey apply", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestResolveTokens(t *testing.T) {
+	users := map[string]string{"token-a": "user-1", "token-b": "user-1", "token-c": "user-2", "token-e": ""}
+	legacy := func(token string) (string, bool) {
+		userId, ok := users[token]
+		return userId, ok
+	}
+
+	tokens, userIds, unresolved := resolveTokens([]string{"token-a", "token-b", "token-c", "token-d", "token-e"}, legacy)
+	wantTokens := []string{"token-a", "token-b", "token-c"}
+	wantUsers := []string{"user-1", "user-1", "user-2"}
+	if len(tokens) != len(wantTokens) || len(userIds) != len(wantUsers) {
+		t.Fatalf("got tokens %q for %q, want %q for %q", tokens, userIds, wantTokens, wantUsers)
+	}
+	for i := range tokens {
+		if tokens[i] != wantTokens[i] || userIds[i] != wantUsers[i] {
+			t.Errorf("token %d is %q for %q, want %q for %q", i, tokens[i], userIds[i], wantTokens[i], wantUsers[i])
+		}
+	}
+	if unresolved != 2 {
+		t.Errorf("got %d unresolved tokens, want 2", unresolved)
+	}
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/GP-Hacks/kdt2024-votes/internal/tally"
+	"time"
+)
+
+type RankedInfo struct {
+	ID           int
+	Category     string
+	Name         string
+	Description  string
+	Organization string
+	EndTime      time.Time
+	Photo        string
+	Options      []VoteOption
+	Ballots      int
+	Result       tally.RunoffResult
+}
+
+// UserRanking is a ranked ballot, option IDs from most to least preferred.
+type UserRanking struct {
+	ID        int
+	OptionIDs []int
+}
+
+func (s *PostgresStorage) GetUserRankings(ctx context.Context, voterId string) ([]*UserRanking, error) {
+	const op = "storage.postgresql.GetUserRankings"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT vote_id, option_id
+		FROM ranked_results
+		WHERE user_token = $1
+		ORDER BY vote_id, rank`, voterId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rankings []*UserRanking
+	for rows.Next() {
+		var voteId, optionId int
+		if err := rows.Scan(&voteId, &optionId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(rankings) == 0 || rankings[len(rankings)-1].ID != voteId {
+			rankings = append(rankings, &UserRanking{ID: voteId})
+		}
+		last := rankings[len(rankings)-1]
+		last.OptionIDs = append(last.OptionIDs, optionId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return rankings, nil
+}
+
+func (s *PostgresStorage) GetRankedInfo(ctx context.Context, voteId int) (*RankedInfo, error) {
+	const op = "storage.postgresql.GetRankedInfo"
+
+	query := `
+		SELECT id, category, name, description, organization, photo, end_time
+		FROM votes
+		WHERE id = $1 AND status = ANY($2)
+	`
+	var rankedInfo RankedInfo
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
+		&rankedInfo.ID, &rankedInfo.Category, &rankedInfo.Name, &rankedInfo.Description,
+		&rankedInfo.Organization, &rankedInfo.Photo, &rankedInfo.EndTime,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, rankedInfo.Category, "ranked"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	options, err := s.getOptions(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rankedInfo.Options = options
+
+	ballots, err := s.getRankedBallots(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rankedInfo.Ballots = len(ballots)
+	rankedInfo.Result = tally.InstantRunoff(optionIDs(options), ballots)
+
+	return &rankedInfo, nil
+}
+
+func (s *PostgresStorage) getRankedBallots(ctx context.Context, voteId int) ([][]int, error) {
+	const op = "storage.postgresql.getRankedBallots"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT user_token, option_id
+		FROM ranked_results
+		WHERE vote_id = $1
+		ORDER BY user_token, rank`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ballots [][]int
+	var current string
+	for rows.Next() {
+		var voterId string
+		var optionId int
+		if err := rows.Scan(&voterId, &optionId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(ballots) == 0 || voterId != current {
+			ballots = append(ballots, nil)
+			current = voterId
+		}
+		ballots[len(ballots)-1] = append(ballots[len(ballots)-1], optionId)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return ballots, nil
+}
+
+// VoteRanked replaces the ranked ballot of voterId with optionIds, most
+// preferred first.
+func (s *PostgresStorage) VoteRanked(ctx context.Context, voterId string, voteId int, optionIds []int) error {
+	const op = "storage.postgresql.VoteRanked"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, "ranked"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotRanked, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM ranked_results WHERE vote_id = $1 AND user_token = $2`, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO ranked_results (vote_id, user_token, option_id, rank)
+		SELECT $1, $2, r.option_id, r.rank
+		FROM unnest($3::int[]) WITH ORDINALITY AS r(option_id, rank)`,
+		voteId, voterId, optionIds)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.recordBallot(ctx, tx, ballotRanked, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func optionIDs(options []VoteOption) []int {
+	ids := make([]int, 0, len(options))
+	for _, option := range options {
+		ids = append(ids, option.ID)
+	}
+	return ids
+}
@@ -0,0 +1,73 @@
+package storage
+
+import "context"
+
+// Repository is the set of storage operations used by the gRPC handlers.
+// PostgresStorage is the production implementation; MemoryStorage keeps
+// everything in process for tests and local development.
+type Repository interface {
+	GetCategories(ctx context.Context) ([]string, error)
+	GetVotes(ctx context.Context, filter VoteFilter) ([]*Vote, error)
+	GetVote(ctx context.Context, voteId int) (*Vote, error)
+	SearchVotes(ctx context.Context, query SearchQuery) (*SearchResults, error)
+
+	GetUserRates(ctx context.Context, voterId string) ([]*UserRate, error)
+	GetUserChoices(ctx context.Context, voterId string) ([]*UserChoice, error)
+	GetUserPetitions(ctx context.Context, voterId string) ([]*UserPetition, error)
+	GetUserRankings(ctx context.Context, voterId string) ([]*UserRanking, error)
+	GetUserAllocations(ctx context.Context, voterId string) ([]*UserAllocation, error)
+	GetSurveyResponse(ctx context.Context, voterId string, voteId int) (*SurveyResponse, error)
+	GetPendingFollowUps(ctx context.Context, voterId string, voteId int) ([]FollowUp, error)
+	GetUserComment(ctx context.Context, voterId string, voteId int) (*Comment, error)
+
+	GetRateInfo(ctx context.Context, voteId int) (*RateInfo, error)
+	GetPetitionInfo(ctx context.Context, voteId int) (*PetitionInfo, error)
+	GetChoiceInfo(ctx context.Context, voteId int) (*ChoiceInfo, error)
+	GetRankedInfo(ctx context.Context, voteId int) (*RankedInfo, error)
+	GetAllocationInfo(ctx context.Context, voteId int) (*AllocationInfo, error)
+	GetSurveyInfo(ctx context.Context, voteId int) (*SurveyInfo, error)
+
+	VoteRate(ctx context.Context, voterId string, voteId int, rating int, comment *Comment) error
+	VotePetition(ctx context.Context, voterId string, voteId int, support string) error
+	VoteChoice(ctx context.Context, voterId string, voteId int, optionIds []int, comment *Comment) error
+	VoteRanked(ctx context.Context, voterId string, voteId int, optionIds []int) error
+	VoteAllocation(ctx context.Context, voterId string, voteId int, allocations []Allocation) error
+	SubmitSurvey(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer, draft bool) error
+	AnswerFollowUps(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer) error
+	WithdrawVote(ctx context.Context, voterId string, voteId int) error
+	GetBallotHistory(ctx context.Context, voterId string, voteId int) ([]BallotChange, error)
+
+	CreateVote(ctx context.Context, vote *Vote) (*Vote, error)
+	UpdateVote(ctx context.Context, vote *Vote) (*Vote, error)
+	DeleteVote(ctx context.Context, voteId int) error
+	ListAllVotes(ctx context.Context, status string) ([]*Vote, error)
+	SetVoteStatus(ctx context.Context, voteId int, status string) (*Vote, error)
+	AdvanceVoteStatuses(ctx context.Context) (StatusChanges, error)
+
+	CreatePetition(ctx context.Context, vote *Vote) (*Vote, error)
+	ReviewPetition(ctx context.Context, voteId int, status, reason string, signatureGoal int) (*Vote, error)
+	PublishResponse(ctx context.Context, response *PetitionResponse) (*PetitionResponse, error)
+	ListOverduePetitions(ctx context.Context) ([]*Vote, error)
+
+	ListOrganizations(ctx context.Context) ([]Organization, error)
+	GetOrganization(ctx context.Context, organizationId int) (*Organization, error)
+	CreateOrganization(ctx context.Context, organization *Organization) (*Organization, error)
+	UpdateOrganization(ctx context.Context, organization *Organization) (*Organization, error)
+
+	ListTopics(ctx context.Context) ([]Topic, error)
+	CreateTopic(ctx context.Context, topic *Topic) (*Topic, error)
+	UpdateTopic(ctx context.Context, topic *Topic) (*Topic, error)
+
+	GetRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error)
+	ListRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error)
+	GrantRole(ctx context.Context, assignment *RoleAssignment) (*RoleAssignment, error)
+	RevokeRole(ctx context.Context, assignment *RoleAssignment) error
+
+	ListComments(ctx context.Context, status string, voteId int) ([]Comment, error)
+	ReviewComment(ctx context.Context, commentId int, status, reason string) (*Comment, error)
+}
+
+var (
+	_ Repository = (*PostgresStorage)(nil)
+	_ Repository = (*MemoryStorage)(nil)
+)
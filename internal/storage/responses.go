@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Decisions announced in an official response to a petition.
+const (
+	DecisionAccepted          = "accepted"
+	DecisionPartiallyAccepted = "partially_accepted"
+	DecisionRejected          = "rejected"
+)
+
+var Decisions = []string{DecisionAccepted, DecisionPartiallyAccepted, DecisionRejected}
+
+// DefaultResponseWindow is how long an organization has to answer a petition
+// that reached its signature goal.
+const DefaultResponseWindow = 30 * 24 * time.Hour
+
+// maxResponseText and maxAttachments bound the size of a response.
+const (
+	maxResponseText = 10000
+	maxAttachments  = 10
+)
+
+// respondableStatuses are the states of a petition that can be answered. A
+// petition awaiting a response is closed once it has one.
+var respondableStatuses = []string{StatusOpen, StatusAwaitingResponse, StatusClosed}
+
+// Attachment is a document published with a response.
+type Attachment struct {
+	Name string
+	URL  string
+}
+
+// PetitionResponse is the official answer of an organization to a petition.
+// An empty Organization defaults to the one the petition is addressed to.
+type PetitionResponse struct {
+	VoteID       int
+	Decision     string
+	Text         string
+	Organization string
+	Attachments  []Attachment
+	RespondedAt  time.Time
+}
+
+// ValidateResponse checks a response before it is published and trims its
+// text.
+func ValidateResponse(response *PetitionResponse) error {
+	if !contains(Decisions, response.Decision) {
+		return fmt.Errorf("decision must be one of %s", strings.Join(Decisions, ", "))
+	}
+	response.Text = strings.TrimSpace(response.Text)
+	response.Organization = strings.TrimSpace(response.Organization)
+	if response.Text == "" {
+		return errors.New("text is required")
+	}
+	if utf8.RuneCountInString(response.Text) > maxResponseText {
+		return fmt.Errorf("text is longer than %d characters", maxResponseText)
+	}
+	if len(response.Attachments) > maxAttachments {
+		return fmt.Errorf("a response has at most %d attachments", maxAttachments)
+	}
+	for i, attachment := range response.Attachments {
+		if strings.TrimSpace(attachment.Name) == "" {
+			return fmt.Errorf("attachment %d has no name", i+1)
+		}
+		if !isHTTPURL(attachment.URL) {
+			return fmt.Errorf("attachment %q is not a valid http(s) URL", attachment.URL)
+		}
+	}
+	return nil
+}
+
+// PublishResponse stores the official response to a petition, replacing an
+// earlier one, and closes a petition that was awaiting it.
+func (s *PostgresStorage) PublishResponse(ctx context.Context, response *PetitionResponse) (*PetitionResponse, error) {
+	const op = "storage.postgresql.PublishResponse"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var category, status, organization string
+	err = tx.QueryRow(ctx, `SELECT category, status, organization FROM votes WHERE id = $1 FOR UPDATE`, response.VoteID).
+		Scan(&category, &status, &organization)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkRespondable(response.VoteID, category, status); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previous, err := s.getPetitionResponse(ctx, response.VoteID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	published := *response
+	published.RespondedAt = s.opts.clock.Now()
+	if published.Organization == "" {
+		published.Organization = organization
+	}
+	published.Attachments = append([]Attachment{}, response.Attachments...)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO petition_responses (vote_id, decision, text, organization, responded_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (vote_id)
+		DO UPDATE SET decision = EXCLUDED.decision, text = EXCLUDED.text,
+			organization = EXCLUDED.organization, responded_at = EXCLUDED.responded_at`,
+		published.VoteID, published.Decision, published.Text, published.Organization, published.RespondedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM petition_response_attachments WHERE vote_id = $1`, published.VoteID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for position, attachment := range published.Attachments {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO petition_response_attachments (vote_id, name, url, position)
+			VALUES ($1, $2, $3, $4)`,
+			published.VoteID, attachment.Name, attachment.URL, position)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+	}
+
+	if status == StatusAwaitingResponse {
+		if _, err := tx.Exec(ctx, `UPDATE votes SET status = $2 WHERE id = $1`, published.VoteID, StatusClosed); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	err = s.auditChange(ctx, tx, "petition.respond", auditVote, strconv.Itoa(published.VoteID), previous, &published)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &published, nil
+}
+
+// ListOverduePetitions returns the petitions still awaiting a response
+// after their deadline, the most overdue first.
+func (s *PostgresStorage) ListOverduePetitions(ctx context.Context) ([]*Vote, error) {
+	query := `
+		SELECT ` + voteColumns + `
+		FROM votes WHERE status = 'awaiting_response' AND response_overdue
+		ORDER BY response_due, id
+	`
+	return s.fetchVotes(ctx, query)
+}
+
+// getPetitionResponse returns the response to a petition, or nil if it has
+// not been answered yet.
+func (s *PostgresStorage) getPetitionResponse(ctx context.Context, voteId int) (*PetitionResponse, error) {
+	const op = "storage.postgresql.getPetitionResponse"
+
+	response := PetitionResponse{VoteID: voteId, Attachments: []Attachment{}}
+	err := s.db.QueryRow(ctx, `
+		SELECT decision, text, organization, responded_at
+		FROM petition_responses
+		WHERE vote_id = $1`, voteId).
+		Scan(&response.Decision, &response.Text, &response.Organization, &response.RespondedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT name, url
+		FROM petition_response_attachments
+		WHERE vote_id = $1
+		ORDER BY position, id`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attachment Attachment
+		if err := rows.Scan(&attachment.Name, &attachment.URL); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		response.Attachments = append(response.Attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &response, nil
+}
+
+// flagOverdueResponses marks petitions whose response deadline has passed
+// at now and returns the newly flagged ones in order. The flag stays set
+// when a late response is published.
+func flagOverdueResponses(ctx context.Context, tx pgx.Tx, now time.Time) ([]int, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE votes SET response_overdue = TRUE
+		WHERE status = 'awaiting_response' AND NOT response_overdue AND response_due <= $1
+		RETURNING id`, now)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// checkRespondable verifies that a vote is a petition that can be answered.
+func checkRespondable(voteId int, category, status string) error {
+	if err := checkCategory(voteId, category, "petition"); err != nil {
+		return err
+	}
+	if !contains(respondableStatuses, status) {
+		return fmt.Errorf("%w: petition %d is %s and cannot be answered", ErrInvalidTransition, voteId, status)
+	}
+	return nil
+}
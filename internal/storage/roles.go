@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Roles of staff users. Editors are assigned per organization and manage
+// only its votes; the other roles apply to every vote.
+const (
+	RoleAdmin     = "admin"
+	RoleEditor    = "editor"
+	RoleModerator = "moderator"
+	RoleAnalyst   = "analyst"
+)
+
+var Roles = []string{RoleAdmin, RoleEditor, RoleModerator, RoleAnalyst}
+
+// maxRoleUserID bounds the user IDs roles are assigned to.
+const maxRoleUserID = 255
+
+// RoleAssignment grants a role to the user with UserID, the ID the
+// authenticator resolves their token to. OrganizationID is set for editors
+// only.
+type RoleAssignment struct {
+	UserID         string
+	Role           string
+	OrganizationID int
+	GrantedAt      time.Time
+}
+
+// ValidateRoleAssignment checks an assignment before it is granted or
+// revoked and trims its user ID.
+func ValidateRoleAssignment(assignment *RoleAssignment) error {
+	assignment.UserID = strings.TrimSpace(assignment.UserID)
+	if assignment.UserID == "" {
+		return errors.New("user id is required")
+	}
+	if utf8.RuneCountInString(assignment.UserID) > maxRoleUserID {
+		return fmt.Errorf("user id is longer than %d characters", maxRoleUserID)
+	}
+	if !contains(Roles, assignment.Role) {
+		return fmt.Errorf("role must be one of %s", strings.Join(Roles, ", "))
+	}
+	if assignment.Role == RoleEditor && assignment.OrganizationID <= 0 {
+		return errors.New("an editor role requires an organization")
+	}
+	if assignment.Role != RoleEditor && assignment.OrganizationID != 0 {
+		return fmt.Errorf("the %s role is not assigned per organization", assignment.Role)
+	}
+	return nil
+}
+
+// GetRoleAssignments returns the roles of a user.
+func (s *PostgresStorage) GetRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error) {
+	const op = "storage.postgresql.GetRoleAssignments"
+
+	assignments, err := s.queryRoleAssignments(ctx, `user_id = $1`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return assignments, nil
+}
+
+// ListRoleAssignments returns the roles of every user, or of one user when
+// userId is set, ordered by user.
+func (s *PostgresStorage) ListRoleAssignments(ctx context.Context, userId string) ([]RoleAssignment, error) {
+	const op = "storage.postgresql.ListRoleAssignments"
+
+	assignments, err := s.queryRoleAssignments(ctx, `$1 = '' OR user_id = $1`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return assignments, nil
+}
+
+// GrantRole stores a role assignment. Granting a role the user already has
+// is refused with ErrConflict; an unknown organization with
+// ErrOrganizationNotFound.
+func (s *PostgresStorage) GrantRole(ctx context.Context, assignment *RoleAssignment) (*RoleAssignment, error) {
+	const op = "storage.postgresql.GrantRole"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	granted := *assignment
+	err = tx.QueryRow(ctx, `
+		INSERT INTO role_assignments (user_id, role, organization_id)
+		VALUES ($1, $2, $3)
+		RETURNING granted_at`,
+		assignment.UserID, assignment.Role, nullID(assignment.OrganizationID)).Scan(&granted.GrantedAt)
+	if err != nil {
+		err = classifyError(err)
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%s: organization %d: %w", op, assignment.OrganizationID, ErrOrganizationNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.auditChange(ctx, tx, "role.grant", auditRole, roleEntityID(&granted), nil, &granted); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &granted, nil
+}
+
+// RevokeRole removes a role assignment.
+func (s *PostgresStorage) RevokeRole(ctx context.Context, assignment *RoleAssignment) error {
+	const op = "storage.postgresql.RevokeRole"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	revoked := *assignment
+	err = tx.QueryRow(ctx, `
+		DELETE FROM role_assignments
+		WHERE user_id = $1 AND role = $2 AND COALESCE(organization_id, 0) = $3
+		RETURNING granted_at`,
+		assignment.UserID, assignment.Role, assignment.OrganizationID).Scan(&revoked.GrantedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%s: %s of %q: %w", op, assignment.Role, assignment.UserID, ErrRoleNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.auditChange(ctx, tx, "role.revoke", auditRole, roleEntityID(&revoked), &revoked, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// roleEntityID identifies an assignment in the audit log.
+func roleEntityID(assignment *RoleAssignment) string {
+	if assignment.OrganizationID == 0 {
+		return assignment.UserID + "/" + assignment.Role
+	}
+	return fmt.Sprintf("%s/%s/%d", assignment.UserID, assignment.Role, assignment.OrganizationID)
+}
+
+func (s *PostgresStorage) queryRoleAssignments(ctx context.Context, condition, userId string) ([]RoleAssignment, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT user_id, role, COALESCE(organization_id, 0), granted_at
+		FROM role_assignments
+		WHERE `+condition+`
+		ORDER BY user_id, role, organization_id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := []RoleAssignment{}
+	for rows.Next() {
+		var assignment RoleAssignment
+		if err := rows.Scan(&assignment.UserID, &assignment.Role, &assignment.OrganizationID, &assignment.GrantedAt); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments, rows.Err()
+}
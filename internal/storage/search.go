@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Paging limits of SearchVotes and the size of a search query.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	maxSearchQuery     = 200
+)
+
+// Highlight markers around matched words in headlines and snippets, and
+// the length of a description snippet in words.
+const (
+	highlightStart = "<b>"
+	highlightStop  = "</b>"
+	snippetWords   = 35
+)
+
+// htmlEscaped is the SQL expression that escapes the text of column for
+// HTML like html.EscapeString does. Headlines are built from the escaped
+// text, as citizens write the names and descriptions of petitions and the
+// markup in them must not reach clients that render the highlights.
+func htmlEscaped(column string) string {
+	return `replace(replace(replace(replace(replace(` + column + `,
+		'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+}
+
+// fuzzyThreshold is the pg_trgm word similarity a vote name needs to be
+// returned by the typo-tolerant fallback.
+const fuzzyThreshold = 0.4
+
+// SearchQuery selects public votes by text. An empty Category searches
+// every category.
+type SearchQuery struct {
+	Text     string
+	Category string
+	Limit    int
+	Offset   int
+}
+
+// SearchResult is a vote found by SearchVotes. Headline is the vote name
+// and Snippet a fragment of its description. Both are HTML: the text is
+// escaped and matched words are wrapped in <b> and </b>.
+type SearchResult struct {
+	Vote     *Vote
+	Rank     float64
+	Headline string
+	Snippet  string
+}
+
+// SearchResults is a page of search results. Fuzzy is set when nothing
+// matched the full-text query and the results come from the trigram
+// fallback on vote names instead.
+type SearchResults struct {
+	Results []SearchResult
+	Fuzzy   bool
+}
+
+// ValidateSearch checks a search query and fills in the default limit. The
+// category "all" is accepted like GetVotes does and means every category.
+func ValidateSearch(query *SearchQuery) error {
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return errors.New("query is required")
+	}
+	if utf8.RuneCountInString(query.Text) > maxSearchQuery {
+		return fmt.Errorf("query is longer than %d characters", maxSearchQuery)
+	}
+	if query.Category == "all" {
+		query.Category = ""
+	}
+	if query.Category != "" && !isKnownCategory(query.Category) {
+		return fmt.Errorf("unknown category %q", query.Category)
+	}
+	switch {
+	case query.Limit < 0 || query.Offset < 0:
+		return errors.New("limit and offset must not be negative")
+	case query.Limit == 0:
+		query.Limit = DefaultSearchLimit
+	case query.Limit > MaxSearchLimit:
+		query.Limit = MaxSearchLimit
+	}
+	return nil
+}
+
+// SearchVotes ranks public votes against a websearch_to_tsquery query. When
+// the query matches no vote at all, vote names are compared by trigram
+// similarity so that misspelled queries still find something.
+func (s *PostgresStorage) SearchVotes(ctx context.Context, query SearchQuery) (*SearchResults, error) {
+	const op = "storage.postgresql.SearchVotes"
+
+	results, err := s.searchFullText(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(results) > 0 {
+		return &SearchResults{Results: results}, nil
+	}
+
+	if query.Offset > 0 {
+		var matched bool
+		err := s.db.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM votes
+				WHERE search_vector @@ websearch_to_tsquery('russian', $1)
+					AND status = ANY($2) AND ($3 = '' OR category = $3)
+			)`, query.Text, PublicStatuses, query.Category).Scan(&matched)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if matched {
+			return &SearchResults{Results: []SearchResult{}}, nil
+		}
+	}
+
+	results, err = s.searchFuzzy(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &SearchResults{Results: results, Fuzzy: true}, nil
+}
+
+func (s *PostgresStorage) searchFullText(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	const op = "storage.postgresql.searchFullText"
+
+	markers := fmt.Sprintf("StartSel=%s, StopSel=%s", highlightStart, highlightStop)
+	rows, err := s.db.Query(ctx, `
+		SELECT `+voteColumns+`, ts_rank(search_vector, q.query) AS rank,
+			ts_headline('russian', `+htmlEscaped("name")+`, q.query, $6),
+			ts_headline('russian', `+htmlEscaped("description")+`, q.query, $7)
+		FROM votes, websearch_to_tsquery('russian', $1) AS q(query)
+		WHERE search_vector @@ q.query AND status = ANY($2) AND ($3 = '' OR category = $3)
+		ORDER BY rank DESC, id
+		LIMIT $4 OFFSET $5`,
+		query.Text, PublicStatuses, query.Category, query.Limit, query.Offset,
+		markers+", HighlightAll=true", fmt.Sprintf("%s, MaxWords=%d, MinWords=15", markers, snippetWords))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	results, err := s.scanSearchResults(ctx, rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return results, nil
+}
+
+// searchFuzzy runs in a transaction so that the similarity threshold of
+// the <% operator, which the trigram index supports, stays local to it.
+func (s *PostgresStorage) searchFuzzy(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	const op = "storage.postgresql.searchFuzzy"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	threshold := strconv.FormatFloat(fuzzyThreshold, 'f', -1, 64)
+	if _, err := tx.Exec(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`, threshold); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT `+voteColumns+`, word_similarity($1, name) AS rank, `+htmlEscaped("name")+`,
+			`+htmlEscaped(`array_to_string((regexp_split_to_array(btrim(description), '\s+'))[1:$6], ' ')`)+`
+		FROM votes
+		WHERE $1 <% name AND status = ANY($2) AND ($3 = '' OR category = $3)
+		ORDER BY rank DESC, id
+		LIMIT $4 OFFSET $5`,
+		query.Text, PublicStatuses, query.Category, query.Limit, query.Offset, snippetWords)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	results, err := s.scanSearchResults(ctx, rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return results, nil
+}
+
+// scanSearchResults reads rows of voteColumns followed by rank, headline
+// and snippet, then loads the details of every vote.
+func (s *PostgresStorage) scanSearchResults(ctx context.Context, rows pgx.Rows) ([]SearchResult, error) {
+	results := []SearchResult{}
+	for rows.Next() {
+		result := SearchResult{Vote: &Vote{}}
+		if err := scanVote(rows, result.Vote, &result.Rank, &result.Headline, &result.Snippet); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if err := s.loadVoteDetails(ctx, result.Vote); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
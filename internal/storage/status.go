@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lifecycle states of a vote. Citizens only see open and closed votes and
+// petitions awaiting an official response; drafts and scheduled votes are
+// being prepared and archived votes are retired from the public lists.
+// Petitions submitted by citizens start in moderation and are either opened
+// or rejected by a moderator.
+const (
+	StatusDraft            = "draft"
+	StatusScheduled        = "scheduled"
+	StatusOpen             = "open"
+	StatusClosed           = "closed"
+	StatusArchived         = "archived"
+	StatusModeration       = "moderation"
+	StatusRejected         = "rejected"
+	StatusAwaitingResponse = "awaiting_response"
+)
+
+var Statuses = []string{StatusDraft, StatusScheduled, StatusOpen, StatusClosed, StatusArchived,
+	StatusModeration, StatusRejected, StatusAwaitingResponse}
+
+// PublicStatuses are the states visible through the citizen API.
+var PublicStatuses = []string{StatusOpen, StatusClosed, StatusAwaitingResponse}
+
+// InitialStatuses are the states a vote may be created in.
+var InitialStatuses = []string{StatusDraft, StatusScheduled, StatusOpen}
+
+// statusTransitions lists the states reachable from each state. An open
+// petition only moves to awaiting_response by reaching its signature goal.
+var statusTransitions = map[string][]string{
+	StatusDraft:            {StatusScheduled, StatusOpen},
+	StatusScheduled:        {StatusDraft, StatusOpen},
+	StatusOpen:             {StatusClosed},
+	StatusClosed:           {StatusOpen, StatusArchived},
+	StatusArchived:         {StatusClosed},
+	StatusModeration:       {StatusOpen, StatusRejected},
+	StatusRejected:         {StatusModeration, StatusArchived},
+	StatusAwaitingResponse: {StatusClosed, StatusArchived},
+}
+
+// StatusChanges counts the votes moved by AdvanceVoteStatuses.
+type StatusChanges struct {
+	Opened           int
+	Closed           int
+	AwaitingResponse int
+	Overdue          int
+}
+
+func IsKnownStatus(status string) bool {
+	return contains(Statuses, status)
+}
+
+func CanTransition(from, to string) bool {
+	return contains(statusTransitions[from], to)
+}
+
+// InitialStatus is the state of a vote that is published on creation: it is
+// scheduled until its start time and open afterwards.
+func InitialStatus(vote *Vote, now time.Time) string {
+	if !vote.StartTime.IsZero() && now.Before(vote.StartTime) {
+		return StatusScheduled
+	}
+	return StatusOpen
+}
+
+// checkTransition verifies that a vote may move from one state to another at
+// now. A vote can only be scheduled for a future start and only be opened
+// before its end time.
+func checkTransition(voteId int, from, to string, start, end, now time.Time) error {
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w: vote %d cannot move from %s to %s", ErrInvalidTransition, voteId, from, to)
+	}
+	switch to {
+	case StatusScheduled:
+		if start.IsZero() || !now.Before(start) {
+			return fmt.Errorf("%w: vote %d has no start time in the future", ErrInvalidTransition, voteId)
+		}
+	case StatusOpen:
+		if !now.Before(end) {
+			return fmt.Errorf("%w: vote %d ended at %s", ErrInvalidTransition, voteId, end.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// rulesEditable are the states in which the ballot rules of a vote may
+// still change: the vote has not opened yet.
+var rulesEditable = []string{StatusDraft, StatusScheduled, StatusModeration}
+
+// rulesChanged reports whether an edit changes the ballot rules of a vote:
+// its category, rating scale, selection range or budget, or the options,
+// survey questions and follow-ups its ballots refer to. Ballots for what an
+// edit drops would be deleted without an audit entry.
+func rulesChanged(previous, vote *Vote) bool {
+	return previous.Category != vote.Category || previous.Scale != vote.Scale ||
+		previous.Selection != vote.Selection || previous.Budget != vote.Budget ||
+		optionsDropped(previous.Options, vote.Options) || questionsDropped(previous.Questions, vote.Questions) ||
+		followUpsDropped(previous.FollowUps, resolveTriggers(vote.FollowUps, previous.Options))
+}
+
+// optionsDropped reports whether an edit removes or renames one of
+// options, which would delete the ballots for it or count them for another
+// text. Edited options are matched like replaceOptions does: by ID, or by
+// text when no ID is given. Adding options is not a change of the rules.
+func optionsDropped(options, edited []VoteOption) bool {
+	texts := make(map[int]string, len(edited))
+	unmatched := make(map[string]bool, len(edited))
+	for _, option := range edited {
+		if option.ID != 0 {
+			texts[option.ID] = option.Text
+		} else {
+			unmatched[option.Text] = true
+		}
+	}
+	for _, option := range options {
+		text, ok := texts[option.ID]
+		if ok && text != option.Text || !ok && !unmatched[option.Text] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRulesLocked refuses to change the ballot rules of a vote that has
+// opened or has ballots, which were cast under the old rules and could not
+// be counted under the new ones.
+func checkRulesLocked(previous *Vote, hasBallots bool) error {
+	if hasBallots {
+		return fmt.Errorf("%w: vote %d already has ballots", ErrRulesLocked, previous.ID)
+	}
+	if !contains(rulesEditable, previous.Status) {
+		return fmt.Errorf("%w: vote %d is %s", ErrRulesLocked, previous.ID, previous.Status)
+	}
+	return nil
+}
+
+// nextStatus is the state the background job moves a vote to at now, or the
+// current state when nothing is due.
+func nextStatus(status string, start, end time.Time, now time.Time) string {
+	switch {
+	case (status == StatusScheduled || status == StatusOpen) && !now.Before(end):
+		return StatusClosed
+	case status == StatusScheduled && (start.IsZero() || !now.Before(start)):
+		return StatusOpen
+	}
+	return status
+}
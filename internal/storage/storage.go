@@ -1,24 +1,85 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	_ "embed"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
 	"time"
 )
 
+//go:embed seed.json
+var seedVotes []byte
+
+// Vote is a vote of any category. Organization is a copy of the name of the
+// organization identified by OrganizationID, and Topics are the slugs of
+// its topics in alphabetical order. SignatureGoal is the number of
+// signatures after which an open petition awaits an official response, 0
+// meaning no goal; Author is the voter pseudonym of the citizen who
+// submitted a petition (see pseudonym.Keys.VoterID), never their user ID,
+// and ReviewReason the moderator's explanation of a rejection.
+// ResponseDue is set when a petition reaches its goal and ResponseOverdue
+// once it passes without an official response.
 type Vote struct {
-	ID           int
-	Category     string
-	Name         string
-	Description  string
-	Organization string
-	EndTime      time.Time
-	Photo        string
-	Options      []string
+	ID              int
+	ExternalKey     string
+	Category        string
+	Name            string
+	Description     string
+	Organization    string
+	OrganizationID  int
+	Topics          []string
+	StartTime       time.Time
+	EndTime         time.Time
+	Photo           string
+	Options         []VoteOption
+	Questions       []Question
+	FollowUps       []FollowUp
+	Scale           RateScale
+	Selection       SelectionRange
+	Budget          int
+	Status          string
+	SignatureGoal   int
+	Author          string
+	ReviewReason    string
+	ResponseDue     time.Time
+	ResponseOverdue bool
+}
+
+// VoteOption is one answer of a choice vote. Ballots reference options by ID, so
+// the text can be edited without losing votes.
+type VoteOption struct {
+	ID          int
+	Text        string
+	Description string
+	Image       string
+	Position    int
+}
+
+// RateScale limits the ratings accepted by a rate vote to Min..Max in
+// increments of Step.
+type RateScale struct {
+	Min  int
+	Max  int
+	Step int
 }
 
+var DefaultRateScale = RateScale{Min: 1, Max: 5, Step: 1}
+
+// SelectionRange limits how many options a choice ballot selects. Exactly
+// one option is a classic single choice; a larger Max makes it an approval
+// vote where every selected option counts.
+type SelectionRange struct {
+	Min int
+	Max int
+}
+
+var DefaultSelection = SelectionRange{Min: 1, Max: 1}
+
 type RateInfo struct {
 	ID           int
 	Category     string
@@ -27,20 +88,27 @@ type RateInfo struct {
 	Organization string
 	EndTime      time.Time
 	Photo        string
-	Options      []string
+	Options      []VoteOption
 	Mid          float64
+	Comments     []Comment
 }
 
 type PetitionInfo struct {
-	ID           int
-	Category     string
-	Name         string
-	Description  string
-	Organization string
-	EndTime      time.Time
-	Photo        string
-	Options      []string
-	Stats        map[string]int32
+	ID              int
+	Category        string
+	Name            string
+	Description     string
+	Organization    string
+	EndTime         time.Time
+	Photo           string
+	Options         []VoteOption
+	Stats           map[string]int32
+	Status          string
+	SignatureGoal   int
+	Signatures      int
+	Response        *PetitionResponse
+	ResponseDue     time.Time
+	ResponseOverdue bool
 }
 
 type ChoiceInfo struct {
@@ -51,8 +119,11 @@ type ChoiceInfo struct {
 	Organization string
 	EndTime      time.Time
 	Photo        string
-	Options      []string
+	Options      []VoteOption
+	Selection    SelectionRange
 	Stats        map[string]int32
+	Ballots      int
+	Comments     []Comment
 }
 
 type UserRate struct {
@@ -60,9 +131,12 @@ type UserRate struct {
 	Rate int
 }
 
+// UserChoice is the selection of one user in a choice vote, in display
+// order.
 type UserChoice struct {
-	ID     int
-	Choice string
+	ID        int
+	OptionIDs []int
+	Choices   []string
 }
 
 type UserPetition struct {
@@ -70,17 +144,26 @@ type UserPetition struct {
 	Support string
 }
 
+// VoteFilter narrows GetVotes. Zero fields match every vote; Topic is a
+// topic slug.
+type VoteFilter struct {
+	Category       string
+	OrganizationID int
+	Topic          string
+}
+
 type PostgresStorage struct {
-	db *pgxpool.Pool
+	db   *pgxpool.Pool
+	opts options
 }
 
-func NewPostgresStorage(storagePath string) (*PostgresStorage, error) {
+func NewPostgresStorage(storagePath string, opts ...Option) (*PostgresStorage, error) {
 	const op = "storage.postgresql.New"
 	dbpool, err := pgxpool.New(context.Background(), storagePath)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	return &PostgresStorage{db: dbpool}, nil
+	return &PostgresStorage{db: dbpool, opts: applyOptions(opts)}, nil
 }
 
 func (s *PostgresStorage) Close() {
@@ -90,7 +173,7 @@ func (s *PostgresStorage) Close() {
 func (s *PostgresStorage) GetCategories(ctx context.Context) ([]string, error) {
 	const op = "storage.postgresql.GetCategories"
 
-	rows, err := s.db.Query(ctx, "SELECT DISTINCT category FROM votes")
+	rows, err := s.db.Query(ctx, "SELECT DISTINCT category FROM votes WHERE status = ANY($1)", PublicStatuses)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -112,30 +195,35 @@ func (s *PostgresStorage) GetCategories(ctx context.Context) ([]string, error) {
 	return categories, nil
 }
 
-func (s *PostgresStorage) GetVotes(ctx context.Context) ([]*Vote, error) {
-	const op = "storage.postgresql.GetVotes"
-
+// GetVotes returns the public votes matching filter.
+func (s *PostgresStorage) GetVotes(ctx context.Context, filter VoteFilter) ([]*Vote, error) {
 	query := `
-		SELECT id, category, name, description, organization, photo, end_time 
+		SELECT ` + voteColumns + `
 		FROM votes
+		WHERE status = ANY($1) AND ($2 = '' OR category = $2) AND ($3 = 0 OR organization_id = $3)
+			AND ($4 = '' OR EXISTS (
+				SELECT 1 FROM vote_topics vt JOIN topics t ON t.id = vt.topic_id
+				WHERE vt.vote_id = votes.id AND t.slug = $4
+			))
 	`
-	return s.fetchVotes(ctx, query)
+	return s.fetchVotes(ctx, query, PublicStatuses, filter.Category, filter.OrganizationID, filter.Topic)
 }
 
-func (s *PostgresStorage) GetVotesByCategory(ctx context.Context, category string) ([]*Vote, error) {
-	const op = "storage.postgresql.GetVotes"
-
+// ListAllVotes returns votes in every state for the admin API, optionally
+// restricted to one state.
+func (s *PostgresStorage) ListAllVotes(ctx context.Context, status string) ([]*Vote, error) {
 	query := `
-		SELECT id, category, name, description, organization, photo, end_time 
-		FROM votes WHERE category = $1
+		SELECT ` + voteColumns + `
+		FROM votes WHERE $1 = '' OR status = $1
+		ORDER BY id
 	`
-	return s.fetchVotes(ctx, query, category)
+	return s.fetchVotes(ctx, query, status)
 }
 
-func (s *PostgresStorage) GetUserRates(ctx context.Context, token string) ([]*UserRate, error) {
+func (s *PostgresStorage) GetUserRates(ctx context.Context, voterId string) ([]*UserRate, error) {
 	const op = "storage.postgresql.GetUserRates"
 
-	rows, err := s.db.Query(ctx, `SELECT vote_id, user_token, rate FROM rate_results WHERE user_token = $1`, token)
+	rows, err := s.db.Query(ctx, `SELECT vote_id, user_token, rate FROM rate_results WHERE user_token = $1`, voterId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -157,21 +245,32 @@ func (s *PostgresStorage) GetUserRates(ctx context.Context, token string) ([]*Us
 	return rates, nil
 }
 
-func (s *PostgresStorage) GetUserChoices(ctx context.Context, token string) ([]*UserChoice, error) {
+func (s *PostgresStorage) GetUserChoices(ctx context.Context, voterId string) ([]*UserChoice, error) {
 	const op = "storage.postgresql.GetUserRates"
 
-	rows, err := s.db.Query(ctx, `SELECT vote_id, user_token, choice FROM choices_results WHERE user_token = $1`, token)
+	rows, err := s.db.Query(ctx, `
+		SELECT c.vote_id, c.option_id, o.option
+		FROM choices_results c
+		JOIN options o ON o.id = c.option_id
+		WHERE c.user_token = $1
+		ORDER BY c.vote_id, o.position, o.id`, voterId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 	var choices []*UserChoice
 	for rows.Next() {
-		var choice UserChoice
-		if err := rows.Scan(&choice.ID, nil, &choice.Choice); err != nil {
+		var voteId, optionId int
+		var text string
+		if err := rows.Scan(&voteId, &optionId, &text); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		choices = append(choices, &choice)
+		if len(choices) == 0 || choices[len(choices)-1].ID != voteId {
+			choices = append(choices, &UserChoice{ID: voteId})
+		}
+		last := choices[len(choices)-1]
+		last.OptionIDs = append(last.OptionIDs, optionId)
+		last.Choices = append(last.Choices, text)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -182,10 +281,10 @@ func (s *PostgresStorage) GetUserChoices(ctx context.Context, token string) ([]*
 	return choices, nil
 }
 
-func (s *PostgresStorage) GetUserPetitions(ctx context.Context, token string) ([]*UserPetition, error) {
+func (s *PostgresStorage) GetUserPetitions(ctx context.Context, voterId string) ([]*UserPetition, error) {
 	const op = "storage.postgresql.GetUserRates"
 
-	rows, err := s.db.Query(ctx, `SELECT vote_id, user_token, support FROM petition_results WHERE user_token = $1`, token)
+	rows, err := s.db.Query(ctx, `SELECT vote_id, user_token, support FROM petition_results WHERE user_token = $1`, voterId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -219,18 +318,11 @@ func (s *PostgresStorage) fetchVotes(ctx context.Context, query string, args ...
 	var votes []*Vote
 	for rows.Next() {
 		var vote Vote
-		if err := rows.Scan(&vote.ID, &vote.Category, &vote.Name, &vote.Description, &vote.Organization, &vote.Photo, &vote.EndTime); err != nil {
+		if err := scanVote(rows, &vote); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-
-		if vote.Category == "choice" {
-			options, err := s.getOptions(ctx, vote.ID)
-			if err != nil {
-				return nil, fmt.Errorf("%s: %w", op, err)
-			}
-			vote.Options = options
-		} else {
-			vote.Options = []string{}
+		if err := s.loadVoteDetails(ctx, &vote); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
 		votes = append(votes, &vote)
@@ -243,20 +335,102 @@ func (s *PostgresStorage) fetchVotes(ctx context.Context, query string, args ...
 	return votes, nil
 }
 
+// voteColumns is the column list read by scanVote.
+const voteColumns = `id, category, name, description, organization, photo, start_time, end_time,
+		COALESCE(external_key, ''), rate_min, rate_max, rate_step, min_selections, max_selections, budget, status,
+		signature_goal, COALESCE(author_token, ''), review_reason, response_due, response_overdue,
+		COALESCE(organization_id, 0)`
+
+// scanVote reads voteColumns into vote; extra receives any columns selected
+// after them.
+func scanVote(row pgx.Row, vote *Vote, extra ...interface{}) error {
+	var startTime, responseDue *time.Time
+	dest := []interface{}{&vote.ID, &vote.Category, &vote.Name, &vote.Description, &vote.Organization, &vote.Photo,
+		&startTime, &vote.EndTime, &vote.ExternalKey, &vote.Scale.Min, &vote.Scale.Max, &vote.Scale.Step,
+		&vote.Selection.Min, &vote.Selection.Max, &vote.Budget, &vote.Status,
+		&vote.SignatureGoal, &vote.Author, &vote.ReviewReason, &responseDue, &vote.ResponseOverdue,
+		&vote.OrganizationID}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	if startTime != nil {
+		vote.StartTime = *startTime
+	}
+	if responseDue != nil {
+		vote.ResponseDue = *responseDue
+	}
+	return nil
+}
+
+func (s *PostgresStorage) GetVote(ctx context.Context, voteId int) (*Vote, error) {
+	const op = "storage.postgresql.GetVote"
+
+	query := `
+		SELECT ` + voteColumns + `
+		FROM votes
+		WHERE id = $1
+	`
+	var vote Vote
+	if err := scanVote(s.db.QueryRow(ctx, query, voteId), &vote); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.loadVoteDetails(ctx, &vote); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &vote, nil
+}
+
+// loadVoteDetails reads the topics of vote and the options, survey
+// questions and follow-ups that belong to its category.
+func (s *PostgresStorage) loadVoteDetails(ctx context.Context, vote *Vote) error {
+	topics, err := s.getVoteTopics(ctx, vote.ID)
+	if err != nil {
+		return err
+	}
+	vote.Topics = topics
+
+	vote.Options = []VoteOption{}
+	if UsesOptions(vote.Category) {
+		options, err := s.getOptions(ctx, vote.ID)
+		if err != nil {
+			return err
+		}
+		vote.Options = options
+	}
+	if vote.Category == "survey" {
+		questions, err := s.getQuestions(ctx, vote.ID)
+		if err != nil {
+			return err
+		}
+		vote.Questions = questions
+	}
+	if contains(followUpCategories, vote.Category) {
+		followUps, err := s.getFollowUps(ctx, vote.ID)
+		if err != nil {
+			return err
+		}
+		vote.FollowUps = followUps
+	}
+	return nil
+}
+
 func (s *PostgresStorage) GetRateInfo(ctx context.Context, voteId int) (*RateInfo, error) {
 	const op = "storage.postgresql.GetRateInfo"
 
 	query := `
 		SELECT id, category, name, description, organization, photo, end_time 
 		FROM votes 
-		WHERE id = $1 AND category = 'rate'
+		WHERE id = $1 AND status = ANY($2)
 	`
 	var rateInfo RateInfo
-	err := s.db.QueryRow(ctx, query, voteId).Scan(
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
 		&rateInfo.ID, &rateInfo.Category, &rateInfo.Name, &rateInfo.Description,
 		&rateInfo.Organization, &rateInfo.Photo, &rateInfo.EndTime,
 	)
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, rateInfo.Category, "rate"); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -265,7 +439,13 @@ func (s *PostgresStorage) GetRateInfo(ctx context.Context, voteId int) (*RateInf
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	rateInfo.Mid = mid
-	rateInfo.Options = []string{}
+	rateInfo.Options = []VoteOption{}
+
+	comments, err := s.getApprovedComments(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rateInfo.Comments = comments
 
 	return &rateInfo, nil
 }
@@ -274,16 +454,22 @@ func (s *PostgresStorage) GetPetitionInfo(ctx context.Context, voteId int) (*Pet
 	const op = "storage.postgresql.GetPetitionInfo"
 
 	query := `
-		SELECT id, category, name, description, organization, photo, end_time 
+		SELECT id, category, name, description, organization, photo, end_time, status, signature_goal,
+			response_due, response_overdue
 		FROM votes 
-		WHERE id = $1 AND category = 'petition'
+		WHERE id = $1 AND status = ANY($2)
 	`
 	var petitionInfo PetitionInfo
-	err := s.db.QueryRow(ctx, query, voteId).Scan(
+	var responseDue *time.Time
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
 		&petitionInfo.ID, &petitionInfo.Category, &petitionInfo.Name, &petitionInfo.Description,
 		&petitionInfo.Organization, &petitionInfo.Photo, &petitionInfo.EndTime,
+		&petitionInfo.Status, &petitionInfo.SignatureGoal, &responseDue, &petitionInfo.ResponseOverdue,
 	)
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, petitionInfo.Category, "petition"); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -292,7 +478,17 @@ func (s *PostgresStorage) GetPetitionInfo(ctx context.Context, voteId int) (*Pet
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	petitionInfo.Stats = stats
-	petitionInfo.Options = []string{}
+	petitionInfo.Signatures = int(stats[signatureSupport])
+	petitionInfo.Options = []VoteOption{}
+	if responseDue != nil {
+		petitionInfo.ResponseDue = *responseDue
+	}
+
+	response, err := s.getPetitionResponse(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	petitionInfo.Response = response
 
 	return &petitionInfo, nil
 }
@@ -301,16 +497,20 @@ func (s *PostgresStorage) GetChoiceInfo(ctx context.Context, voteId int) (*Choic
 	const op = "storage.postgresql.GetChoiceInfo"
 
 	query := `
-		SELECT id, category, name, description, organization, photo, end_time 
+		SELECT id, category, name, description, organization, photo, end_time, min_selections, max_selections
 		FROM votes 
-		WHERE id = $1 AND category = 'choice'
+		WHERE id = $1 AND status = ANY($2)
 	`
 	var choiceInfo ChoiceInfo
-	err := s.db.QueryRow(ctx, query, voteId).Scan(
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
 		&choiceInfo.ID, &choiceInfo.Category, &choiceInfo.Name, &choiceInfo.Description,
 		&choiceInfo.Organization, &choiceInfo.Photo, &choiceInfo.EndTime,
+		&choiceInfo.Selection.Min, &choiceInfo.Selection.Max,
 	)
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, choiceInfo.Category, "choice"); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -326,10 +526,24 @@ func (s *PostgresStorage) GetChoiceInfo(ctx context.Context, voteId int) (*Choic
 	}
 	choiceInfo.Stats = stats
 
+	err = s.db.QueryRow(ctx, `SELECT COUNT(DISTINCT user_token) FROM choices_results WHERE vote_id = $1`, voteId).
+		Scan(&choiceInfo.Ballots)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	comments, err := s.getApprovedComments(ctx, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	choiceInfo.Comments = comments
+
 	return &choiceInfo, nil
 }
 
-func (s *PostgresStorage) VoteRate(ctx context.Context, token string, voteId int, rating int) error {
+// VoteRate upserts the rating of voterId. A non-nil comment replaces the
+// comment sent with an earlier ballot.
+func (s *PostgresStorage) VoteRate(ctx context.Context, voterId string, voteId int, rating int, comment *Comment) error {
 	const op = "storage.postgresql.VoteRate"
 
 	query := `
@@ -338,14 +552,39 @@ func (s *PostgresStorage) VoteRate(ctx context.Context, token string, voteId int
 		ON CONFLICT (vote_id, user_token) 
 		DO UPDATE SET rate = EXCLUDED.rate
 	`
-	_, err := s.db.Exec(ctx, query, voteId, token, rating)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, "rate"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotRate, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, query, voteId, voterId, rating); err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := pruneFollowUpAnswers(ctx, tx, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := saveComment(ctx, tx, voteId, voterId, comment, s.opts.clock.Now()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.recordBallot(ctx, tx, ballotRate, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	return nil
 }
 
-func (s *PostgresStorage) VotePetition(ctx context.Context, token string, voteId int, support string) error {
+func (s *PostgresStorage) VotePetition(ctx context.Context, voterId string, voteId int, support string) error {
 	const op = "storage.postgresql.VotePetition"
 
 	query := `
@@ -354,36 +593,118 @@ func (s *PostgresStorage) VotePetition(ctx context.Context, token string, voteId
 		ON CONFLICT (vote_id, user_token) 
 		DO UPDATE SET support = EXCLUDED.support
 	`
-	_, err := s.db.Exec(ctx, query, voteId, token, support)
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, "petition"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotPetition, voteId, voterId)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	if _, err := tx.Exec(ctx, query, voteId, voterId, support); err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.recordBallot(ctx, tx, ballotPetition, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The goal is checked after the commit: promoting the petition takes an
+	// exclusive lock on the vote row, which the shared lock of concurrent
+	// signatures would turn into a deadlock. The signature is stored by now,
+	// so a failed promotion is only logged and caught up by
+	// AdvanceVoteStatuses.
+	if support == signatureSupport {
+		if _, err := s.promotePetitions(ctx, voteId); err != nil {
+			s.opts.logger.Error("Failed to promote petition", slog.String("op", op), slog.Int("vote_id", voteId),
+				slog.String("error", err.Error()))
+		}
+	}
 	return nil
 }
 
-func (s *PostgresStorage) VoteChoice(ctx context.Context, token string, voteId int, choice string) error {
+// VoteChoice replaces the selection of voterId with optionIds. A non-nil
+// comment replaces the comment sent with an earlier ballot.
+func (s *PostgresStorage) VoteChoice(ctx context.Context, voterId string, voteId int, optionIds []int, comment *Comment) error {
 	const op = "storage.postgresql.VoteChoice"
 
 	query := `
-		INSERT INTO choices_results (vote_id, user_token, choice)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (vote_id, user_token) 
-		DO UPDATE SET choice = EXCLUDED.choice
+		INSERT INTO choices_results (vote_id, user_token, option_id)
+		SELECT $1, $2, unnest($3::int[])
 	`
-	_, err := s.db.Exec(ctx, query, voteId, token, choice)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, "choice"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotChoice, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM choices_results WHERE vote_id = $1 AND user_token = $2`, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.Exec(ctx, query, voteId, voterId, optionIds); err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := pruneFollowUpAnswers(ctx, tx, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := saveComment(ctx, tx, voteId, voterId, comment, s.opts.clock.Now()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.recordBallot(ctx, tx, ballotChoice, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	return nil
 }
 
-func (s *PostgresStorage) getOptions(ctx context.Context, voteId int) ([]string, error) {
+// checkBallotAllowed locks the vote row for the rest of the transaction and
+// verifies that it has the expected category and currently accepts ballots.
+func (s *PostgresStorage) checkBallotAllowed(ctx context.Context, tx pgx.Tx, voteId int, categories ...string) error {
+	var actual, status string
+	var startTime *time.Time
+	var endTime time.Time
+	err := tx.QueryRow(ctx, `SELECT category, status, start_time, end_time FROM votes WHERE id = $1 FOR SHARE`, voteId).
+		Scan(&actual, &status, &startTime, &endTime)
+	if err != nil {
+		return classifyError(err)
+	}
+	if err := checkCategory(voteId, actual, categories...); err != nil {
+		return err
+	}
+
+	var start time.Time
+	if startTime != nil {
+		start = *startTime
+	}
+	return checkVoteOpen(voteId, status, start, endTime, s.opts.clock.Now())
+}
+
+func (s *PostgresStorage) getOptions(ctx context.Context, voteId int) ([]VoteOption, error) {
 	const op = "storage.postgresql.getOptions"
 
 	query := `
-		SELECT option 
+		SELECT id, option, description, image, position
 		FROM options 
 		WHERE vote_id = $1
+		ORDER BY position, id
 	`
 	rows, err := s.db.Query(ctx, query, voteId)
 	if err != nil {
@@ -391,10 +712,10 @@ func (s *PostgresStorage) getOptions(ctx context.Context, voteId int) ([]string,
 	}
 	defer rows.Close()
 
-	var options []string
+	options := []VoteOption{}
 	for rows.Next() {
-		var option string
-		if err := rows.Scan(&option); err != nil {
+		var option VoteOption
+		if err := rows.Scan(&option.ID, &option.Text, &option.Description, &option.Image, &option.Position); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 		options = append(options, option)
@@ -460,10 +781,11 @@ func (s *PostgresStorage) calculateChoiceStats(ctx context.Context, voteId int)
 	const op = "storage.postgresql.calculateChoiceStats"
 
 	query := `
-		SELECT choice, COUNT(*) 
-		FROM choices_results 
-		WHERE vote_id = $1 
-		GROUP BY choice
+		SELECT o.option, COUNT(*) 
+		FROM choices_results c
+		JOIN options o ON o.id = c.option_id
+		WHERE c.vote_id = $1 
+		GROUP BY o.option
 	`
 	rows, err := s.db.Query(ctx, query, voteId)
 	if err != nil {
@@ -501,110 +823,21 @@ func (s *PostgresStorage) FetchAndStoreData(ctx context.Context) error {
 		return nil
 	}
 
-	votes := []Vote{
-		{1, "choice", "Лучший кружок по интересам", "Опрос о том, какой кружок по интересам в вашем районе вы считаете самым интересным и полезным.", "Управление молодежной политики Республики Татарстан", time.Now().Add(154 * time.Hour), "https://krupki.by/images/zastavki/deti_tvorchestvo_2.jpg", []string{"Кружок робототехники", "Художественная студия", "Спортивная секция", "Музыкальная группа"}},
-		{2, "choice", "Лучшее место для отдыха в Татарстане", "Опрос о том, какое место для отдыха в Татарстане вы считаете самым привлекательным.", "Министерство туризма Республики Татарстан", time.Now().Add(254 * time.Hour), "https://cdn.tripster.ru/thumbs2/1d8c9102-e90d-11ed-9add-42476a0af5aa.1220x600.jpeg", []string{"Казанская набережная", "Национальный парк «Шульган-Таш»", "Озеро Кабан", "Гора Муслюмово"}},
-		{3, "petition", "Создание велодорожек в Казани", "Поддержите петицию о создании велодорожек для безопасного передвижения велосипедистов по городу.", "Группа инициативных граждан", time.Now().Add(204 * time.Hour), "https://sun9-66.userapi.com/impg/0PdgWVSRvBbkcwrwuNbNhTZfU-Tk6S0oPH4cKQ/5awLbsk3B_M.jpg?size=1052x596&quality=95&sign=c1b6b3e55f319113dbd14a8e0fd03ada&type=album", []string{}},
-		{4, "petition", "Запрос на улучшение общественного транспорта", "Подпишите петицию за улучшение качества общественного транспорта в нашем районе.", "Общественное движение «Транспорт для всех»", time.Now().Add(554 * time.Hour), "https://kazantransport.ru/information_items_property_761.jpg", []string{}},
-		{5, "rate", "Отзыв о работе общественного транспорта", "Поделитесь своим мнением о качестве работы общественного транспорта в вашем районе. Ваши отзывы помогут улучшить сервис.", "Министерство транспорта Республики Татарстан", time.Now().Add(354 * time.Hour), "https://sun9-68.userapi.com/s/v1/ig2/ZcNGIpVANdONHaduKo_AyI_ZGO70gCmsJoERl6ueb2qWLKHp20zyZ0VT1XjRrqjNDCdtNMFiphriuiolRj5PyDls.jpg?quality=95&as=32x24,48x36,72x54,108x81,160x120,240x180,360x270,480x360,540x405,640x480,720x540,870x653&from=bu&u=bAdxtPh4rqpatU9DDn8YeaUbV95ztvCXd3J8ADBTqaQ&cs=807x606", []string{}},
-		{6, "rate", "Отзыв о культурном мероприятии", "Поделитесь своим впечатлением о культурном мероприятии, которое вы посетили. Ваши отзывы помогут организовать лучшие события в будущем.", "Управление культуры Республики Татарстан", time.Now().Add(194 * time.Hour), "https://ucare.timepad.ru/a7c550ce-b1a7-4ee2-ab8f-81759077108c/-/preview/600x600/", []string{}},
-	}
-
-	tx, err := s.db.Begin(ctx)
+	records, err := DecodeVotes(bytes.NewReader(seedVotes), FormatJSON, s.opts.clock.Now())
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback(ctx)
-
-	for _, vote := range votes {
-		var voteID int
-		err := tx.QueryRow(ctx, `
-            INSERT INTO votes (category, name, description, organization, photo, end_time)
-            VALUES ($1, $2, $3, $4, $5, $6)
-            RETURNING id`,
-			vote.Category, vote.Name, vote.Description, vote.Organization, vote.Photo, vote.EndTime).Scan(&voteID)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
-
-		for _, option := range vote.Options {
-			_, err = tx.Exec(ctx, `INSERT INTO options (vote_id, option) VALUES ($1, $2)`, voteID, option)
-			if err != nil {
-				return fmt.Errorf("%s: %w", op, err)
-			}
-		}
-	}
 
-	if err := tx.Commit(ctx); err != nil {
+	report, err := s.ImportVotes(ctx, records, ImportOptions{})
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
-}
-
-func (s *PostgresStorage) CreateTables(ctx context.Context) error {
-	const op = "storage.postgresql.CreateTables"
-	tables := []struct {
-		name  string
-		query string
-	}{
-		{
-			name: "votes",
-			query: `
-				CREATE TABLE IF NOT EXISTS votes (
-					id SERIAL PRIMARY KEY,
-					category VARCHAR(255),
-					name TEXT,
-					description TEXT,
-					organization TEXT,
-					photo TEXT,
-					end_time TIMESTAMP
-				)`,
-		},
-		{
-			name: "options",
-			query: `
-				CREATE TABLE IF NOT EXISTS options (
-					vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
-					option VARCHAR(255)
-				)`,
-		},
-		{
-			name: "rate_results",
-			query: `
-				CREATE TABLE IF NOT EXISTS rate_results (
-					vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
-					user_token TEXT,
-					rate INT,
-					UNIQUE (vote_id, user_token)
-				)`,
-		},
-		{
-			name: "petition_results",
-			query: `
-				CREATE TABLE IF NOT EXISTS petition_results (
-					vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
-					user_token TEXT,
-					support VARCHAR(50),
-					UNIQUE (vote_id, user_token)
-				)`,
-		},
-		{
-			name: "choices_results",
-			query: `
-				CREATE TABLE IF NOT EXISTS choices_results (
-					vote_id INT REFERENCES votes(id) ON DELETE CASCADE,
-					user_token TEXT,
-					choice TEXT,
-					UNIQUE (vote_id, user_token)
-				)`,
-		},
-	}
-
-	for _, table := range tables {
-		_, err := s.db.Exec(ctx, table.query)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+	if len(report.Errors) > 0 {
+		errs := make([]error, 0, len(report.Errors))
+		for _, importErr := range report.Errors {
+			errs = append(errs, importErr)
 		}
+		return fmt.Errorf("%s: %w", op, errors.Join(errs...))
 	}
 	return nil
 }
@@ -0,0 +1,562 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// Question kinds of a survey.
+const (
+	QuestionRating = "rating"
+	QuestionSingle = "single"
+	QuestionMulti  = "multi"
+	QuestionText   = "text"
+)
+
+var QuestionKinds = []string{QuestionRating, QuestionSingle, QuestionMulti, QuestionText}
+
+// Question is one question of a survey vote. Rating questions use Scale,
+// single and multi choice questions use Options.
+type Question struct {
+	ID       int
+	Position int
+	Kind     string
+	Text     string
+	Required bool
+	Options  []VoteOption
+	Scale    RateScale
+}
+
+// SurveyAnswer answers one question: Rating for rating questions, OptionIDs
+// for choice questions and Text for free-text questions.
+type SurveyAnswer struct {
+	QuestionID int
+	Rating     *int
+	OptionIDs  []int
+	Text       string
+}
+
+// SurveyResponse is the response of one user to a survey. A response that
+// is not submitted is a draft and is left out of the results.
+type SurveyResponse struct {
+	VoteID    int
+	Submitted bool
+	UpdatedAt time.Time
+	Answers   []SurveyAnswer
+}
+
+type SurveyInfo struct {
+	ID           int
+	Category     string
+	Name         string
+	Description  string
+	Organization string
+	EndTime      time.Time
+	Photo        string
+	Questions    []Question
+	Responses    int
+	Results      []QuestionResult
+}
+
+// QuestionResult aggregates the submitted answers to one question. Ratings
+// maps each rating to the number of answers giving it and Options each
+// option ID to the number of answers selecting it.
+type QuestionResult struct {
+	QuestionID int
+	Answers    int
+	Average    float64
+	Ratings    map[int]int
+	Options    map[int]int
+}
+
+// surveyResults builds the per-question results, in question order, from
+// counts keyed by question ID.
+func surveyResults(questions []Question, answers map[int]int, ratings, options map[int]map[int]int) []QuestionResult {
+	results := make([]QuestionResult, 0, len(questions))
+	for _, question := range questions {
+		result := QuestionResult{
+			QuestionID: question.ID,
+			Answers:    answers[question.ID],
+			Ratings:    make(map[int]int),
+			Options:    make(map[int]int),
+		}
+		var sum, count int
+		for rating, n := range ratings[question.ID] {
+			result.Ratings[rating] = n
+			sum += rating * n
+			count += n
+		}
+		if count > 0 {
+			result.Average = float64(sum) / float64(count)
+		}
+		for optionId, n := range options[question.ID] {
+			if hasOptionID(question.Options, optionId) {
+				result.Options[optionId] = n
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func copyQuestions(questions []Question) []Question {
+	copied := make([]Question, 0, len(questions))
+	for _, question := range questions {
+		question.Options = append([]VoteOption{}, question.Options...)
+		copied = append(copied, question)
+	}
+	return copied
+}
+
+func findQuestion(questions []Question, id int) (Question, bool) {
+	for _, question := range questions {
+		if question.ID == id {
+			return question, true
+		}
+	}
+	return Question{}, false
+}
+
+func (s *PostgresStorage) getQuestions(ctx context.Context, voteId int) ([]Question, error) {
+	const op = "storage.postgresql.getQuestions"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT id, position, kind, text, required, rate_min, rate_max, rate_step
+		FROM survey_questions
+		WHERE vote_id = $1
+		ORDER BY position, id`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	questions := []Question{}
+	index := make(map[int]int)
+	for rows.Next() {
+		question := Question{Options: []VoteOption{}}
+		err := rows.Scan(&question.ID, &question.Position, &question.Kind, &question.Text, &question.Required,
+			&question.Scale.Min, &question.Scale.Max, &question.Scale.Step)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[question.ID] = len(questions)
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = s.db.Query(ctx, `
+		SELECT o.question_id, o.id, o.option, o.description, o.image, o.position
+		FROM survey_question_options o
+		JOIN survey_questions q ON q.id = o.question_id
+		WHERE q.vote_id = $1
+		ORDER BY o.position, o.id`, voteId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var questionId int
+		var option VoteOption
+		if err := rows.Scan(&questionId, &option.ID, &option.Text, &option.Description, &option.Image, &option.Position); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if i, ok := index[questionId]; ok {
+			questions[i].Options = append(questions[i].Options, option)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return questions, nil
+}
+
+// GetSurveyResponse returns the response of voterId to a survey, or nil if
+// the user has not answered it yet.
+func (s *PostgresStorage) GetSurveyResponse(ctx context.Context, voterId string, voteId int) (*SurveyResponse, error) {
+	const op = "storage.postgresql.GetSurveyResponse"
+
+	response := SurveyResponse{VoteID: voteId}
+	err := s.db.QueryRow(ctx, `
+		SELECT submitted, updated_at
+		FROM survey_responses
+		WHERE vote_id = $1 AND user_token = $2`, voteId, voterId).Scan(&response.Submitted, &response.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT a.question_id, a.rating, COALESCE(a.text, ''),
+			array_remove(array_agg(ao.option_id ORDER BY o.position, o.id), NULL)
+		FROM survey_answers a
+		JOIN survey_questions q ON q.id = a.question_id
+		LEFT JOIN survey_answer_options ao
+			ON ao.vote_id = a.vote_id AND ao.user_token = a.user_token AND ao.question_id = a.question_id
+		LEFT JOIN survey_question_options o ON o.id = ao.option_id
+		WHERE a.vote_id = $1 AND a.user_token = $2
+		GROUP BY a.question_id, a.rating, a.text, q.position
+		ORDER BY q.position, a.question_id`, voteId, voterId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var answer SurveyAnswer
+		if err := rows.Scan(&answer.QuestionID, &answer.Rating, &answer.Text, &answer.OptionIDs); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		response.Answers = append(response.Answers, answer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &response, nil
+}
+
+func (s *PostgresStorage) GetSurveyInfo(ctx context.Context, voteId int) (*SurveyInfo, error) {
+	const op = "storage.postgresql.GetSurveyInfo"
+
+	query := `
+		SELECT id, category, name, description, organization, photo, end_time
+		FROM votes
+		WHERE id = $1 AND status = ANY($2)
+	`
+	var info SurveyInfo
+	err := s.db.QueryRow(ctx, query, voteId, PublicStatuses).Scan(
+		&info.ID, &info.Category, &info.Name, &info.Description,
+		&info.Organization, &info.Photo, &info.EndTime,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := checkCategory(voteId, info.Category, "survey"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if info.Questions, err = s.getQuestions(ctx, voteId); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.db.QueryRow(ctx, `SELECT COUNT(*) FROM survey_responses WHERE vote_id = $1 AND submitted`, voteId).
+		Scan(&info.Responses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	answers, err := s.countSurveyAnswers(ctx, voteId, `
+		SELECT a.question_id, 0, COUNT(*)
+		FROM survey_answers a
+		JOIN survey_responses r USING (vote_id, user_token)
+		WHERE a.vote_id = $1 AND r.submitted
+		GROUP BY a.question_id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	ratings, err := s.countSurveyAnswers(ctx, voteId, `
+		SELECT a.question_id, a.rating, COUNT(*)
+		FROM survey_answers a
+		JOIN survey_responses r USING (vote_id, user_token)
+		WHERE a.vote_id = $1 AND r.submitted AND a.rating IS NOT NULL
+		GROUP BY a.question_id, a.rating`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	options, err := s.countSurveyAnswers(ctx, voteId, `
+		SELECT ao.question_id, ao.option_id, COUNT(*)
+		FROM survey_answer_options ao
+		JOIN survey_responses r USING (vote_id, user_token)
+		WHERE ao.vote_id = $1 AND r.submitted
+		GROUP BY ao.question_id, ao.option_id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	answered := make(map[int]int, len(answers))
+	for questionId, counts := range answers {
+		answered[questionId] = counts[0]
+	}
+	info.Results = surveyResults(info.Questions, answered, ratings, options)
+	return &info, nil
+}
+
+// countSurveyAnswers runs a query returning (question_id, key, count) rows
+// and groups the counts by question.
+func (s *PostgresStorage) countSurveyAnswers(ctx context.Context, voteId int, query string) (map[int]map[int]int, error) {
+	rows, err := s.db.Query(ctx, query, voteId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]map[int]int)
+	for rows.Next() {
+		var questionId, key, count int
+		if err := rows.Scan(&questionId, &key, &count); err != nil {
+			return nil, err
+		}
+		if counts[questionId] == nil {
+			counts[questionId] = make(map[int]int)
+		}
+		counts[questionId][key] = count
+	}
+	return counts, rows.Err()
+}
+
+// SubmitSurvey replaces the response of voterId with answers in one
+// transaction. A draft can be saved any number of times until the response
+// is submitted; after that only another submission may replace it.
+func (s *PostgresStorage) SubmitSurvey(ctx context.Context, voterId string, voteId int, answers []SurveyAnswer, draft bool) error {
+	const op = "storage.postgresql.SubmitSurvey"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.checkBallotAllowed(ctx, tx, voteId, "survey"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	before, err := ballotSnapshot(ctx, tx, ballotSurvey, voteId, voterId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var submitted bool
+	err = tx.QueryRow(ctx, `
+		INSERT INTO survey_responses (vote_id, user_token, submitted, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (vote_id, user_token)
+		DO UPDATE SET submitted = EXCLUDED.submitted, updated_at = EXCLUDED.updated_at
+		WHERE EXCLUDED.submitted OR NOT survey_responses.submitted
+		RETURNING submitted`, voteId, voterId, !draft, s.opts.clock.Now()).Scan(&submitted)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%s: %w: survey %d was already submitted", op, ErrConflict, voteId)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM survey_answers WHERE vote_id = $1 AND user_token = $2`, voteId, voterId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, answer := range answers {
+		var text *string
+		if answer.Text != "" {
+			text = &answer.Text
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO survey_answers (vote_id, user_token, question_id, rating, text)
+			VALUES ($1, $2, $3, $4, $5)`,
+			voteId, voterId, answer.QuestionID, answer.Rating, text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+		if len(answer.OptionIDs) == 0 {
+			continue
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO survey_answer_options (vote_id, user_token, question_id, option_id)
+			SELECT $1, $2, $3, unnest($4::int[])`,
+			voteId, voterId, answer.QuestionID, answer.OptionIDs)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, classifyError(err))
+		}
+	}
+	if err := s.recordBallot(ctx, tx, ballotSurvey, voteId, voterId, before); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// questionsDropped reports whether an edit removes, renames or retypes one
+// of questions, changes its scale or drops one of its options. Any of these
+// would delete the answers to the question or count them for another text.
+// Questions are matched like replaceQuestions does.
+func questionsDropped(questions, edited []Question) bool {
+	byID := make(map[int]*Question, len(edited))
+	byText := make(map[string]*Question, len(edited))
+	for i := range edited {
+		if edited[i].ID != 0 {
+			byID[edited[i].ID] = &edited[i]
+		} else {
+			byText[edited[i].Text] = &edited[i]
+		}
+	}
+	for _, question := range questions {
+		match := byID[question.ID]
+		if match == nil {
+			match = byText[question.Text]
+		}
+		if match == nil || match.Text != question.Text || match.Kind != question.Kind || match.Scale != question.Scale ||
+			optionsDropped(question.Options, match.Options) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceQuestions makes the questions of a survey equal to questions, in
+// that order, the way replaceOptions does for options: questions are
+// matched by ID, or by text when no ID is given. Answers that no longer fit
+// their question are deleted afterwards.
+func replaceQuestions(ctx context.Context, tx pgx.Tx, voteId int, questions []Question) ([]Question, error) {
+	keepIDs := []int{}
+	keepTexts := []string{}
+	for _, question := range questions {
+		if question.ID != 0 {
+			keepIDs = append(keepIDs, question.ID)
+		} else {
+			keepTexts = append(keepTexts, question.Text)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM survey_questions WHERE vote_id = $1 AND id <> ALL($2) AND text <> ALL($3)`, voteId, keepIDs, keepTexts)
+	if err != nil {
+		return nil, err
+	}
+	renamed := make(map[int]string, len(keepIDs))
+	for _, question := range questions {
+		if question.ID != 0 {
+			renamed[question.ID] = question.Text
+		}
+	}
+	if err := moveRenamed(ctx, tx, "survey_questions", "vote_id", "text", voteId, renamed); err != nil {
+		return nil, err
+	}
+
+	stored := make([]Question, 0, len(questions))
+	for position, question := range questions {
+		question.Position = position
+		if question.ID != 0 {
+			tag, err := tx.Exec(ctx, `
+				UPDATE survey_questions
+				SET kind = $3, text = $4, required = $5, position = $6, rate_min = $7, rate_max = $8, rate_step = $9
+				WHERE id = $1 AND vote_id = $2`,
+				question.ID, voteId, question.Kind, question.Text, question.Required, question.Position,
+				question.Scale.Min, question.Scale.Max, question.Scale.Step)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+			if tag.RowsAffected() == 0 {
+				return nil, fmt.Errorf("%w: question %d does not belong to vote %d", ErrInvalidQuestion, question.ID, voteId)
+			}
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO survey_questions (vote_id, kind, text, required, position, rate_min, rate_max, rate_step)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (vote_id, text)
+				DO UPDATE SET kind = EXCLUDED.kind, required = EXCLUDED.required, position = EXCLUDED.position,
+					rate_min = EXCLUDED.rate_min, rate_max = EXCLUDED.rate_max, rate_step = EXCLUDED.rate_step
+				RETURNING id`,
+				voteId, question.Kind, question.Text, question.Required, question.Position,
+				question.Scale.Min, question.Scale.Max, question.Scale.Step).Scan(&question.ID)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+		}
+
+		if question.Options, err = replaceQuestionOptions(ctx, tx, "survey_question_options", "question_id", question.ID, question.Options); err != nil {
+			return nil, err
+		}
+		stored = append(stored, question)
+	}
+
+	if err := pruneSurveyAnswers(ctx, tx, voteId); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// replaceQuestionOptions replaces the options of a survey question or a
+// follow-up; table holds the options and parent is its question column.
+func replaceQuestionOptions(ctx context.Context, tx pgx.Tx, table, parent string, questionId int, options []VoteOption) ([]VoteOption, error) {
+	keepIDs := []int{}
+	keepTexts := []string{}
+	for _, option := range options {
+		if option.ID != 0 {
+			keepIDs = append(keepIDs, option.ID)
+		} else {
+			keepTexts = append(keepTexts, option.Text)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE `+parent+` = $1 AND id <> ALL($2) AND option <> ALL($3)`,
+		questionId, keepIDs, keepTexts)
+	if err != nil {
+		return nil, err
+	}
+	if err := moveRenamed(ctx, tx, table, parent, "option", questionId, renamedTexts(options)); err != nil {
+		return nil, err
+	}
+
+	stored := make([]VoteOption, 0, len(options))
+	for position, option := range options {
+		option.Position = position
+		if option.ID != 0 {
+			tag, err := tx.Exec(ctx, `
+				UPDATE `+table+` SET option = $3, description = $4, image = $5, position = $6
+				WHERE id = $1 AND `+parent+` = $2`,
+				option.ID, questionId, option.Text, option.Description, option.Image, option.Position)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+			if tag.RowsAffected() == 0 {
+				return nil, fmt.Errorf("%w: option %d does not belong to question %d", ErrInvalidOption, option.ID, questionId)
+			}
+		} else {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO `+table+` (`+parent+`, option, description, image, position)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT (`+parent+`, option)
+				DO UPDATE SET description = EXCLUDED.description, image = EXCLUDED.image, position = EXCLUDED.position
+				RETURNING id`,
+				questionId, option.Text, option.Description, option.Image, option.Position).Scan(&option.ID)
+			if err != nil {
+				return nil, classifyError(err)
+			}
+		}
+		stored = append(stored, option)
+	}
+	return stored, nil
+}
+
+// pruneSurveyAnswers deletes the answers of a survey that no longer fit
+// their question after an edit: a changed kind, a rating off the new scale
+// or a choice left with the wrong number of options.
+func pruneSurveyAnswers(ctx context.Context, tx pgx.Tx, voteId int) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM survey_answers a
+		USING survey_questions q
+		WHERE q.id = a.question_id AND q.vote_id = $1 AND `+answerMismatch("survey_answer_options", "question_id"), voteId)
+	return err
+}
+
+// answerMismatch is the SQL condition under which answer a does not fit its
+// question q. The selected options are in table, keyed by the parent
+// question column.
+func answerMismatch(table, parent string) string {
+	return `CASE q.kind
+			WHEN 'rating' THEN a.rating IS NULL OR a.text IS NOT NULL
+				OR a.rating NOT BETWEEN q.rate_min AND q.rate_max OR (a.rating - q.rate_min) % q.rate_step <> 0
+			WHEN 'text' THEN a.text IS NULL OR a.rating IS NOT NULL
+			ELSE a.rating IS NOT NULL OR a.text IS NOT NULL OR (
+				SELECT COUNT(*) FROM ` + table + ` ao
+				WHERE ao.vote_id = a.vote_id AND ao.user_token = a.user_token AND ao.` + parent + ` = a.` + parent + `
+			) NOT BETWEEN 1 AND CASE q.kind WHEN 'single' THEN 1 ELSE 2147483647 END
+		END`
+}
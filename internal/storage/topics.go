@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxVoteTopics bounds the topics of one vote; maxTopicSlug and
+// maxTopicName bound a topic.
+const (
+	maxVoteTopics = 5
+	maxTopicSlug  = 50
+	maxTopicName  = 100
+)
+
+// topicSlugPattern matches the slugs accepted by the topics table.
+var topicSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Topic is a subject votes are about, such as transport or culture. Votes
+// refer to topics by slug. Votes counts the public votes of the topic; it
+// is only filled in when reading.
+type Topic struct {
+	ID    int
+	Slug  string
+	Name  string
+	Votes int
+}
+
+// DefaultTopics is the taxonomy a new database starts with. Migration 0017
+// inserts the same topics.
+var DefaultTopics = []Topic{
+	{Slug: "transport", Name: "Транспорт"},
+	{Slug: "culture", Name: "Культура"},
+	{Slug: "youth", Name: "Молодежь"},
+	{Slug: "tourism", Name: "Туризм"},
+	{Slug: "ecology", Name: "Экология"},
+	{Slug: "urban", Name: "Благоустройство"},
+	{Slug: "education", Name: "Образование"},
+	{Slug: "health", Name: "Здравоохранение"},
+	{Slug: "sport", Name: "Спорт"},
+}
+
+// ValidateTopic checks a topic before it is stored and trims its name.
+func ValidateTopic(topic *Topic) error {
+	topic.Name = strings.TrimSpace(topic.Name)
+	if err := validateTopicSlug(topic.Slug); err != nil {
+		return err
+	}
+	if topic.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(topic.Name) > maxTopicName {
+		return fmt.Errorf("name is longer than %d characters", maxTopicName)
+	}
+	return nil
+}
+
+func validateTopicSlug(slug string) error {
+	if len(slug) > maxTopicSlug || !topicSlugPattern.MatchString(slug) {
+		return fmt.Errorf("topic %q must be a lowercase slug of at most %d characters", slug, maxTopicSlug)
+	}
+	return nil
+}
+
+// validateVoteTopics checks the topic slugs of a vote and sorts them. It
+// does not check that the topics exist; storage reports unknown topics with
+// ErrTopicNotFound.
+func validateVoteTopics(topics []string) error {
+	if len(topics) > maxVoteTopics {
+		return fmt.Errorf("a vote has at most %d topics", maxVoteTopics)
+	}
+	for i, slug := range topics {
+		if err := validateTopicSlug(slug); err != nil {
+			return err
+		}
+		if contains(topics[:i], slug) {
+			return fmt.Errorf("duplicate topic %q", slug)
+		}
+	}
+	sort.Strings(topics)
+	return nil
+}
+
+// ListTopics returns every topic with the number of its public votes,
+// ordered by name.
+func (s *PostgresStorage) ListTopics(ctx context.Context) ([]Topic, error) {
+	const op = "storage.postgresql.ListTopics"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT t.id, t.slug, t.name, COUNT(v.id) FILTER (WHERE v.status = ANY($1))
+		FROM topics t
+		LEFT JOIN vote_topics vt ON vt.topic_id = t.id
+		LEFT JOIN votes v ON v.id = vt.vote_id
+		GROUP BY t.id
+		ORDER BY t.name, t.id`, PublicStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	topics := []Topic{}
+	for rows.Next() {
+		var topic Topic
+		if err := rows.Scan(&topic.ID, &topic.Slug, &topic.Name, &topic.Votes); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		topics = append(topics, topic)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return topics, nil
+}
+
+// CreateTopic adds a topic to the taxonomy. A duplicate slug is refused
+// with ErrConflict.
+func (s *PostgresStorage) CreateTopic(ctx context.Context, topic *Topic) (*Topic, error) {
+	const op = "storage.postgresql.CreateTopic"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	created := *topic
+	created.Votes = 0
+	err = tx.QueryRow(ctx, `INSERT INTO topics (slug, name) VALUES ($1, $2) RETURNING id`, topic.Slug, topic.Name).
+		Scan(&created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, classifyError(err))
+	}
+	if err := s.auditChange(ctx, tx, "topic.create", auditTopic, created.Slug, nil, &created); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &created, nil
+}
+
+// UpdateTopic renames the topic with the slug of topic. Slugs never change
+// because clients keep them in links and filters.
+func (s *PostgresStorage) UpdateTopic(ctx context.Context, topic *Topic) (*Topic, error) {
+	const op = "storage.postgresql.UpdateTopic"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var previousName string
+	err = tx.QueryRow(ctx, `SELECT name FROM topics WHERE slug = $1 FOR UPDATE`, topic.Slug).Scan(&previousName)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: topic %q: %w", op, topic.Slug, ErrTopicNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	updated := *topic
+	err = tx.QueryRow(ctx, `
+		UPDATE topics t SET name = $2
+		WHERE t.slug = $1
+		RETURNING t.id, (
+			SELECT COUNT(*) FROM vote_topics vt JOIN votes v ON v.id = vt.vote_id
+			WHERE vt.topic_id = t.id AND v.status = ANY($3)
+		)`, topic.Slug, topic.Name, PublicStatuses).Scan(&updated.ID, &updated.Votes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	previous := updated
+	previous.Name = previousName
+	if err := s.auditChange(ctx, tx, "topic.update", auditTopic, topic.Slug, &previous, &updated); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &updated, nil
+}
+
+// getVoteTopics returns the topic slugs of a vote in alphabetical order.
+func (s *PostgresStorage) getVoteTopics(ctx context.Context, voteId int) ([]string, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT t.slug
+		FROM vote_topics vt
+		JOIN topics t ON t.id = vt.topic_id
+		WHERE vt.vote_id = $1
+		ORDER BY t.slug`, voteId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topics := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		topics = append(topics, slug)
+	}
+	return topics, rows.Err()
+}
+
+// replaceTopics sets the topics of a vote to the given slugs.
+func replaceTopics(ctx context.Context, tx pgx.Tx, voteId int, slugs []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM vote_topics WHERE vote_id = $1`, voteId); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(ctx, `
+		INSERT INTO vote_topics (vote_id, topic_id)
+		SELECT $1, id FROM topics WHERE slug = ANY($2)
+		RETURNING (SELECT slug FROM topics WHERE id = topic_id)`, voteId, slugs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var found []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return err
+		}
+		found = append(found, slug)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return checkTopicsFound(slugs, found)
+}
+
+// checkTopicsFound reports the first of slugs missing from found.
+func checkTopicsFound(slugs, found []string) error {
+	for _, slug := range slugs {
+		if !contains(found, slug) {
+			return fmt.Errorf("topic %q: %w", slug, ErrTopicNotFound)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,553 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var Categories = []string{"choice", "petition", "rate", "ranked", "score", "quadratic", "survey"}
+
+// optionCategories are the vote types whose ballots refer to options.
+var optionCategories = []string{"choice", "ranked", "score", "quadratic"}
+
+// budgetCategories are the vote types whose ballots spend a budget across
+// the options: points in score votes, credits in quadratic votes.
+var budgetCategories = []string{"score", "quadratic"}
+
+// maxSurveyQuestions, maxFollowUps and maxAnswerText bound the size of a
+// survey, the follow-ups of a vote and a free-text answer.
+const (
+	maxSurveyQuestions = 50
+	maxFollowUps       = 10
+	maxAnswerText      = 2000
+)
+
+// PetitionSupport is the closed vocabulary accepted by VotePetition.
+var PetitionSupport = []string{"for", "against"}
+
+// ValidateVote checks a vote definition before it is stored. A vote without
+// a scale gets DefaultRateScale and one without a selection range gets
+// DefaultSelection. A status, if set, must be one of
+// InitialStatuses.
+func ValidateVote(vote *Vote, now time.Time) error {
+	if strings.TrimSpace(vote.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if !isKnownCategory(vote.Category) {
+		return fmt.Errorf("unknown category %q, expected one of %s", vote.Category, strings.Join(Categories, ", "))
+	}
+
+	if UsesOptions(vote.Category) {
+		if len(vote.Options) < 2 {
+			return fmt.Errorf("%s vote requires at least two options", vote.Category)
+		}
+		if err := validateOptions(vote.Options); err != nil {
+			return err
+		}
+	} else if len(vote.Options) != 0 {
+		return fmt.Errorf("%s vote must not have options", vote.Category)
+	}
+
+	if vote.Category == "survey" {
+		if err := validateQuestions(vote.Questions); err != nil {
+			return err
+		}
+	} else if len(vote.Questions) != 0 {
+		return fmt.Errorf("%s vote must not have questions", vote.Category)
+	}
+
+	if vote.Scale == (RateScale{}) {
+		vote.Scale = DefaultRateScale
+	}
+	if err := validateScale(vote.Scale); err != nil {
+		return err
+	}
+
+	if vote.Selection == (SelectionRange{}) {
+		vote.Selection = DefaultSelection
+	}
+	if vote.Selection != DefaultSelection && vote.Category != "choice" {
+		return fmt.Errorf("%s vote does not take a selection range", vote.Category)
+	}
+	if vote.Selection.Min < 1 || vote.Selection.Min > vote.Selection.Max {
+		return errors.New("selection minimum must be at least one and not above the maximum")
+	}
+	if vote.Category == "choice" && vote.Selection.Max > len(vote.Options) {
+		return fmt.Errorf("selection maximum %d exceeds the %d options", vote.Selection.Max, len(vote.Options))
+	}
+
+	if contains(followUpCategories, vote.Category) {
+		if err := validateFollowUps(vote); err != nil {
+			return err
+		}
+	} else if len(vote.FollowUps) != 0 {
+		return fmt.Errorf("%s vote does not take follow-ups", vote.Category)
+	}
+
+	if contains(budgetCategories, vote.Category) {
+		if vote.Budget <= 0 {
+			return fmt.Errorf("%s vote requires a positive budget", vote.Category)
+		}
+	} else if vote.Budget != 0 {
+		return fmt.Errorf("%s vote does not take a budget", vote.Category)
+	}
+
+	if vote.SignatureGoal < 0 {
+		return errors.New("signature goal must not be negative")
+	}
+	if vote.SignatureGoal != 0 && vote.Category != "petition" {
+		return fmt.Errorf("%s vote does not take a signature goal", vote.Category)
+	}
+
+	if vote.Photo != "" && !isHTTPURL(vote.Photo) {
+		return fmt.Errorf("photo %q is not a valid http(s) URL", vote.Photo)
+	}
+
+	if err := validateVoteTopics(vote.Topics); err != nil {
+		return err
+	}
+
+	if vote.Status != "" && !contains(InitialStatuses, vote.Status) {
+		return fmt.Errorf("status must be one of %s", strings.Join(InitialStatuses, ", "))
+	}
+	if vote.Status == StatusScheduled && (vote.StartTime.IsZero() || !now.Before(vote.StartTime)) {
+		return errors.New("scheduled vote requires a start time in the future")
+	}
+
+	if vote.EndTime.IsZero() {
+		return errors.New("end time is required")
+	}
+	if !vote.EndTime.After(now) {
+		return errors.New("end time must be in the future")
+	}
+	if !vote.StartTime.IsZero() && !vote.StartTime.Before(vote.EndTime) {
+		return errors.New("start time must be before end time")
+	}
+
+	return nil
+}
+
+func validateOptions(options []VoteOption) error {
+	seen := make(map[string]struct{}, len(options))
+	seenIDs := make(map[int]struct{}, len(options))
+	for _, option := range options {
+		text := strings.TrimSpace(option.Text)
+		if text == "" {
+			return errors.New("options must not be empty")
+		}
+		if len(text) > 255 {
+			return fmt.Errorf("option %q is longer than 255 bytes", text)
+		}
+		if _, ok := seen[text]; ok {
+			return fmt.Errorf("duplicate option %q", text)
+		}
+		seen[text] = struct{}{}
+		if option.ID != 0 {
+			if _, ok := seenIDs[option.ID]; ok {
+				return fmt.Errorf("duplicate option id %d", option.ID)
+			}
+			seenIDs[option.ID] = struct{}{}
+		}
+		if option.Image != "" && !isHTTPURL(option.Image) {
+			return fmt.Errorf("image of option %q is not a valid http(s) URL", text)
+		}
+	}
+	return nil
+}
+
+// validateQuestions checks the questions of a survey. Rating questions
+// without a scale get DefaultRateScale; the other kinds always store it.
+func validateQuestions(questions []Question) error {
+	if len(questions) == 0 {
+		return errors.New("survey vote requires at least one question")
+	}
+	if len(questions) > maxSurveyQuestions {
+		return fmt.Errorf("survey vote has more than %d questions", maxSurveyQuestions)
+	}
+	seen := make(map[string]struct{}, len(questions))
+	seenIDs := make(map[int]struct{}, len(questions))
+	for i := range questions {
+		question := &questions[i]
+		text := strings.TrimSpace(question.Text)
+		if text == "" {
+			return errors.New("questions must not be empty")
+		}
+		if _, ok := seen[text]; ok {
+			return fmt.Errorf("duplicate question %q", text)
+		}
+		seen[text] = struct{}{}
+		if question.ID != 0 {
+			if _, ok := seenIDs[question.ID]; ok {
+				return fmt.Errorf("duplicate question id %d", question.ID)
+			}
+			seenIDs[question.ID] = struct{}{}
+		}
+
+		if err := validateQuestion(question.Kind, text, question.Options, &question.Scale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateQuestion checks the kind, options and scale shared by survey
+// questions and follow-ups.
+func validateQuestion(kind, text string, options []VoteOption, scale *RateScale) error {
+	switch kind {
+	case QuestionSingle, QuestionMulti:
+		if len(options) < 2 {
+			return fmt.Errorf("question %q requires at least two options", text)
+		}
+		if err := validateOptions(options); err != nil {
+			return fmt.Errorf("question %q: %w", text, err)
+		}
+	case QuestionRating, QuestionText:
+		if len(options) != 0 {
+			return fmt.Errorf("%s question %q must not have options", kind, text)
+		}
+	default:
+		return fmt.Errorf("question %q has unknown kind %q, expected one of %s", text, kind, strings.Join(QuestionKinds, ", "))
+	}
+
+	if kind != QuestionRating || *scale == (RateScale{}) {
+		*scale = DefaultRateScale
+	}
+	if err := validateScale(*scale); err != nil {
+		return fmt.Errorf("question %q: %w", text, err)
+	}
+	return nil
+}
+
+// validateFollowUps checks the follow-ups of a rate or choice vote, whose
+// scale and options must already be valid. A rate vote triggers on a
+// rating range within its scale, a choice vote on options of the vote,
+// given by ID or by text.
+func validateFollowUps(vote *Vote) error {
+	if len(vote.FollowUps) > maxFollowUps {
+		return fmt.Errorf("vote has more than %d follow-ups", maxFollowUps)
+	}
+	seen := make(map[string]struct{}, len(vote.FollowUps))
+	for i := range vote.FollowUps {
+		followUp := &vote.FollowUps[i]
+		text := strings.TrimSpace(followUp.Text)
+		if text == "" {
+			return errors.New("follow-ups must not be empty")
+		}
+		if _, ok := seen[text]; ok {
+			return fmt.Errorf("duplicate follow-up %q", text)
+		}
+		seen[text] = struct{}{}
+		if err := validateQuestion(followUp.Kind, text, followUp.Options, &followUp.Scale); err != nil {
+			return err
+		}
+
+		trigger := followUp.Trigger
+		if vote.Category == "rate" {
+			if len(trigger.OptionIDs) != 0 || len(trigger.Options) != 0 {
+				return fmt.Errorf("follow-up %q of a rate vote must trigger on ratings, not options", text)
+			}
+			if trigger.MinRating > trigger.MaxRating || trigger.MinRating < vote.Scale.Min || trigger.MaxRating > vote.Scale.Max {
+				return fmt.Errorf("follow-up %q must trigger on a rating range within %d..%d", text, vote.Scale.Min, vote.Scale.Max)
+			}
+			continue
+		}
+		if trigger.MinRating != 0 || trigger.MaxRating != 0 {
+			return fmt.Errorf("follow-up %q of a choice vote must trigger on options, not ratings", text)
+		}
+		if len(trigger.OptionIDs) == 0 && len(trigger.Options) == 0 {
+			return fmt.Errorf("follow-up %q requires at least one trigger option", text)
+		}
+		for _, id := range trigger.OptionIDs {
+			if !hasOptionID(vote.Options, id) {
+				return fmt.Errorf("follow-up %q triggers on %d, which is not an option of the vote", text, id)
+			}
+		}
+		for _, option := range trigger.Options {
+			if !contains(OptionTexts(vote.Options), option) {
+				return fmt.Errorf("follow-up %q triggers on %q, which is not an option of the vote", text, option)
+			}
+		}
+	}
+	return nil
+}
+
+func validateScale(scale RateScale) error {
+	if scale.Step <= 0 {
+		return errors.New("rate scale step must be positive")
+	}
+	if scale.Min >= scale.Max {
+		return errors.New("rate scale minimum must be below its maximum")
+	}
+	if (scale.Max-scale.Min)%scale.Step != 0 {
+		return errors.New("rate scale range must be a multiple of its step")
+	}
+	return nil
+}
+
+func ValidateRating(vote *Vote, rating int) error {
+	if err := checkCategory(vote.ID, vote.Category, "rate"); err != nil {
+		return err
+	}
+	scale := vote.Scale
+	if rating < scale.Min || rating > scale.Max || (rating-scale.Min)%scale.Step != 0 {
+		return fmt.Errorf("%w: rating %d is not on the %d..%d scale with step %d", ErrInvalidBallot, rating, scale.Min, scale.Max, scale.Step)
+	}
+	return nil
+}
+
+func ValidateSupport(support string) error {
+	if !contains(PetitionSupport, support) {
+		return fmt.Errorf("%w: support must be one of %s", ErrInvalidBallot, strings.Join(PetitionSupport, ", "))
+	}
+	return nil
+}
+
+// ValidateChoice checks that a choice ballot selects distinct options of the
+// vote and respects its selection range.
+func ValidateChoice(vote *Vote, optionIds []int) error {
+	if err := checkCategory(vote.ID, vote.Category, "choice"); err != nil {
+		return err
+	}
+	seen := make(map[int]struct{}, len(optionIds))
+	for _, id := range optionIds {
+		if !hasOptionID(vote.Options, id) {
+			return fmt.Errorf("%w: %d is not an option of vote %d", ErrInvalidOption, id, vote.ID)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: option %d is selected more than once", ErrInvalidBallot, id)
+		}
+		seen[id] = struct{}{}
+	}
+	if n := len(optionIds); n < vote.Selection.Min || n > vote.Selection.Max {
+		return fmt.Errorf("%w: select between %d and %d options, got %d", ErrInvalidBallot, vote.Selection.Min, vote.Selection.Max, n)
+	}
+	return nil
+}
+
+// ValidateRanking checks a ranked ballot: option IDs from most to least
+// preferred, each ranked at most once. Not every option has to be ranked.
+func ValidateRanking(vote *Vote, optionIds []int) error {
+	if err := checkCategory(vote.ID, vote.Category, "ranked"); err != nil {
+		return err
+	}
+	if len(optionIds) == 0 {
+		return fmt.Errorf("%w: ranking must contain at least one option", ErrInvalidBallot)
+	}
+	seen := make(map[int]struct{}, len(optionIds))
+	for _, id := range optionIds {
+		if !hasOptionID(vote.Options, id) {
+			return fmt.Errorf("%w: %d is not an option of vote %d", ErrInvalidOption, id, vote.ID)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: option %d is ranked more than once", ErrInvalidBallot, id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateAllocation checks a score or quadratic ballot: positive amounts on
+// distinct options of the vote whose cost stays within the vote budget.
+func ValidateAllocation(vote *Vote, allocations []Allocation) error {
+	if err := checkCategory(vote.ID, vote.Category, budgetCategories...); err != nil {
+		return err
+	}
+	if len(allocations) == 0 {
+		return fmt.Errorf("%w: allocate to at least one option", ErrInvalidBallot)
+	}
+	seen := make(map[int]struct{}, len(allocations))
+	for _, allocation := range allocations {
+		if !hasOptionID(vote.Options, allocation.OptionID) {
+			return fmt.Errorf("%w: %d is not an option of vote %d", ErrInvalidOption, allocation.OptionID, vote.ID)
+		}
+		if _, ok := seen[allocation.OptionID]; ok {
+			return fmt.Errorf("%w: option %d is allocated more than once", ErrInvalidBallot, allocation.OptionID)
+		}
+		seen[allocation.OptionID] = struct{}{}
+		if allocation.Points <= 0 {
+			return fmt.Errorf("%w: allocation to option %d must be positive", ErrInvalidBallot, allocation.OptionID)
+		}
+	}
+	if cost := AllocationCost(vote.Category, allocations); cost > vote.Budget {
+		return fmt.Errorf("%w: ballot costs %d, the budget is %d", ErrInvalidBallot, cost, vote.Budget)
+	}
+	return nil
+}
+
+// ValidateSurvey checks a survey response: at most one answer per question
+// of the survey, each matching the kind of its question. A draft may leave
+// out required questions, a submitted response may not.
+func ValidateSurvey(vote *Vote, answers []SurveyAnswer, draft bool) error {
+	if err := checkCategory(vote.ID, vote.Category, "survey"); err != nil {
+		return err
+	}
+	answered := make(map[int]struct{}, len(answers))
+	for _, answer := range answers {
+		question, ok := findQuestion(vote.Questions, answer.QuestionID)
+		if !ok {
+			return fmt.Errorf("%w: %d is not a question of vote %d", ErrInvalidQuestion, answer.QuestionID, vote.ID)
+		}
+		if _, ok := answered[question.ID]; ok {
+			return fmt.Errorf("%w: question %d is answered more than once", ErrInvalidBallot, question.ID)
+		}
+		answered[question.ID] = struct{}{}
+		if err := validateAnswer(question, answer); err != nil {
+			return err
+		}
+	}
+	if draft {
+		return nil
+	}
+	for _, question := range vote.Questions {
+		if _, ok := answered[question.ID]; question.Required && !ok {
+			return fmt.Errorf("%w: question %d is required", ErrInvalidBallot, question.ID)
+		}
+	}
+	return nil
+}
+
+// ValidateFollowUpAnswers checks answers to follow-ups of a rate or choice
+// vote. Whether the ballot triggers them is checked when they are stored.
+func ValidateFollowUpAnswers(vote *Vote, answers []SurveyAnswer) error {
+	if err := checkCategory(vote.ID, vote.Category, followUpCategories...); err != nil {
+		return err
+	}
+	if len(answers) == 0 {
+		return fmt.Errorf("%w: answer at least one follow-up", ErrInvalidBallot)
+	}
+	questions := followUpQuestions(vote.FollowUps)
+	answered := make(map[int]struct{}, len(answers))
+	for _, answer := range answers {
+		question, ok := findQuestion(questions, answer.QuestionID)
+		if !ok {
+			return fmt.Errorf("%w: %d is not a follow-up of vote %d", ErrInvalidQuestion, answer.QuestionID, vote.ID)
+		}
+		if _, ok := answered[question.ID]; ok {
+			return fmt.Errorf("%w: follow-up %d is answered more than once", ErrInvalidBallot, question.ID)
+		}
+		answered[question.ID] = struct{}{}
+		if err := validateAnswer(question, answer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAnswer(question Question, answer SurveyAnswer) error {
+	switch question.Kind {
+	case QuestionRating:
+		if answer.Rating == nil || len(answer.OptionIDs) != 0 || answer.Text != "" {
+			return fmt.Errorf("%w: question %d takes a rating", ErrInvalidBallot, question.ID)
+		}
+		scale := question.Scale
+		if rating := *answer.Rating; rating < scale.Min || rating > scale.Max || (rating-scale.Min)%scale.Step != 0 {
+			return fmt.Errorf("%w: rating %d is not on the %d..%d scale with step %d", ErrInvalidBallot, rating, scale.Min, scale.Max, scale.Step)
+		}
+	case QuestionSingle, QuestionMulti:
+		if answer.Rating != nil || answer.Text != "" || len(answer.OptionIDs) == 0 {
+			return fmt.Errorf("%w: question %d takes options", ErrInvalidBallot, question.ID)
+		}
+		if question.Kind == QuestionSingle && len(answer.OptionIDs) != 1 {
+			return fmt.Errorf("%w: question %d takes exactly one option", ErrInvalidBallot, question.ID)
+		}
+		seen := make(map[int]struct{}, len(answer.OptionIDs))
+		for _, id := range answer.OptionIDs {
+			if !hasOptionID(question.Options, id) {
+				return fmt.Errorf("%w: %d is not an option of question %d", ErrInvalidOption, id, question.ID)
+			}
+			if _, ok := seen[id]; ok {
+				return fmt.Errorf("%w: option %d is selected more than once", ErrInvalidBallot, id)
+			}
+			seen[id] = struct{}{}
+		}
+	case QuestionText:
+		if answer.Rating != nil || len(answer.OptionIDs) != 0 || strings.TrimSpace(answer.Text) == "" {
+			return fmt.Errorf("%w: question %d takes a text", ErrInvalidBallot, question.ID)
+		}
+		if n := utf8.RuneCountInString(answer.Text); n > maxAnswerText {
+			return fmt.Errorf("%w: answer to question %d is longer than %d characters", ErrInvalidBallot, question.ID, maxAnswerText)
+		}
+	}
+	return nil
+}
+
+// FindOption resolves a single-option ballot given either as an option ID
+// or, for older clients, as the option text.
+func FindOption(vote *Vote, optionId int, text string) (int, error) {
+	if err := checkCategory(vote.ID, vote.Category, "choice"); err != nil {
+		return 0, err
+	}
+	if optionId != 0 {
+		if !hasOptionID(vote.Options, optionId) {
+			return 0, fmt.Errorf("%w: %d is not an option of vote %d", ErrInvalidOption, optionId, vote.ID)
+		}
+		return optionId, nil
+	}
+	if id := optionIDByText(vote.Options, text); id != 0 {
+		return id, nil
+	}
+	return 0, fmt.Errorf("%w: %q is not an option of vote %d", ErrInvalidOption, text, vote.ID)
+}
+
+// OptionTexts returns the option texts in display order.
+func OptionTexts(options []VoteOption) []string {
+	texts := make([]string, 0, len(options))
+	for _, option := range options {
+		texts = append(texts, option.Text)
+	}
+	return texts
+}
+
+func hasOptionID(options []VoteOption, id int) bool {
+	for _, option := range options {
+		if option.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func optionIDByText(options []VoteOption, text string) int {
+	for _, option := range options {
+		if option.Text == text {
+			return option.ID
+		}
+	}
+	return 0
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func UsesOptions(category string) bool {
+	return contains(optionCategories, category)
+}
+
+func isKnownCategory(category string) bool {
+	return contains(Categories, category)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,476 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var validateNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// validVote returns a vote of category that ValidateVote accepts.
+func validVote(category string) Vote {
+	vote := Vote{Name: "Vote", Category: category, EndTime: validateNow.Add(24 * time.Hour)}
+	switch category {
+	case "choice", "ranked", "score", "quadratic":
+		vote.Options = []VoteOption{{Text: "A"}, {Text: "B"}}
+	case "survey":
+		vote.Questions = []Question{{Kind: QuestionRating, Text: "How?"}}
+	}
+	if contains(budgetCategories, category) {
+		vote.Budget = 10
+	}
+	return vote
+}
+
+func numberedQuestions(n int) []Question {
+	questions := make([]Question, n)
+	for i := range questions {
+		questions[i] = Question{Kind: QuestionText, Text: fmt.Sprintf("Question %d", i+1)}
+	}
+	return questions
+}
+
+func numberedFollowUps(n int) []FollowUp {
+	followUps := make([]FollowUp, n)
+	for i := range followUps {
+		followUps[i] = FollowUp{Kind: QuestionText, Text: fmt.Sprintf("Why %d?", i+1), Trigger: FollowUpTrigger{MinRating: 1, MaxRating: 5}}
+	}
+	return followUps
+}
+
+func TestValidateVote(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		edit     func(*Vote)
+		err      string
+	}{
+		{"rate", "rate", nil, ""},
+		{"choice", "choice", nil, ""},
+		{"ranked", "ranked", nil, ""},
+		{"score", "score", nil, ""},
+		{"quadratic", "quadratic", nil, ""},
+		{"survey", "survey", nil, ""},
+		{"petition", "petition", nil, ""},
+		{"no name", "rate", func(v *Vote) { v.Name = " " }, "name is required"},
+		{"unknown category", "rate", func(v *Vote) { v.Category = "poll" }, "unknown category"},
+
+		{"one option", "choice", func(v *Vote) { v.Options = v.Options[:1] }, "requires at least two options"},
+		{"empty option", "choice", func(v *Vote) { v.Options[1].Text = " " }, "options must not be empty"},
+		{"option of 255 bytes", "choice", func(v *Vote) { v.Options[1].Text = strings.Repeat("x", 255) }, ""},
+		{"option of 256 bytes", "choice", func(v *Vote) { v.Options[1].Text = strings.Repeat("x", 256) }, "longer than 255 bytes"},
+		{"duplicate option", "choice", func(v *Vote) { v.Options[1].Text = " A " }, `duplicate option "A"`},
+		{"duplicate option id", "choice", func(v *Vote) { v.Options[0].ID, v.Options[1].ID = 7, 7 }, "duplicate option id 7"},
+		{"option image", "choice", func(v *Vote) { v.Options[0].Image = "ftp://example.com/a.png" }, "is not a valid http(s) URL"},
+		{"options of a rate vote", "rate", func(v *Vote) { v.Options = []VoteOption{{Text: "A"}} }, "must not have options"},
+
+		{"no questions", "survey", func(v *Vote) { v.Questions = nil }, "requires at least one question"},
+		{"50 questions", "survey", func(v *Vote) { v.Questions = numberedQuestions(50) }, ""},
+		{"51 questions", "survey", func(v *Vote) { v.Questions = numberedQuestions(51) }, "more than 50 questions"},
+		{"empty question", "survey", func(v *Vote) { v.Questions[0].Text = "" }, "questions must not be empty"},
+		{"duplicate question", "survey", func(v *Vote) { v.Questions = append(v.Questions, v.Questions[0]) }, `duplicate question "How?"`},
+		{"duplicate question id", "survey", func(v *Vote) {
+			v.Questions = numberedQuestions(2)
+			v.Questions[0].ID, v.Questions[1].ID = 3, 3
+		}, "duplicate question id 3"},
+		{"unknown question kind", "survey", func(v *Vote) { v.Questions[0].Kind = "slider" }, "unknown kind"},
+		{"single question with one option", "survey", func(v *Vote) {
+			v.Questions[0] = Question{Kind: QuestionSingle, Text: "Which?", Options: []VoteOption{{Text: "A"}}}
+		}, "requires at least two options"},
+		{"question with duplicate options", "survey", func(v *Vote) {
+			v.Questions[0] = Question{Kind: QuestionMulti, Text: "Which?", Options: []VoteOption{{Text: "A"}, {Text: "A"}}}
+		}, `question "Which?": duplicate option`},
+		{"rating question with options", "survey", func(v *Vote) { v.Questions[0].Options = []VoteOption{{Text: "A"}} }, "must not have options"},
+		{"question scale", "survey", func(v *Vote) { v.Questions[0].Scale = RateScale{Min: 1, Max: 4, Step: 2} }, `question "How?": rate scale range`},
+		{"questions of a rate vote", "rate", func(v *Vote) { v.Questions = numberedQuestions(1) }, "must not have questions"},
+
+		{"custom scale", "rate", func(v *Vote) { v.Scale = RateScale{Min: -10, Max: 10, Step: 5} }, ""},
+		{"single step scale", "rate", func(v *Vote) { v.Scale = RateScale{Min: 0, Max: 1, Step: 1} }, ""},
+		{"zero step", "rate", func(v *Vote) { v.Scale = RateScale{Min: 1, Max: 5} }, "step must be positive"},
+		{"negative step", "rate", func(v *Vote) { v.Scale = RateScale{Min: 1, Max: 5, Step: -1} }, "step must be positive"},
+		{"empty scale", "rate", func(v *Vote) { v.Scale = RateScale{Min: 3, Max: 3, Step: 1} }, "minimum must be below its maximum"},
+		{"inverted scale", "rate", func(v *Vote) { v.Scale = RateScale{Min: 5, Max: 1, Step: 1} }, "minimum must be below its maximum"},
+		{"step off the range", "rate", func(v *Vote) { v.Scale = RateScale{Min: 1, Max: 10, Step: 2} }, "multiple of its step"},
+
+		{"selection of all options", "choice", func(v *Vote) { v.Selection = SelectionRange{Min: 1, Max: 2} }, ""},
+		{"selection of a rate vote", "rate", func(v *Vote) { v.Selection = SelectionRange{Min: 1, Max: 2} }, "does not take a selection range"},
+		{"selection minimum zero", "choice", func(v *Vote) { v.Selection = SelectionRange{Min: 0, Max: 2} }, "selection minimum"},
+		{"inverted selection", "choice", func(v *Vote) { v.Selection = SelectionRange{Min: 2, Max: 1} }, "selection minimum"},
+		{"selection above options", "choice", func(v *Vote) { v.Selection = SelectionRange{Min: 1, Max: 3} }, "exceeds the 2 options"},
+
+		{"10 follow-ups", "rate", func(v *Vote) { v.FollowUps = numberedFollowUps(10) }, ""},
+		{"11 follow-ups", "rate", func(v *Vote) { v.FollowUps = numberedFollowUps(11) }, "more than 10 follow-ups"},
+		{"follow-ups of a petition", "petition", func(v *Vote) { v.FollowUps = numberedFollowUps(1) }, "does not take follow-ups"},
+		{"empty follow-up", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Text = " "
+		}, "follow-ups must not be empty"},
+		{"duplicate follow-up", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(2)
+			v.FollowUps[1].Text = v.FollowUps[0].Text
+		}, "duplicate follow-up"},
+		{"follow-up kind", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Kind = "slider"
+		}, "unknown kind"},
+		{"rate follow-up on options", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Trigger.Options = []string{"A"}
+		}, "must trigger on ratings, not options"},
+		{"rate follow-up below the scale", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Trigger.MinRating = 0
+		}, "rating range within 1..5"},
+		{"rate follow-up above the scale", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Trigger.MaxRating = 6
+		}, "rating range within 1..5"},
+		{"inverted rate follow-up", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Trigger = FollowUpTrigger{MinRating: 4, MaxRating: 2}
+		}, "rating range within 1..5"},
+		{"rate follow-up on one rating", "rate", func(v *Vote) {
+			v.FollowUps = numberedFollowUps(1)
+			v.FollowUps[0].Trigger = FollowUpTrigger{MinRating: 5, MaxRating: 5}
+		}, ""},
+		{"choice follow-up by text", "choice", func(v *Vote) {
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{Options: []string{"B"}}}}
+		}, ""},
+		{"choice follow-up by id", "choice", func(v *Vote) {
+			v.Options[0].ID = 4
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{OptionIDs: []int{4}}}}
+		}, ""},
+		{"choice follow-up on ratings", "choice", func(v *Vote) {
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{MinRating: 1, MaxRating: 5}}}
+		}, "must trigger on options, not ratings"},
+		{"choice follow-up without trigger", "choice", func(v *Vote) {
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?"}}
+		}, "requires at least one trigger option"},
+		{"choice follow-up on unknown id", "choice", func(v *Vote) {
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{OptionIDs: []int{9}}}}
+		}, "triggers on 9, which is not an option"},
+		{"choice follow-up on unknown text", "choice", func(v *Vote) {
+			v.FollowUps = []FollowUp{{Kind: QuestionText, Text: "Why?", Trigger: FollowUpTrigger{Options: []string{"C"}}}}
+		}, `triggers on "C", which is not an option`},
+
+		{"budget of one", "score", func(v *Vote) { v.Budget = 1 }, ""},
+		{"no budget", "score", func(v *Vote) { v.Budget = 0 }, "requires a positive budget"},
+		{"negative budget", "quadratic", func(v *Vote) { v.Budget = -4 }, "requires a positive budget"},
+		{"budget of a choice vote", "choice", func(v *Vote) { v.Budget = 10 }, "does not take a budget"},
+
+		{"signature goal", "petition", func(v *Vote) { v.SignatureGoal = 1 }, ""},
+		{"negative signature goal", "petition", func(v *Vote) { v.SignatureGoal = -1 }, "must not be negative"},
+		{"signature goal of a rate vote", "rate", func(v *Vote) { v.SignatureGoal = 10 }, "does not take a signature goal"},
+
+		{"photo", "rate", func(v *Vote) { v.Photo = "https://example.com/p.png" }, ""},
+		{"relative photo", "rate", func(v *Vote) { v.Photo = "/p.png" }, "is not a valid http(s) URL"},
+		{"duplicate topic", "rate", func(v *Vote) { v.Topics = []string{"urban", "urban"} }, `duplicate topic "urban"`},
+		{"topic slug", "rate", func(v *Vote) { v.Topics = []string{"Urban"} }, "must be a lowercase slug"},
+
+		{"scheduled", "rate", func(v *Vote) {
+			v.Status = StatusScheduled
+			v.StartTime = validateNow.Add(time.Hour)
+		}, ""},
+		{"created closed", "rate", func(v *Vote) { v.Status = StatusClosed }, "status must be one of"},
+		{"scheduled without start", "rate", func(v *Vote) { v.Status = StatusScheduled }, "requires a start time in the future"},
+		{"scheduled in the past", "rate", func(v *Vote) {
+			v.Status = StatusScheduled
+			v.StartTime = validateNow
+		}, "requires a start time in the future"},
+		{"no end time", "rate", func(v *Vote) { v.EndTime = time.Time{} }, "end time is required"},
+		{"ends now", "rate", func(v *Vote) { v.EndTime = validateNow }, "must be in the future"},
+		{"ends in a moment", "rate", func(v *Vote) { v.EndTime = validateNow.Add(time.Nanosecond) }, ""},
+		{"starts at the end", "rate", func(v *Vote) { v.StartTime = v.EndTime }, "start time must be before end time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vote := validVote(tt.category)
+			if tt.edit != nil {
+				tt.edit(&vote)
+			}
+			err := ValidateVote(&vote, validateNow)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Fatalf("accepted, want an error containing %q", tt.err)
+			case tt.err != "" && !strings.Contains(err.Error(), tt.err):
+				t.Fatalf("got %q, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateVoteDefaults(t *testing.T) {
+	vote := validVote("survey")
+	vote.Questions = append(vote.Questions, Question{Kind: QuestionText, Text: "Why?", Scale: RateScale{Min: 0, Max: 9, Step: 3}})
+	if err := ValidateVote(&vote, validateNow); err != nil {
+		t.Fatal(err)
+	}
+	if vote.Scale != DefaultRateScale || vote.Selection != DefaultSelection {
+		t.Errorf("got scale %v and selection %v, want the defaults", vote.Scale, vote.Selection)
+	}
+	for _, question := range vote.Questions {
+		if question.Scale != DefaultRateScale {
+			t.Errorf("question %q has scale %v, want the default", question.Text, question.Scale)
+		}
+	}
+}
+
+func checkValidation(t *testing.T, err, want error) {
+	t.Helper()
+	switch {
+	case want == nil && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case want != nil && !errors.Is(err, want):
+		t.Fatalf("got %v, want %v", err, want)
+	}
+}
+
+func TestValidateRating(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "rate", Scale: RateScale{Min: -10, Max: 10, Step: 5}}
+
+	tests := []struct {
+		rating int
+		err    error
+	}{
+		{-10, nil},
+		{0, nil},
+		{10, nil},
+		{-15, ErrInvalidBallot},
+		{15, ErrInvalidBallot},
+		{3, ErrInvalidBallot},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.rating), func(t *testing.T) {
+			checkValidation(t, ValidateRating(vote, tt.rating), tt.err)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		checkValidation(t, ValidateRating(&Vote{ID: 1, Category: "choice"}, 0), ErrWrongVoteType)
+	})
+}
+
+func TestValidateSupport(t *testing.T) {
+	tests := []struct {
+		support string
+		err     error
+	}{
+		{"for", nil},
+		{"against", nil},
+		{"", ErrInvalidBallot},
+		{"For", ErrInvalidBallot},
+		{"abstain", ErrInvalidBallot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.support, func(t *testing.T) {
+			checkValidation(t, ValidateSupport(tt.support), tt.err)
+		})
+	}
+}
+
+func TestValidateChoice(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "choice", Options: []VoteOption{{ID: 1}, {ID: 2}, {ID: 3}},
+		Selection: SelectionRange{Min: 1, Max: 2}}
+
+	tests := []struct {
+		name      string
+		optionIds []int
+		err       error
+	}{
+		{"minimum", []int{3}, nil},
+		{"maximum", []int{3, 1}, nil},
+		{"none", nil, ErrInvalidBallot},
+		{"above maximum", []int{1, 2, 3}, ErrInvalidBallot},
+		{"twice", []int{2, 2}, ErrInvalidBallot},
+		{"unknown", []int{4}, ErrInvalidOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, ValidateChoice(vote, tt.optionIds), tt.err)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		checkValidation(t, ValidateChoice(&Vote{ID: 1, Category: "ranked"}, []int{1}), ErrWrongVoteType)
+	})
+}
+
+func TestValidateRanking(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "ranked", Options: []VoteOption{{ID: 1}, {ID: 2}, {ID: 3}}}
+
+	tests := []struct {
+		name      string
+		optionIds []int
+		err       error
+	}{
+		{"one", []int{2}, nil},
+		{"all", []int{3, 1, 2}, nil},
+		{"none", nil, ErrInvalidBallot},
+		{"twice", []int{1, 2, 1}, ErrInvalidBallot},
+		{"unknown", []int{1, 4}, ErrInvalidOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, ValidateRanking(vote, tt.optionIds), tt.err)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		checkValidation(t, ValidateRanking(&Vote{ID: 1, Category: "choice"}, []int{1}), ErrWrongVoteType)
+	})
+}
+
+func TestValidateAllocation(t *testing.T) {
+	options := []VoteOption{{ID: 1}, {ID: 2}, {ID: 3}}
+	score := &Vote{ID: 1, Category: "score", Options: options, Budget: 10}
+	quadratic := &Vote{ID: 2, Category: "quadratic", Options: options, Budget: 10}
+
+	tests := []struct {
+		name        string
+		vote        *Vote
+		allocations []Allocation
+		err         error
+	}{
+		{"whole budget", score, []Allocation{{1, 6}, {3, 4}}, nil},
+		{"part of the budget", score, []Allocation{{2, 1}}, nil},
+		{"over budget", score, []Allocation{{1, 6}, {3, 5}}, ErrInvalidBallot},
+		{"quadratic whole budget", quadratic, []Allocation{{1, 3}, {2, 1}}, nil},
+		{"quadratic over budget", quadratic, []Allocation{{1, 3}, {2, 2}}, ErrInvalidBallot},
+		{"quadratic linear sum within budget", quadratic, []Allocation{{1, 4}}, ErrInvalidBallot},
+		{"none", score, nil, ErrInvalidBallot},
+		{"zero points", score, []Allocation{{1, 0}}, ErrInvalidBallot},
+		{"negative points", score, []Allocation{{1, 5}, {2, -3}}, ErrInvalidBallot},
+		{"twice", score, []Allocation{{1, 1}, {1, 1}}, ErrInvalidBallot},
+		{"unknown", score, []Allocation{{4, 1}}, ErrInvalidOption},
+		{"wrong type", &Vote{ID: 3, Category: "choice", Options: options}, []Allocation{{1, 1}}, ErrWrongVoteType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, ValidateAllocation(tt.vote, tt.allocations), tt.err)
+		})
+	}
+}
+
+func rating(value int) *int {
+	return &value
+}
+
+func TestValidateSurvey(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "survey", Questions: []Question{
+		{ID: 1, Kind: QuestionRating, Text: "How?", Required: true, Scale: RateScale{Min: 0, Max: 10, Step: 2}},
+		{ID: 2, Kind: QuestionSingle, Text: "Which?", Options: []VoteOption{{ID: 21}, {ID: 22}}},
+		{ID: 3, Kind: QuestionMulti, Text: "Which ones?", Options: []VoteOption{{ID: 31}, {ID: 32}}},
+		{ID: 4, Kind: QuestionText, Text: "Why?"},
+	}}
+
+	tests := []struct {
+		name    string
+		answers []SurveyAnswer
+		draft   bool
+		err     error
+	}{
+		{"complete", []SurveyAnswer{
+			{QuestionID: 1, Rating: rating(10)},
+			{QuestionID: 2, OptionIDs: []int{22}},
+			{QuestionID: 3, OptionIDs: []int{31, 32}},
+			{QuestionID: 4, Text: strings.Repeat("я", maxAnswerText)},
+		}, false, nil},
+		{"required only", []SurveyAnswer{{QuestionID: 1, Rating: rating(0)}}, false, nil},
+		{"required missing", []SurveyAnswer{{QuestionID: 4, Text: "Because"}}, false, ErrInvalidBallot},
+		{"draft without required", []SurveyAnswer{{QuestionID: 4, Text: "Because"}}, true, nil},
+		{"empty draft", nil, true, nil},
+		{"unknown question", []SurveyAnswer{{QuestionID: 1, Rating: rating(2)}, {QuestionID: 5, Text: "?"}}, false, ErrInvalidQuestion},
+		{"answered twice", []SurveyAnswer{{QuestionID: 1, Rating: rating(2)}, {QuestionID: 1, Rating: rating(4)}}, false, ErrInvalidBallot},
+		{"rating missing", []SurveyAnswer{{QuestionID: 1}}, false, ErrInvalidBallot},
+		{"rating with text", []SurveyAnswer{{QuestionID: 1, Rating: rating(2), Text: "ok"}}, false, ErrInvalidBallot},
+		{"rating above the scale", []SurveyAnswer{{QuestionID: 1, Rating: rating(12)}}, false, ErrInvalidBallot},
+		{"rating below the scale", []SurveyAnswer{{QuestionID: 1, Rating: rating(-2)}}, false, ErrInvalidBallot},
+		{"rating off step", []SurveyAnswer{{QuestionID: 1, Rating: rating(3)}}, false, ErrInvalidBallot},
+		{"single without option", []SurveyAnswer{{QuestionID: 2}}, true, ErrInvalidBallot},
+		{"single with two options", []SurveyAnswer{{QuestionID: 2, OptionIDs: []int{21, 22}}}, true, ErrInvalidBallot},
+		{"options with rating", []SurveyAnswer{{QuestionID: 3, OptionIDs: []int{31}, Rating: rating(2)}}, true, ErrInvalidBallot},
+		{"option of another question", []SurveyAnswer{{QuestionID: 3, OptionIDs: []int{21}}}, true, ErrInvalidOption},
+		{"option twice", []SurveyAnswer{{QuestionID: 3, OptionIDs: []int{31, 31}}}, true, ErrInvalidBallot},
+		{"blank text", []SurveyAnswer{{QuestionID: 4, Text: " \n"}}, true, ErrInvalidBallot},
+		{"text with options", []SurveyAnswer{{QuestionID: 4, Text: "ok", OptionIDs: []int{31}}}, true, ErrInvalidBallot},
+		{"text too long", []SurveyAnswer{{QuestionID: 4, Text: strings.Repeat("я", maxAnswerText+1)}}, true, ErrInvalidBallot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, ValidateSurvey(vote, tt.answers, tt.draft), tt.err)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		checkValidation(t, ValidateSurvey(&Vote{ID: 1, Category: "rate"}, nil, true), ErrWrongVoteType)
+	})
+}
+
+func TestValidateFollowUpAnswers(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "rate", FollowUps: []FollowUp{
+		{ID: 1, Kind: QuestionText, Text: "Why?"},
+		{ID: 2, Kind: QuestionRating, Text: "How much?", Scale: DefaultRateScale},
+	}}
+
+	tests := []struct {
+		name    string
+		answers []SurveyAnswer
+		err     error
+	}{
+		{"one", []SurveyAnswer{{QuestionID: 2, Rating: rating(5)}}, nil},
+		{"both", []SurveyAnswer{{QuestionID: 1, Text: "Because"}, {QuestionID: 2, Rating: rating(1)}}, nil},
+		{"none", nil, ErrInvalidBallot},
+		{"unknown", []SurveyAnswer{{QuestionID: 3, Text: "?"}}, ErrInvalidQuestion},
+		{"twice", []SurveyAnswer{{QuestionID: 1, Text: "a"}, {QuestionID: 1, Text: "b"}}, ErrInvalidBallot},
+		{"wrong kind", []SurveyAnswer{{QuestionID: 1, Rating: rating(3)}}, ErrInvalidBallot},
+		{"off the scale", []SurveyAnswer{{QuestionID: 2, Rating: rating(6)}}, ErrInvalidBallot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidation(t, ValidateFollowUpAnswers(vote, tt.answers), tt.err)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		answers := []SurveyAnswer{{QuestionID: 1, Text: "a"}}
+		checkValidation(t, ValidateFollowUpAnswers(&Vote{ID: 1, Category: "petition"}, answers), ErrWrongVoteType)
+	})
+}
+
+func TestFindOption(t *testing.T) {
+	vote := &Vote{ID: 1, Category: "choice", Options: []VoteOption{{ID: 5, Text: "A"}, {ID: 6, Text: "B"}}}
+
+	tests := []struct {
+		name     string
+		optionId int
+		text     string
+		want     int
+		err      error
+	}{
+		{"by id", 6, "", 6, nil},
+		{"id wins over text", 5, "B", 5, nil},
+		{"by text", 0, "B", 6, nil},
+		{"unknown id", 7, "A", 0, ErrInvalidOption},
+		{"unknown text", 0, "C", 0, ErrInvalidOption},
+		{"nothing", 0, "", 0, ErrInvalidOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindOption(vote, tt.optionId, tt.text)
+			checkValidation(t, err, tt.err)
+			if got != tt.want {
+				t.Errorf("got option %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := FindOption(&Vote{ID: 1, Category: "rate"}, 5, "")
+		checkValidation(t, err, ErrWrongVoteType)
+	})
+}
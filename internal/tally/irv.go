@@ -0,0 +1,129 @@
+// Package tally computes results of vote types whose outcome is more than a
+// simple count per option.
+package tally
+
+// Round is one counting round of an instant-runoff tally. Votes holds the
+// number of ballots whose highest continuing preference is each option;
+// options eliminated in earlier rounds are absent.
+type Round struct {
+	Number     int
+	Votes      map[int]int
+	Exhausted  int
+	Eliminated []int
+	Winner     int
+}
+
+// RunoffResult is the outcome of an instant-runoff tally. Winner is zero
+// when there are no ballots or none of them ranks an option of the vote.
+type RunoffResult struct {
+	Rounds []Round
+	Winner int
+}
+
+// InstantRunoff tallies ranked ballots over options, given in display order.
+// Each ballot lists option IDs from most to least preferred and may rank
+// only some of the options; unknown IDs are ignored. Every round the ballots
+// count for their highest continuing preference, and the option with the
+// fewest votes is eliminated until one option holds a majority of the
+// ballots that are not exhausted.
+//
+// Ties for last place are broken by the earlier rounds, eliminating the
+// option that had fewer votes most recently, and finally by display order,
+// eliminating the later option.
+func InstantRunoff(options []int, ballots [][]int) RunoffResult {
+	var result RunoffResult
+	if len(options) == 0 || len(ballots) == 0 {
+		return result
+	}
+
+	continuing := make(map[int]bool, len(options))
+	for _, id := range options {
+		continuing[id] = true
+	}
+
+	for number := 1; ; number++ {
+		round := Round{Number: number, Votes: make(map[int]int, len(continuing))}
+		for id := range continuing {
+			round.Votes[id] = 0
+		}
+		active := 0
+		for _, ballot := range ballots {
+			if id, ok := topPreference(ballot, continuing); ok {
+				round.Votes[id]++
+				active++
+			} else {
+				round.Exhausted++
+			}
+		}
+
+		if active == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+		if leader, votes := leading(options, round.Votes); len(continuing) == 1 || votes*2 > active {
+			round.Winner = leader
+			result.Rounds = append(result.Rounds, round)
+			result.Winner = leader
+			return result
+		}
+
+		loser := trailing(options, round.Votes, result.Rounds)
+		round.Eliminated = []int{loser}
+		delete(continuing, loser)
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+func topPreference(ballot []int, continuing map[int]bool) (int, bool) {
+	for _, id := range ballot {
+		if continuing[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// leading returns the option with the most votes, preferring the earlier
+// option on ties.
+func leading(options []int, votes map[int]int) (int, int) {
+	best, bestVotes := 0, -1
+	for _, id := range options {
+		if v, ok := votes[id]; ok && v > bestVotes {
+			best, bestVotes = id, v
+		}
+	}
+	return best, bestVotes
+}
+
+func trailing(options []int, votes map[int]int, previous []Round) int {
+	var tied []int
+	fewest := -1
+	for _, id := range options {
+		v, ok := votes[id]
+		if !ok {
+			continue
+		}
+		switch {
+		case fewest == -1 || v < fewest:
+			tied, fewest = []int{id}, v
+		case v == fewest:
+			tied = append(tied, id)
+		}
+	}
+
+	for i := len(previous) - 1; i >= 0 && len(tied) > 1; i-- {
+		var narrowed []int
+		fewest := -1
+		for _, id := range tied {
+			v := previous[i].Votes[id]
+			switch {
+			case fewest == -1 || v < fewest:
+				narrowed, fewest = []int{id}, v
+			case v == fewest:
+				narrowed = append(narrowed, id)
+			}
+		}
+		tied = narrowed
+	}
+	return tied[len(tied)-1]
+}
@@ -0,0 +1,162 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+)
+
+func repeat(ballot []int, n int) [][]int {
+	ballots := make([][]int, n)
+	for i := range ballots {
+		ballots[i] = ballot
+	}
+	return ballots
+}
+
+func join(groups ...[][]int) [][]int {
+	var ballots [][]int
+	for _, group := range groups {
+		ballots = append(ballots, group...)
+	}
+	return ballots
+}
+
+func TestInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    []int
+		ballots    [][]int
+		winner     int
+		eliminated [][]int
+		exhausted  []int
+	}{
+		{
+			name:    "no ballots",
+			options: []int{1, 2},
+		},
+		{
+			name:       "single option",
+			options:    []int{1},
+			ballots:    [][]int{{1}, {1}},
+			winner:     1,
+			eliminated: [][]int{nil},
+			exhausted:  []int{0},
+		},
+		{
+			name:       "outright majority",
+			options:    []int{1, 2, 3},
+			ballots:    [][]int{{1, 2}, {1}, {2, 1}},
+			winner:     1,
+			eliminated: [][]int{nil},
+			exhausted:  []int{0},
+		},
+		{
+			name:    "multi-round elimination",
+			options: []int{1, 2, 3, 4},
+			ballots: join(repeat([]int{1}, 3), repeat([]int{2}, 2), [][]int{{3, 2}, {4, 2}}),
+			// 4 and 3 tie on one vote in the first round; 4 comes later.
+			winner:     2,
+			eliminated: [][]int{{4}, {3}, nil},
+			exhausted:  []int{0, 0, 0},
+		},
+		{
+			name:       "tie broken by an earlier round",
+			options:    []int{1, 2, 3, 4},
+			ballots:    join(repeat([]int{1}, 4), repeat([]int{2, 3}, 2), repeat([]int{3}, 3), [][]int{{4, 2, 3}}),
+			winner:     3,
+			eliminated: [][]int{{4}, {2}, nil},
+			exhausted:  []int{0, 0, 0},
+		},
+		{
+			name:       "tie broken by display order",
+			options:    []int{1, 2, 3},
+			ballots:    [][]int{{1}, {1}, {2}, {3}},
+			winner:     1,
+			eliminated: [][]int{{3}, nil},
+			exhausted:  []int{0, 1},
+		},
+		{
+			name:       "exhausted ballots leave the majority",
+			options:    []int{1, 2, 3},
+			ballots:    join(repeat([]int{1}, 3), repeat([]int{2}, 2), repeat([]int{3}, 2)),
+			winner:     1,
+			eliminated: [][]int{{3}, nil},
+			exhausted:  []int{0, 2},
+		},
+		{
+			name:       "all ballots exhausted",
+			options:    []int{1, 2},
+			ballots:    [][]int{{7}, {8, 9}},
+			eliminated: [][]int{nil},
+			exhausted:  []int{2},
+		},
+		{
+			name:       "unknown options are skipped",
+			options:    []int{1, 2},
+			ballots:    [][]int{{9, 2}, {2}, {1, 9}},
+			winner:     2,
+			eliminated: [][]int{nil},
+			exhausted:  []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := InstantRunoff(tt.options, tt.ballots)
+			if result.Winner != tt.winner {
+				t.Errorf("got winner %d, want %d", result.Winner, tt.winner)
+			}
+			if len(result.Rounds) != len(tt.eliminated) {
+				t.Fatalf("got %d rounds, want %d", len(result.Rounds), len(tt.eliminated))
+			}
+			for i, round := range result.Rounds {
+				if round.Number != i+1 {
+					t.Errorf("round %d is numbered %d", i+1, round.Number)
+				}
+				if !reflect.DeepEqual(round.Eliminated, tt.eliminated[i]) {
+					t.Errorf("round %d eliminated %v, want %v", i+1, round.Eliminated, tt.eliminated[i])
+				}
+				if round.Exhausted != tt.exhausted[i] {
+					t.Errorf("round %d has %d exhausted ballots, want %d", i+1, round.Exhausted, tt.exhausted[i])
+				}
+				counted := round.Exhausted
+				for _, votes := range round.Votes {
+					counted += votes
+				}
+				if counted != len(tt.ballots) {
+					t.Errorf("round %d counts %d ballots, want %d", i+1, counted, len(tt.ballots))
+				}
+			}
+			if last := len(result.Rounds) - 1; last >= 0 && result.Rounds[last].Winner != tt.winner {
+				t.Errorf("last round has winner %d, want %d", result.Rounds[last].Winner, tt.winner)
+			}
+		})
+	}
+}
+
+func TestTrailing(t *testing.T) {
+	options := []int{1, 2, 3}
+
+	tests := []struct {
+		name     string
+		votes    map[int]int
+		previous []Round
+		want     int
+	}{
+		{"fewest", map[int]int{1: 3, 2: 1, 3: 2}, nil, 2},
+		{"eliminated options are skipped", map[int]int{1: 3, 3: 2}, nil, 3},
+		{"display order", map[int]int{1: 1, 2: 1, 3: 1}, nil, 3},
+		{"earlier round", map[int]int{1: 2, 2: 2, 3: 4}, []Round{{Votes: map[int]int{1: 1, 2: 2, 3: 3}}}, 1},
+		{"most recent round first", map[int]int{1: 2, 2: 2, 3: 4}, []Round{
+			{Votes: map[int]int{1: 1, 2: 2, 3: 1}},
+			{Votes: map[int]int{1: 2, 2: 1, 3: 3}},
+		}, 2},
+		{"tied in every round", map[int]int{1: 2, 2: 2, 3: 4}, []Round{{Votes: map[int]int{1: 1, 2: 1, 3: 3}}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trailing(options, tt.votes, tt.previous); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
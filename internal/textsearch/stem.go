@@ -0,0 +1,142 @@
+package textsearch
+
+import "strings"
+
+// Suffix groups of the Snowball Russian stemmer, the algorithm behind the
+// "russian" text search configuration of PostgreSQL. Endings of the first
+// group of a class only apply after а or я, which is kept.
+var (
+	perfectiveGerund1 = []string{"в", "вши", "вшись"}
+	perfectiveGerund2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+	adjective         = []string{"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею"}
+	participle1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	participle2 = []string{"ивш", "ывш", "ующ"}
+	reflexive   = []string{"ся", "сь"}
+	verb1       = []string{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"}
+	verb2       = []string{"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю"}
+	noun = []string{"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я"}
+	superlative   = []string{"ейш", "ейше"}
+	derivational  = []string{"ост", "ость"}
+	russianVowels = "аеиоуыэюя"
+)
+
+// Stem reduces a Russian word to its stem. The word is lower-cased and ё is
+// folded into е first; words without Cyrillic letters are returned in
+// lower case unchanged.
+func Stem(word string) string {
+	w := []rune(strings.ReplaceAll(strings.ToLower(word), "ё", "е"))
+	rv, r2 := regions(w)
+	if rv >= len(w) {
+		return string(w)
+	}
+
+	// Step 1: perfective gerund, or reflexive followed by an adjectival,
+	// verb or noun ending.
+	if n := suffix(w, rv, perfectiveGerund1, perfectiveGerund2); n > 0 {
+		w = w[:len(w)-n]
+	} else {
+		w = w[:len(w)-suffix(w, rv, nil, reflexive)]
+		if n := adjectival(w, rv); n > 0 {
+			w = w[:len(w)-n]
+		} else if n := suffix(w, rv, verb1, verb2); n > 0 {
+			w = w[:len(w)-n]
+		} else {
+			w = w[:len(w)-suffix(w, rv, nil, noun)]
+		}
+	}
+
+	// Step 2.
+	if hasSuffix(w, rv, "и") {
+		w = w[:len(w)-1]
+	}
+
+	// Step 3: derivational endings must lie in R2.
+	w = w[:len(w)-suffix(w, max(rv, r2), nil, derivational)]
+
+	// Step 4.
+	switch {
+	case hasSuffix(w, rv, "нн"):
+		w = w[:len(w)-1]
+	case suffix(w, rv, nil, superlative) > 0:
+		w = w[:len(w)-suffix(w, rv, nil, superlative)]
+		if hasSuffix(w, rv, "нн") {
+			w = w[:len(w)-1]
+		}
+	case hasSuffix(w, rv, "ь"):
+		w = w[:len(w)-1]
+	}
+	return string(w)
+}
+
+// regions returns the start of RV, the part after the first vowel, and of
+// R2, the second region following a vowel and a non-vowel.
+func regions(w []rune) (rv, r2 int) {
+	rv = len(w)
+	for i, r := range w {
+		if isVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+	return rv, nextRegion(w, nextRegion(w, 0))
+}
+
+func nextRegion(w []rune, from int) int {
+	for i := from + 1; i < len(w); i++ {
+		if !isVowel(w[i]) && isVowel(w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune(russianVowels, r)
+}
+
+// adjectival matches an adjective ending, optionally preceded by a
+// participle ending, and returns the length of both.
+func adjectival(w []rune, rv int) int {
+	n := suffix(w, rv, nil, adjective)
+	if n == 0 {
+		return 0
+	}
+	return n + suffix(w[:len(w)-n], rv, participle1, participle2)
+}
+
+// suffix finds the longest ending of either group within w[from:] and
+// returns its length, or 0. Like the Snowball among operator it does not
+// fall back to a shorter ending when a first-group ending is not preceded
+// by а or я.
+func suffix(w []rune, from int, afterA, plain []string) int {
+	best, bestAfterA := 0, false
+	for _, group := range []struct {
+		endings []string
+		afterA  bool
+	}{{afterA, true}, {plain, false}} {
+		for _, ending := range group.endings {
+			if n := len([]rune(ending)); n > best && hasSuffix(w, from, ending) {
+				best, bestAfterA = n, group.afterA
+			}
+		}
+	}
+	if best == 0 || !bestAfterA {
+		return best
+	}
+	i := len(w) - best - 1
+	if i >= from && (w[i] == 'а' || w[i] == 'я') {
+		return best
+	}
+	return 0
+}
+
+func hasSuffix(w []rune, from int, ending string) bool {
+	e := []rune(ending)
+	if len(w)-len(e) < from {
+		return false
+	}
+	return string(w[len(w)-len(e):]) == ending
+}
@@ -0,0 +1,261 @@
+// Package textsearch is an in-process approximation of the PostgreSQL
+// "russian" full-text search and pg_trgm word similarity. MemoryStorage
+// uses it so that search behaves close to production without a database;
+// ranks and snippets are comparable but not byte-identical.
+package textsearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are skipped when indexing and querying, like the Snowball
+// Russian stop list used by PostgreSQL.
+var stopWords = toSet(strings.Fields(`
+	и в во не что он на я с со как а то все она так его но да ты к у же вы за бы по только ее мне было вот
+	от меня еще нет о из ему теперь когда даже ну вдруг ли если уже или ни быть был него до вас нибудь опять
+	уж вам ведь там потом себя ничего ей может они тут где есть надо ней для мы тебя их чем была сам чтоб без
+	будто чего раз тоже себе под будет ж тогда кто этот того потому этого какой совсем ним здесь этом один
+	почти мой тем чтобы нее сейчас были куда зачем всех никогда можно при наконец два об другой хоть после
+	над больше тот через эти нас про всего них какая много разве три эту моя впрочем хорошо свою этой перед
+	иногда лучше чуть том нельзя такой им более всегда конечно всю между`))
+
+func toSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// token is a word of a text with its byte offsets.
+type token struct {
+	word       string
+	start, end int
+}
+
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			tokens = append(tokens, token{text[start:i], start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text[start:], start, len(text)})
+	}
+	return tokens
+}
+
+// lexeme returns the normalised form of word, or "" for a stop word.
+func lexeme(word string) string {
+	lower := strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	if stopWords[lower] {
+		return ""
+	}
+	return Stem(lower)
+}
+
+// Query is a parsed search query in the websearch_to_tsquery syntax:
+// words must all match, "or" separates alternatives and a leading minus
+// excludes a word. Quotes are accepted but phrases match as plain words.
+type Query struct {
+	groups   [][]string
+	excluded []string
+}
+
+// ParseQuery parses text typed by a user. It never fails: anything it
+// cannot use is ignored.
+func ParseQuery(text string) Query {
+	var q Query
+	var group []string
+	fields := strings.Fields(strings.NewReplacer(`"`, " ", "«", " ", "»", " ").Replace(text))
+	for _, field := range fields {
+		if strings.EqualFold(field, "or") {
+			if len(group) > 0 {
+				q.groups = append(q.groups, group)
+			}
+			group = nil
+			continue
+		}
+		negated := strings.HasPrefix(field, "-")
+		for _, t := range tokenize(field) {
+			lex := lexeme(t.word)
+			if lex == "" {
+				continue
+			}
+			if negated {
+				q.excluded = append(q.excluded, lex)
+			} else {
+				group = append(group, lex)
+			}
+		}
+	}
+	if len(group) > 0 {
+		q.groups = append(q.groups, group)
+	}
+	return q
+}
+
+// Empty reports whether the query has no searchable words, for example
+// when it only consists of stop words.
+func (q Query) Empty() bool {
+	return len(q.groups) == 0
+}
+
+// Field is a part of a document with its rank weight. PostgreSQL uses 1.0,
+// 0.4, 0.2 and 0.1 for the weights A to D.
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// Rank scores a document against the query; 0 means no match. Every
+// occurrence of a query word counts with the weight of its field.
+func (q Query) Rank(fields ...Field) float64 {
+	lexemes := make(map[string]float64)
+	for _, field := range fields {
+		for _, t := range tokenize(field.Text) {
+			if lex := lexeme(t.word); lex != "" {
+				lexemes[lex] += field.Weight
+			}
+		}
+	}
+	for _, lex := range q.excluded {
+		if lexemes[lex] > 0 {
+			return 0
+		}
+	}
+
+	var best float64
+	for _, group := range q.groups {
+		var rank float64
+		for _, lex := range group {
+			if lexemes[lex] == 0 {
+				rank = 0
+				break
+			}
+			rank += lexemes[lex]
+		}
+		best = max(best, rank)
+	}
+	return best
+}
+
+// Headline wraps the words of text that match the query in start and stop.
+// With maxWords > 0 only a fragment of that many words around the first
+// match is returned, as ts_headline does for long texts.
+func (q Query) Headline(text, start, stop string, maxWords int) string {
+	wanted := make(map[string]bool)
+	for _, group := range q.groups {
+		for _, lex := range group {
+			wanted[lex] = true
+		}
+	}
+
+	tokens := tokenize(text)
+	from, to := 0, len(tokens)
+	if maxWords > 0 && len(tokens) > maxWords {
+		first := 0
+		for i, t := range tokens {
+			if wanted[lexeme(t.word)] {
+				first = i
+				break
+			}
+		}
+		from = max(0, min(first-maxWords/4, len(tokens)-maxWords))
+		to = from + maxWords
+	}
+	if len(tokens) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	pos := tokens[from].start
+	if from == 0 {
+		pos = 0
+	}
+	for _, t := range tokens[from:to] {
+		b.WriteString(text[pos:t.start])
+		if wanted[lexeme(t.word)] {
+			b.WriteString(start + t.word + stop)
+		} else {
+			b.WriteString(t.word)
+		}
+		pos = t.end
+	}
+	if to == len(tokens) {
+		b.WriteString(text[pos:])
+	}
+	return b.String()
+}
+
+// FirstWords returns the first n words of text separated by single spaces.
+func FirstWords(text string, n int) string {
+	words := strings.Fields(text)
+	if len(words) > n {
+		words = words[:n]
+	}
+	return strings.Join(words, " ")
+}
+
+// WordSimilarity approximates pg_trgm word_similarity: for every word of
+// query it takes the share of its trigrams found in the closest word of
+// text, and averages the result over the query words.
+func WordSimilarity(query, text string) float64 {
+	var words [][]string
+	for _, t := range tokenize(text) {
+		words = append(words, trigrams(t.word))
+	}
+
+	var sum float64
+	var count int
+	for _, t := range tokenize(query) {
+		wanted := trigrams(t.word)
+		var best float64
+		for _, candidate := range words {
+			best = max(best, float64(shared(wanted, candidate))/float64(len(wanted)))
+		}
+		sum += best
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// trigrams returns the distinct trigrams of a word padded like pg_trgm
+// does: two spaces in front and one at the end.
+func trigrams(word string) []string {
+	r := []rune("  " + strings.ToLower(word) + " ")
+	seen := make(map[string]bool)
+	var result []string
+	for i := 0; i+3 <= len(r); i++ {
+		trigram := string(r[i : i+3])
+		if !seen[trigram] {
+			seen[trigram] = true
+			result = append(result, trigram)
+		}
+	}
+	return result
+}
+
+func shared(a, b []string) int {
+	var n int
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
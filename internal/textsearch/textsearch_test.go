@@ -0,0 +1,138 @@
+package textsearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		text     string
+		groups   [][]string
+		excluded []string
+	}{
+		{"новый парк", [][]string{{"нов", "парк"}}, nil},
+		{"парк or мост", [][]string{{"парк"}, {"мост"}}, nil},
+		{"парк OR", [][]string{{"парк"}}, nil},
+		{`"новые парки" -мост`, [][]string{{"нов", "парк"}}, []string{"мост"}},
+		{"«Ёлки»", [][]string{{"елк"}}, nil},
+		{"и в на", nil, nil},
+		{"-мост", nil, []string{"мост"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			q := ParseQuery(tt.text)
+			if !reflect.DeepEqual(q.groups, tt.groups) || !reflect.DeepEqual(q.excluded, tt.excluded) {
+				t.Errorf("got groups %q excluding %q, want %q excluding %q", q.groups, q.excluded, tt.groups, tt.excluded)
+			}
+			if q.Empty() != (len(tt.groups) == 0) {
+				t.Errorf("Empty is %v for groups %q", q.Empty(), tt.groups)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	name, description := "Новый парк", "Парки и скверы города"
+	tests := []struct {
+		query string
+		want  float64
+	}{
+		{"парк", 1.4},
+		{"скверы", 0.4},
+		{"новый сквер", 1.4},
+		{"новый мост", 0},
+		{"мост or сквер", 0.4},
+		{"парк -сквер", 0},
+		{"и", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := ParseQuery(tt.query).Rank(Field{Text: name, Weight: 1}, Field{Text: description, Weight: 0.4})
+			if got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("got rank %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		text     string
+		maxWords int
+		want     string
+	}{
+		{"whole text", "парк", "Новый парк у реки", 0, "Новый [парк] у реки"},
+		{"word forms", "парки", "Парк, парки и парков.", 0, "[Парк], [парки] и [парков]."},
+		{"no match", "мост", "Новый парк", 0, "Новый парк"},
+		{"fragment", "парк", "один два три четыре пять шесть семь восемь парк девять десять", 4, "восемь [парк] девять десять"},
+		{"short text", "парк", "Парк у реки", 4, "[Парк] у реки"},
+		{"no words", "парк", "...", 4, "..."},
+		{"markup is text", "lt", "&lt;b&gt; парк", 0, "&[lt];b&gt; парк"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseQuery(tt.query).Headline(tt.text, "[", "]", tt.maxWords); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstWords(t *testing.T) {
+	if got := FirstWords("  один   два\nтри четыре ", 3); got != "один два три" {
+		t.Errorf("got %q, want the first three words", got)
+	}
+	if got := FirstWords("один", 3); got != "один" {
+		t.Errorf("got %q for a short text", got)
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        float64
+	}{
+		{"парк", "Новый парк", 1},
+		{"ПАРК", "Новый парк", 1},
+		{"библиотка", "Часы работы библиотеки", 0.7},
+		{"прак", "Новый парк", 0.2},
+		{"мост", "Новый парк", 0},
+		{"", "Новый парк", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := WordSimilarity(tt.query, tt.text)
+			if got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("got similarity %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	want := []string{"  к", " ко", "кот", "от "}
+	if got := trigrams("Кот"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := trigrams("ааа"); len(got) != 4 {
+		t.Errorf("got %q, want each trigram once", got)
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"парками":    "парк",
+		"библиотеки": "библиотек",
+		"новые":      "нов",
+		"Ёлки":       "елк",
+		"park":       "park",
+	}
+	for word, want := range tests {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) is %q, want %q", word, got, want)
+		}
+	}
+}